@@ -9,6 +9,7 @@ import (
 	"os/signal"
 	"syscall"
 	"time"
+	_ "time/tzdata" // fusos horários dos restaurantes mesmo em imagens sem tzdata
 
 	"github.com/gin-gonic/gin"
 	"github.com/yurisasc/algafood-go/internal/api"
@@ -17,9 +18,15 @@ import (
 	"github.com/yurisasc/algafood-go/internal/domain/service"
 	"github.com/yurisasc/algafood-go/internal/infrastructure/email"
 	"github.com/yurisasc/algafood-go/internal/infrastructure/eventbridge"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/migration"
 	"github.com/yurisasc/algafood-go/internal/infrastructure/notification"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/outbox"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/payment"
 	infraRepo "github.com/yurisasc/algafood-go/internal/infrastructure/repository"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/scheduler"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/security"
 	"github.com/yurisasc/algafood-go/internal/infrastructure/sqs"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/storage"
 )
 
 func main() {
@@ -39,6 +46,12 @@ func main() {
 	}
 	log.Println("Connected to database successfully")
 
+	if cfg.Database.AutoMigrate {
+		if err := migration.AplicarPendentes(appCtx, &cfg.Database); err != nil {
+			log.Fatalf("Failed to apply database migrations: %v", err)
+		}
+	}
+
 	// Initialize repositories
 	estadoRepo := infraRepo.NewEstadoRepository(db)
 	cidadeRepo := infraRepo.NewCidadeRepository(db)
@@ -49,17 +62,42 @@ func main() {
 	usuarioRepo := infraRepo.NewUsuarioRepository(db)
 	restauranteRepo := infraRepo.NewRestauranteRepository(db)
 	produtoRepo := infraRepo.NewProdutoRepository(db)
+	fotoProdutoRepo := infraRepo.NewFotoProdutoRepository(db)
+	horarioRepo := infraRepo.NewHorarioFuncionamentoRepository(db)
+	excecaoHorarioRepo := infraRepo.NewExcecaoHorarioRepository(db)
+	freteRepo := infraRepo.NewFreteRepository(db)
+	cupomRepo := infraRepo.NewCupomRepository(db)
+	grupoOpcoesRepo := infraRepo.NewGrupoOpcoesRepository(db)
 	pedidoRepo := infraRepo.NewPedidoRepository(db)
+	pagamentoRepo := infraRepo.NewPagamentoRepository(db)
+	avaliacaoRepo := infraRepo.NewAvaliacaoRepository(db)
+	enderecoUsuarioRepo := infraRepo.NewEnderecoUsuarioRepository(db)
+	favoritoRepo := infraRepo.NewFavoritoRepository(db)
 	vendaQueryRepo := infraRepo.NewVendaQueryRepository(db)
+	eventoOutboxRepo := infraRepo.NewEventoOutboxRepository(db)
+	refreshTokenRepo := infraRepo.NewRefreshTokenRepository(db)
+	tokenUsuarioRepo := infraRepo.NewTokenUsuarioRepository(db)
 
 	// Initialize services
-	authSvc := service.NewAuthService(&cfg.JWT)
-	tokenBlacklistSvc := service.NewTokenBlacklistService(&cfg.Redis, &cfg.JWT)
+	tokenBlacklistSvc := service.NewTokenBlacklistService(&cfg.Redis)
+
+	// Chaves de assinatura dos tokens (RS256/ES256 com PEM, ou HS256 com secret_key)
+	keySet, err := security.LoadKeySet(&cfg.JWT)
+	if err != nil {
+		log.Fatalf("Failed to load JWT keys: %v", err)
+	}
+	tokenValidator, err := security.NewTokenValidator(cfg, keySet)
+	if err != nil {
+		log.Fatalf("Failed to configure token validation: %v", err)
+	}
 
 	// Initialize cache services
 	userCacheSvc := service.NewUserCacheService(&cfg.Redis)
 	locationCacheSvc := service.NewLocationCacheService(&cfg.Redis)
 	businessCacheSvc := service.NewBusinessCacheService(&cfg.Redis)
+	idempotencySvc := service.NewIdempotencyService(&cfg.Redis)
+	rateLimitSvc := service.NewRateLimitService(&cfg.Redis, &cfg.RateLimit)
+	carrinhoCacheSvc := service.NewCarrinhoCacheService(&cfg.Redis, time.Duration(cfg.Pedido.CarrinhoTTLHours)*time.Hour)
 
 	// Verifica conexão com Redis
 	if err := tokenBlacklistSvc.Ping(); err != nil {
@@ -74,10 +112,42 @@ func main() {
 	formaPagamentoSvc := service.NewFormaPagamentoService(formaPagamentoRepo, businessCacheSvc)
 	permissaoSvc := service.NewPermissaoService(permissaoRepo)
 	grupoSvc := service.NewGrupoService(grupoRepo, permissaoSvc)
-	usuarioSvc := service.NewUsuarioService(usuarioRepo, grupoSvc, userCacheSvc)
+	usuarioSvc := service.NewUsuarioService(usuarioRepo, refreshTokenRepo, grupoSvc, userCacheSvc, rateLimitSvc)
+	authSvc := service.NewAuthService(&cfg.JWT, keySet, refreshTokenRepo, usuarioSvc)
 	restauranteSvc := service.NewRestauranteService(restauranteRepo, cozinhaSvc, cidadeSvc, formaPagamentoSvc, usuarioSvc, businessCacheSvc)
-	produtoSvc := service.NewProdutoService(produtoRepo, restauranteSvc)
-	pedidoSvc := service.NewPedidoService(pedidoRepo, restauranteSvc, cidadeSvc, usuarioSvc, produtoSvc, formaPagamentoSvc)
+	produtoSvc := service.NewProdutoService(produtoRepo, restauranteSvc, carrinhoCacheSvc)
+	// Initialize storage service
+	storageSvc, err := storage.NewStorageService(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("Failed to initialize storage service: %v", err)
+	}
+	fotoProdutoSvc := service.NewFotoProdutoService(fotoProdutoRepo, produtoSvc, storageSvc)
+	horarioSvc := service.NewHorarioFuncionamentoService(horarioRepo, excecaoHorarioRepo, restauranteSvc)
+
+	// Start opening hours scheduler
+	aberturaScheduler := scheduler.NewAberturaScheduler(&cfg.Horario, horarioSvc)
+	aberturaScheduler.Start(appCtx)
+
+	freteSvc := service.NewFreteService(freteRepo, restauranteSvc, cidadeSvc,
+		service.NewFreteFixoEstrategia(),
+		service.NewFreteLocalidadeEstrategia(),
+		service.NewFreteDistanciaEstrategia(),
+	)
+	cupomSvc := service.NewCupomService(cupomRepo, restauranteSvc)
+	grupoOpcoesSvc := service.NewGrupoOpcoesService(grupoOpcoesRepo, produtoSvc)
+	enderecoUsuarioSvc := service.NewEnderecoUsuarioService(enderecoUsuarioRepo, cidadeSvc)
+	favoritoSvc := service.NewFavoritoService(favoritoRepo, restauranteSvc, produtoSvc)
+
+	pedidoSvc := service.NewPedidoService(pedidoRepo, restauranteSvc, cidadeSvc, usuarioSvc, produtoSvc, formaPagamentoSvc, freteSvc, cupomSvc, grupoOpcoesSvc, enderecoUsuarioSvc,
+		service.NewClienteVerificadoValidador(),
+		service.NewRestauranteDisponivelValidador(),
+		service.NewProdutosAtivosValidador(),
+		service.NewQuantidadeMaximaItemValidador(cfg.Pedido.MaxQuantidadePorItem),
+		service.NewValorMinimoPedidoValidador(),
+		service.NewAreaEntregaValidador(restauranteSvc),
+	)
+
+	carrinhoSvc := service.NewCarrinhoService(carrinhoCacheSvc, pedidoSvc, restauranteSvc, produtoSvc, grupoOpcoesSvc)
 
 	// Initialize event publisher
 	eventPublisher, err := eventbridge.NewEventPublisher(&cfg.EventBridge, &cfg.SQS, &cfg.AWS)
@@ -88,7 +158,25 @@ func main() {
 		log.Println("EventBridge publisher initialized successfully")
 	}
 
-	fluxoPedidoSvc := service.NewFluxoPedidoService(pedidoRepo, pedidoSvc, eventPublisher)
+	// Initialize payment provider
+	paymentProvider, err := payment.NewPaymentProvider(&cfg.Pagamento)
+	if err != nil {
+		log.Fatalf("Failed to initialize payment provider: %v", err)
+	}
+	pagamentoSvc := service.NewPagamentoService(pagamentoRepo, paymentProvider, cfg.Pagamento.Moeda)
+
+	// Start refund scheduler
+	estornoScheduler := scheduler.NewEstornoScheduler(&cfg.Pagamento, pagamentoSvc)
+	estornoScheduler.Start(appCtx)
+
+	pedidoStreamSvc := service.NewPedidoStreamService(&cfg.Redis)
+	fluxoPedidoSvc := service.NewFluxoPedidoService(pedidoRepo, pedidoSvc, pedidoStreamSvc, pagamentoSvc)
+	avaliacaoSvc := service.NewAvaliacaoService(avaliacaoRepo, pedidoSvc, restauranteSvc, businessCacheSvc)
+	eventoOutboxSvc := service.NewEventoOutboxService(eventoOutboxRepo, eventPublisher, &cfg.Outbox)
+
+	// Start outbox relay
+	outboxRelay := outbox.NewRelay(&cfg.Outbox, eventoOutboxSvc)
+	outboxRelay.Start(appCtx)
 
 	// Initialize email service
 	emailSvc, err := email.NewEmailService(&cfg.Email, &cfg.AWS)
@@ -98,6 +186,7 @@ func main() {
 	} else {
 		log.Println("Email service initialized successfully")
 	}
+	contaSvc := service.NewContaService(&cfg.Conta, tokenUsuarioRepo, refreshTokenRepo, usuarioSvc, emailSvc)
 
 	// Initialize SQS listener for notifications
 	notificationHandler := notification.NewNotificationHandler(emailSvc)
@@ -120,15 +209,32 @@ func main() {
 	formaPagamentoHandler := handler.NewFormaPagamentoHandler(formaPagamentoSvc)
 	permissaoHandler := handler.NewPermissaoHandler(permissaoSvc)
 	grupoHandler := handler.NewGrupoHandler(grupoSvc)
-	usuarioHandler := handler.NewUsuarioHandler(usuarioSvc, authSvc, tokenBlacklistSvc)
+	usuarioHandler := handler.NewUsuarioHandler(usuarioSvc, authSvc, contaSvc, tokenBlacklistSvc)
 	restauranteHandler := handler.NewRestauranteHandler(restauranteSvc)
 	produtoHandler := handler.NewProdutoHandler(produtoSvc)
-	pedidoHandler := handler.NewPedidoHandler(pedidoSvc, fluxoPedidoSvc)
+	fotoProdutoHandler := handler.NewFotoProdutoHandler(fotoProdutoSvc, cfg.Storage.MaxFileSize)
+	horarioHandler := handler.NewHorarioFuncionamentoHandler(horarioSvc)
+	freteHandler := handler.NewFreteHandler(freteSvc)
+	cupomHandler := handler.NewCupomHandler(cupomSvc)
+	grupoOpcoesHandler := handler.NewGrupoOpcoesHandler(grupoOpcoesSvc)
+	pedidoHandler := handler.NewPedidoHandler(pedidoSvc, fluxoPedidoSvc, idempotencySvc)
+	pedidoStreamHandler := handler.NewPedidoStreamHandler(pedidoSvc, restauranteSvc, pedidoStreamSvc)
+	pagamentoHandler := handler.NewPagamentoHandler(pagamentoSvc, pedidoSvc, fluxoPedidoSvc)
+	avaliacaoHandler := handler.NewAvaliacaoHandler(avaliacaoSvc)
+	enderecoUsuarioHandler := handler.NewEnderecoUsuarioHandler(enderecoUsuarioSvc)
+	favoritoHandler := handler.NewFavoritoHandler(favoritoSvc)
+	carrinhoHandler := handler.NewCarrinhoHandler(carrinhoSvc, idempotencySvc)
 	estatisticaHandler := handler.NewEstatisticaHandler(vendaQueryRepo)
+	eventoOutboxHandler := handler.NewEventoOutboxHandler(eventoOutboxSvc)
+	jwksHandler := handler.NewJwksHandler(keySet)
 
 	// Setup Gin
 	gin.SetMode(cfg.Server.Mode)
 	engine := gin.New()
+	// Sem proxies confiáveis, X-Forwarded-For é ignorado (o rate limit por IP não pode ser burlado)
+	if err := engine.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("Invalid server.trusted_proxies: %v", err)
+	}
 
 	// Setup router
 	router := api.NewRouter(
@@ -141,10 +247,27 @@ func main() {
 		usuarioHandler,
 		restauranteHandler,
 		produtoHandler,
+		fotoProdutoHandler,
+		horarioHandler,
+		freteHandler,
+		cupomHandler,
+		grupoOpcoesHandler,
 		pedidoHandler,
+		pedidoStreamHandler,
+		pagamentoHandler,
+		avaliacaoHandler,
+		enderecoUsuarioHandler,
+		favoritoHandler,
+		carrinhoHandler,
 		estatisticaHandler,
+		eventoOutboxHandler,
+		jwksHandler,
 		usuarioSvc,
+		restauranteSvc,
+		pedidoSvc,
 		tokenBlacklistSvc,
+		tokenValidator,
+		rateLimitSvc,
 		cfg,
 	)
 	router.Setup(engine)
@@ -155,6 +278,8 @@ func main() {
 		Addr:    addr,
 		Handler: engine,
 	}
+	// Streams SSE ficam abertos indefinidamente; encerra-os para o Shutdown não esperar o timeout
+	httpServer.RegisterOnShutdown(pedidoStreamSvc.Encerrar)
 
 	serverErr := make(chan error, 1)
 	go func() {
@@ -178,6 +303,15 @@ func main() {
 		sqsListener.Stop()
 	}
 
+	log.Println("Parando relay do outbox...")
+	outboxRelay.Stop()
+
+	log.Println("Parando scheduler de horarios de funcionamento...")
+	aberturaScheduler.Stop()
+
+	log.Println("Parando scheduler de estornos...")
+	estornoScheduler.Stop()
+
 	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelShutdown()
 
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/migration"
+	"github.com/yurisasc/algafood-go/migrations"
+)
+
+const uso = `Uso: migrate <comando>
+
+Comandos:
+  up          Aplica todas as migracoes pendentes
+  down N      Reverte as ultimas N migracoes
+  version     Mostra a versao atual do banco
+  force V     Registra a versao V como limpa sem executar scripts (-1 = nenhuma)
+  status      Lista as migracoes e indica quais foram aplicadas`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprintln(os.Stderr, uso)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	db, err := config.NewMigrationDB(&cfg.Database)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(db, migrations.FS)
+	if err != nil {
+		log.Fatalf("Falha ao carregar migracoes: %v", err)
+	}
+
+	if err := executar(ctx, migrator, os.Args[1], os.Args[2:]); err != nil {
+		if errors.Is(err, errUso) {
+			fmt.Fprintln(os.Stderr, uso)
+			os.Exit(2)
+		}
+		log.Fatalf("Erro: %v", err)
+	}
+}
+
+var errUso = errors.New("uso invalido")
+
+func executar(ctx context.Context, migrator *migration.Migrator, comando string, args []string) error {
+	switch comando {
+	case "up":
+		aplicadas, err := migrator.Up(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migracao(oes) aplicada(s)\n", aplicadas)
+		return imprimirVersao(ctx, migrator)
+
+	case "down":
+		if len(args) != 1 {
+			return errUso
+		}
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n <= 0 {
+			return errUso
+		}
+		revertidas, err := migrator.Down(ctx, n)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%d migracao(oes) revertida(s)\n", revertidas)
+		return imprimirVersao(ctx, migrator)
+
+	case "version":
+		return imprimirVersao(ctx, migrator)
+
+	case "force":
+		if len(args) != 1 {
+			return errUso
+		}
+		versao, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return errUso
+		}
+		if err := migrator.Force(ctx, versao); err != nil {
+			return err
+		}
+		return imprimirVersao(ctx, migrator)
+
+	case "status":
+		status, atual, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, s := range status {
+			situacao := "pendente"
+			if s.Aplicada {
+				situacao = "aplicada"
+			}
+			if s.Versao == atual.Numero && atual.Sujo {
+				situacao = "SUJA"
+			}
+			fmt.Printf("%06d  %-40s %s\n", s.Versao, s.Nome, situacao)
+		}
+		return nil
+
+	default:
+		return errUso
+	}
+}
+
+func imprimirVersao(ctx context.Context, migrator *migration.Migrator) error {
+	v, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case v.Numero == migration.SemVersao:
+		fmt.Println("Versao: nenhuma migracao aplicada")
+	case v.Sujo:
+		fmt.Printf("Versao: %d (suja)\n", v.Numero)
+	default:
+		fmt.Printf("Versao: %d\n", v.Numero)
+	}
+	return nil
+}
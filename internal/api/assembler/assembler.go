@@ -1,8 +1,12 @@
 package assembler
 
 import (
+	"math"
+	"strings"
+
 	"github.com/shopspring/decimal"
 	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 )
 
@@ -150,10 +154,11 @@ func ToGrupoEntity(input *dto.GrupoInput) *model.Grupo {
 // ToUsuarioModel converts Usuario entity to UsuarioModel DTO
 func ToUsuarioModel(u *model.Usuario) dto.UsuarioModel {
 	return dto.UsuarioModel{
-		ID:           u.ID,
-		Nome:         u.Nome,
-		Email:        u.Email,
-		DataCadastro: u.DataCadastro,
+		ID:              u.ID,
+		Nome:            u.Nome,
+		Email:           u.Email,
+		EmailVerificado: u.EmailVerificado,
+		DataCadastro:    u.DataCadastro,
 	}
 }
 
@@ -178,14 +183,18 @@ func ToUsuarioEntity(input *dto.UsuarioComSenhaInput) *model.Usuario {
 // ToRestauranteModel converts Restaurante entity to RestauranteModel DTO
 func ToRestauranteModel(r *model.Restaurante) dto.RestauranteModel {
 	model := dto.RestauranteModel{
-		ID:              r.ID,
-		Nome:            r.Nome,
-		TaxaFrete:       r.TaxaFrete,
-		Cozinha:         ToCozinhaModel(&r.Cozinha),
-		Ativo:           r.Ativo,
-		Aberto:          r.Aberto,
-		DataCadastro:    r.DataCadastro,
-		DataAtualizacao: r.DataAtualizacao,
+		ID:                r.ID,
+		Nome:              r.Nome,
+		TaxaFrete:         r.TaxaFrete,
+		ValorMinimoPedido: r.ValorMinimoPedido,
+		Cozinha:           ToCozinhaModel(&r.Cozinha),
+		Ativo:             r.Ativo,
+		Aberto:            r.Aberto,
+		FusoHorario:       r.FusoHorario,
+		AvaliacaoMedia:    r.AvaliacaoMedia,
+		TotalAvaliacoes:   r.TotalAvaliacoes,
+		DataCadastro:      r.DataCadastro,
+		DataAtualizacao:   r.DataAtualizacao,
 	}
 
 	if r.Endereco.CEP != "" {
@@ -200,6 +209,8 @@ func ToRestauranteModel(r *model.Restaurante) dto.RestauranteModel {
 				Nome:   r.Endereco.Cidade.Nome,
 				Estado: r.Endereco.Cidade.Estado.Nome,
 			},
+			Latitude:  r.Endereco.Latitude,
+			Longitude: r.Endereco.Longitude,
 		}
 	}
 
@@ -217,6 +228,13 @@ func ToRestauranteResumoModels(restaurantes []model.Restaurante) []dto.Restauran
 			Cozinha:   ToCozinhaModel(&r.Cozinha),
 			Ativo:     r.Ativo,
 			Aberto:    r.Aberto,
+
+			AvaliacaoMedia:  r.AvaliacaoMedia,
+			TotalAvaliacoes: r.TotalAvaliacoes,
+		}
+		if r.DistanciaKm != nil {
+			distancia := math.Round(*r.DistanciaKm*100) / 100
+			models[i].DistanciaKm = &distancia
 		}
 	}
 	return models
@@ -225,9 +243,11 @@ func ToRestauranteResumoModels(restaurantes []model.Restaurante) []dto.Restauran
 // ToRestauranteEntity converts RestauranteInput DTO to Restaurante entity
 func ToRestauranteEntity(input *dto.RestauranteInput) *model.Restaurante {
 	r := &model.Restaurante{
-		Nome:      input.Nome,
-		TaxaFrete: decimal.NewFromFloat(input.TaxaFrete),
-		CozinhaID: input.Cozinha.ID,
+		Nome:              input.Nome,
+		TaxaFrete:         decimal.NewFromFloat(input.TaxaFrete),
+		ValorMinimoPedido: decimal.NewFromFloat(input.ValorMinimoPedido),
+		CozinhaID:         input.Cozinha.ID,
+		FusoHorario:       input.FusoHorario,
 	}
 
 	if input.Endereco != nil {
@@ -238,12 +258,202 @@ func ToRestauranteEntity(input *dto.RestauranteInput) *model.Restaurante {
 			Complemento: input.Endereco.Complemento,
 			Bairro:      input.Endereco.Bairro,
 			CidadeID:    input.Endereco.Cidade.ID,
+			Latitude:    input.Endereco.Latitude,
+			Longitude:   input.Endereco.Longitude,
 		}
 	}
 
 	return r
 }
 
+// ToHorarioFuncionamentoModel converts HorarioFuncionamento entity to DTO
+func ToHorarioFuncionamentoModel(h *model.HorarioFuncionamento) dto.HorarioFuncionamentoModel {
+	return dto.HorarioFuncionamentoModel{
+		ID:             h.ID,
+		DiaSemana:      string(h.DiaSemana),
+		HoraAbertura:   h.HoraAbertura,
+		HoraFechamento: h.HoraFechamento,
+	}
+}
+
+// ToHorarioFuncionamentoModels converts slice of HorarioFuncionamento entities to DTOs
+func ToHorarioFuncionamentoModels(horarios []model.HorarioFuncionamento) []dto.HorarioFuncionamentoModel {
+	models := make([]dto.HorarioFuncionamentoModel, len(horarios))
+	for i, h := range horarios {
+		models[i] = ToHorarioFuncionamentoModel(&h)
+	}
+	return models
+}
+
+// ToHorarioFuncionamentoEntity converts HorarioFuncionamentoInput DTO to entity
+func ToHorarioFuncionamentoEntity(input *dto.HorarioFuncionamentoInput) *model.HorarioFuncionamento {
+	return &model.HorarioFuncionamento{
+		DiaSemana:      model.DiaSemana(input.DiaSemana),
+		HoraAbertura:   input.HoraAbertura,
+		HoraFechamento: input.HoraFechamento,
+	}
+}
+
+// ToExcecaoHorarioModel converts ExcecaoHorario entity to DTO
+func ToExcecaoHorarioModel(e *model.ExcecaoHorario) dto.ExcecaoHorarioModel {
+	return dto.ExcecaoHorarioModel{
+		ID:             e.ID,
+		Data:           e.Data,
+		Descricao:      e.Descricao,
+		Fechado:        e.Fechado,
+		HoraAbertura:   e.HoraAbertura,
+		HoraFechamento: e.HoraFechamento,
+	}
+}
+
+// ToExcecaoHorarioModels converts slice of ExcecaoHorario entities to DTOs
+func ToExcecaoHorarioModels(excecoes []model.ExcecaoHorario) []dto.ExcecaoHorarioModel {
+	models := make([]dto.ExcecaoHorarioModel, len(excecoes))
+	for i, e := range excecoes {
+		models[i] = ToExcecaoHorarioModel(&e)
+	}
+	return models
+}
+
+// ToExcecaoHorarioEntity converts ExcecaoHorarioInput DTO to entity
+func ToExcecaoHorarioEntity(input *dto.ExcecaoHorarioInput) *model.ExcecaoHorario {
+	return &model.ExcecaoHorario{
+		Data:           input.Data,
+		Descricao:      input.Descricao,
+		Fechado:        input.Fechado,
+		HoraAbertura:   input.HoraAbertura,
+		HoraFechamento: input.HoraFechamento,
+	}
+}
+
+// ToConfiguracaoFreteModel converts ConfiguracaoFrete entity to DTO
+func ToConfiguracaoFreteModel(c *model.ConfiguracaoFrete) dto.ConfiguracaoFreteModel {
+	m := dto.ConfiguracaoFreteModel{
+		Tipo:             string(c.Tipo),
+		FreteGratisAcima: c.FreteGratisAcima,
+		Localidades:      make([]dto.FreteLocalidadeModel, len(c.Localidades)),
+		Faixas:           make([]dto.FaixaFreteModel, len(c.Faixas)),
+	}
+	for i, l := range c.Localidades {
+		m.Localidades[i] = dto.FreteLocalidadeModel{
+			Cidade: dto.CidadeResumoModel{
+				ID:     l.Cidade.ID,
+				Nome:   l.Cidade.Nome,
+				Estado: l.Cidade.Estado.Nome,
+			},
+			Bairro: l.Bairro,
+			Taxa:   l.Taxa,
+		}
+	}
+	for i, f := range c.Faixas {
+		m.Faixas[i] = dto.FaixaFreteModel{
+			DistanciaMaxKm: f.DistanciaMaxKm,
+			Taxa:           f.Taxa,
+		}
+	}
+	return m
+}
+
+// ToConfiguracaoFreteEntity converts ConfiguracaoFreteInput DTO to entity
+func ToConfiguracaoFreteEntity(input *dto.ConfiguracaoFreteInput) *model.ConfiguracaoFrete {
+	c := &model.ConfiguracaoFrete{
+		Tipo:        model.TipoFrete(input.Tipo),
+		Localidades: make([]model.FreteLocalidade, len(input.Localidades)),
+		Faixas:      make([]model.FaixaFreteDistancia, len(input.Faixas)),
+	}
+	if input.FreteGratisAcima != nil {
+		minimo := decimal.NewFromFloat(*input.FreteGratisAcima)
+		c.FreteGratisAcima = &minimo
+	}
+	for i, l := range input.Localidades {
+		c.Localidades[i] = model.FreteLocalidade{
+			CidadeID: l.Cidade.ID,
+			Bairro:   l.Bairro,
+			Taxa:     decimal.NewFromFloat(*l.Taxa),
+		}
+	}
+	for i, f := range input.Faixas {
+		c.Faixas[i] = model.FaixaFreteDistancia{
+			DistanciaMaxKm: f.DistanciaMaxKm,
+			Taxa:           decimal.NewFromFloat(*f.Taxa),
+		}
+	}
+	return c
+}
+
+// ToCotacaoFreteModel converts CotacaoFrete to DTO
+func ToCotacaoFreteModel(c *model.CotacaoFrete) dto.CotacaoFreteModel {
+	m := dto.CotacaoFreteModel{
+		TaxaFrete: c.Taxa,
+		Regra:     c.Regra,
+		Descricao: c.Descricao,
+	}
+	if c.DistanciaKm != nil {
+		distancia := math.Round(*c.DistanciaKm*100) / 100
+		m.DistanciaKm = &distancia
+	}
+	return m
+}
+
+// ToCupomModel converts Cupom entity to CupomModel DTO
+func ToCupomModel(c *model.Cupom) dto.CupomModel {
+	m := dto.CupomModel{
+		ID:                   c.ID,
+		Codigo:               c.Codigo,
+		Descricao:            c.Descricao,
+		Tipo:                 string(c.Tipo),
+		Valor:                c.Valor,
+		ValorMinimoPedido:    c.ValorMinimoPedido,
+		ValidoDe:             c.ValidoDe,
+		ValidoAte:            c.ValidoAte,
+		LimiteUsos:           c.LimiteUsos,
+		LimiteUsosPorCliente: c.LimiteUsosPorCliente,
+		TotalUsos:            c.TotalUsos,
+		Ativo:                c.Ativo,
+		DataCadastro:         c.DataCadastro,
+		DataAtualizacao:      c.DataAtualizacao,
+	}
+	if c.Restaurante != nil {
+		m.Restaurante = &dto.RestauranteApenasNomeModel{ID: c.Restaurante.ID, Nome: c.Restaurante.Nome}
+	} else if c.RestauranteID != nil {
+		m.Restaurante = &dto.RestauranteApenasNomeModel{ID: *c.RestauranteID}
+	}
+	return m
+}
+
+// ToCupomModels converts slice of Cupom entities
+func ToCupomModels(cupons []model.Cupom) []dto.CupomModel {
+	models := make([]dto.CupomModel, len(cupons))
+	for i, c := range cupons {
+		models[i] = ToCupomModel(&c)
+	}
+	return models
+}
+
+// ToCupomEntity converts CupomInput DTO to Cupom entity
+func ToCupomEntity(input *dto.CupomInput) *model.Cupom {
+	c := &model.Cupom{
+		Codigo:               input.Codigo,
+		Descricao:            input.Descricao,
+		Tipo:                 model.TipoDescontoCupom(input.Tipo),
+		Valor:                decimal.NewFromFloat(input.Valor),
+		ValidoDe:             input.ValidoDe,
+		ValidoAte:            input.ValidoAte,
+		LimiteUsos:           input.LimiteUsos,
+		LimiteUsosPorCliente: input.LimiteUsosPorCliente,
+		Ativo:                input.Ativo,
+	}
+	if input.Restaurante != nil {
+		restauranteID := input.Restaurante.ID
+		c.RestauranteID = &restauranteID
+	}
+	if input.ValorMinimoPedido != nil {
+		minimo := decimal.NewFromFloat(*input.ValorMinimoPedido)
+		c.ValorMinimoPedido = &minimo
+	}
+	return c
+}
+
 // ToProdutoModel converts Produto entity to ProdutoModel DTO
 func ToProdutoModel(p *model.Produto) dto.ProdutoModel {
 	return dto.ProdutoModel{
@@ -274,6 +484,55 @@ func ToProdutoEntity(input *dto.ProdutoInput) *model.Produto {
 	}
 }
 
+// ToGrupoOpcoesModel converts GrupoOpcoes entity to DTO
+func ToGrupoOpcoesModel(g *model.GrupoOpcoes) dto.GrupoOpcoesModel {
+	m := dto.GrupoOpcoesModel{
+		ID:          g.ID,
+		Nome:        g.Nome,
+		MinSelecoes: g.MinSelecoes,
+		MaxSelecoes: g.MaxSelecoes,
+		Obrigatorio: g.Obrigatorio(),
+		Opcoes:      make([]dto.OpcaoProdutoModel, len(g.Opcoes)),
+	}
+	for i, o := range g.Opcoes {
+		m.Opcoes[i] = dto.OpcaoProdutoModel{
+			ID:             o.ID,
+			Nome:           o.Nome,
+			PrecoAdicional: o.PrecoAdicional,
+			Ativo:          o.Ativo,
+		}
+	}
+	return m
+}
+
+// ToGrupoOpcoesModels converts slice of GrupoOpcoes entities
+func ToGrupoOpcoesModels(grupos []model.GrupoOpcoes) []dto.GrupoOpcoesModel {
+	models := make([]dto.GrupoOpcoesModel, len(grupos))
+	for i, g := range grupos {
+		models[i] = ToGrupoOpcoesModel(&g)
+	}
+	return models
+}
+
+// ToGrupoOpcoesEntity converts GrupoOpcoesInput DTO to entity. Opções sem "ativo" ficam ativas.
+func ToGrupoOpcoesEntity(input *dto.GrupoOpcoesInput) *model.GrupoOpcoes {
+	g := &model.GrupoOpcoes{
+		Nome:        input.Nome,
+		MinSelecoes: input.MinSelecoes,
+		MaxSelecoes: input.MaxSelecoes,
+		Opcoes:      make([]model.OpcaoProduto, len(input.Opcoes)),
+	}
+	for i, o := range input.Opcoes {
+		g.Opcoes[i] = model.OpcaoProduto{
+			ID:             o.ID,
+			Nome:           o.Nome,
+			PrecoAdicional: decimal.NewFromFloat(o.PrecoAdicional),
+			Ativo:          o.Ativo == nil || *o.Ativo,
+		}
+	}
+	return g
+}
+
 // ToFotoProdutoModel converts FotoProduto entity to FotoProdutoModel DTO
 func ToFotoProdutoModel(f *model.FotoProduto) dto.FotoProdutoModel {
 	return dto.FotoProdutoModel{
@@ -284,11 +543,43 @@ func ToFotoProdutoModel(f *model.FotoProduto) dto.FotoProdutoModel {
 	}
 }
 
-// ToPedidoModel converts Pedido entity to PedidoModel DTO
-func ToPedidoModel(p *model.Pedido) dto.PedidoModel {
-	itens := make([]dto.ItemPedidoModel, len(p.Itens))
-	for i, item := range p.Itens {
-		itens[i] = dto.ItemPedidoModel{
+// ToRepeticaoPedidoModel converts the result of repeating a Pedido to DTO
+func ToRepeticaoPedidoModel(r *model.RepeticaoPedido) dto.RepeticaoPedidoModel {
+	alterados := make([]dto.ItemRepeticaoAlteradoModel, len(r.ItensAlterados))
+	for i, item := range r.ItensAlterados {
+		alterados[i] = dto.ItemRepeticaoAlteradoModel{
+			ProdutoID:     item.ProdutoID,
+			ProdutoNome:   item.Nome,
+			PrecoAnterior: item.PrecoAnterior,
+			PrecoAtual:    item.PrecoAtual,
+		}
+	}
+
+	removidos := make([]dto.ItemRepeticaoRemovidoModel, len(r.ItensRemovidos))
+	for i, item := range r.ItensRemovidos {
+		removidos[i] = dto.ItemRepeticaoRemovidoModel{
+			ProdutoID:   item.ProdutoID,
+			ProdutoNome: item.Nome,
+			Quantidade:  item.Quantidade,
+			Motivo:      item.Motivo,
+		}
+	}
+
+	return dto.RepeticaoPedidoModel{
+		CodigoPedidoOriginal: r.Original.Codigo,
+		DryRun:               !r.Emitido,
+		ValorTotalAnterior:   r.Original.ValorTotal,
+		Pedido:               ToPedidoModel(r.Pedido),
+		ItensAlterados:       alterados,
+		ItensRemovidos:       removidos,
+	}
+}
+
+// ToItemPedidoModels converts order items to DTOs
+func ToItemPedidoModels(itens []model.ItemPedido) []dto.ItemPedidoModel {
+	models := make([]dto.ItemPedidoModel, len(itens))
+	for i, item := range itens {
+		models[i] = dto.ItemPedidoModel{
 			ProdutoID:     item.ProdutoID,
 			ProdutoNome:   item.Produto.Nome,
 			Quantidade:    item.Quantidade,
@@ -296,16 +587,36 @@ func ToPedidoModel(p *model.Pedido) dto.PedidoModel {
 			PrecoTotal:    item.PrecoTotal,
 			Observacao:    item.Observacao,
 		}
+		for _, opcao := range item.Opcoes {
+			models[i].Opcoes = append(models[i].Opcoes, dto.ItemPedidoOpcaoModel{
+				OpcaoID:        opcao.OpcaoID,
+				Grupo:          opcao.Grupo,
+				Nome:           opcao.Nome,
+				PrecoAdicional: opcao.PrecoAdicional,
+			})
+		}
 	}
 
+	return models
+}
+
+// ToPedidoModel converts Pedido entity to PedidoModel DTO
+func ToPedidoModel(p *model.Pedido) dto.PedidoModel {
 	return dto.PedidoModel{
 		Codigo:           p.Codigo,
 		Subtotal:         p.Subtotal,
 		TaxaFrete:        p.TaxaFrete,
+		RegraFrete:       p.RegraFrete,
+		DescricaoFrete:   p.DescricaoFrete,
+		Desconto:         p.Desconto,
+		Cupom:            p.CodigoCupom,
 		ValorTotal:       p.ValorTotal,
-		Status:           string(p.Status),
+		Status:           string(p.Status.Legado()),
+		StatusDetalhado:  string(p.Status),
 		DataCriacao:      p.DataCriacao,
 		DataConfirmacao:  p.DataConfirmacao,
+		DataPreparacao:   p.DataPreparacao,
+		DataSaidaEntrega: p.DataSaidaEntrega,
 		DataCancelamento: p.DataCancelamento,
 		DataEntrega:      p.DataEntrega,
 		Restaurante: dto.RestauranteApenasNomeModel{
@@ -325,8 +636,10 @@ func ToPedidoModel(p *model.Pedido) dto.PedidoModel {
 				Nome:   p.EnderecoEntrega.Cidade.Nome,
 				Estado: p.EnderecoEntrega.Cidade.Estado.Nome,
 			},
+			Latitude:  p.EnderecoEntrega.Latitude,
+			Longitude: p.EnderecoEntrega.Longitude,
 		},
-		Itens: itens,
+		Itens: ToItemPedidoModels(p.Itens),
 	}
 }
 
@@ -335,12 +648,14 @@ func ToPedidoResumoModels(pedidos []model.Pedido) []dto.PedidoResumoModel {
 	models := make([]dto.PedidoResumoModel, len(pedidos))
 	for i, p := range pedidos {
 		models[i] = dto.PedidoResumoModel{
-			Codigo:      p.Codigo,
-			Subtotal:    p.Subtotal,
-			TaxaFrete:   p.TaxaFrete,
-			ValorTotal:  p.ValorTotal,
-			Status:      string(p.Status),
-			DataCriacao: p.DataCriacao,
+			Codigo:          p.Codigo,
+			Subtotal:        p.Subtotal,
+			TaxaFrete:       p.TaxaFrete,
+			Desconto:        p.Desconto,
+			ValorTotal:      p.ValorTotal,
+			Status:          string(p.Status.Legado()),
+			StatusDetalhado: string(p.Status),
+			DataCriacao:     p.DataCriacao,
 			Restaurante: dto.RestauranteApenasNomeModel{
 				ID:   p.Restaurante.ID,
 				Nome: p.Restaurante.Nome,
@@ -351,6 +666,73 @@ func ToPedidoResumoModels(pedidos []model.Pedido) []dto.PedidoResumoModel {
 	return models
 }
 
+// ToPagamentoModel converts Pagamento entity to DTO
+func ToPagamentoModel(p *model.Pagamento) dto.PagamentoModel {
+	return dto.PagamentoModel{
+		Provedor:        p.Provedor,
+		TransacaoID:     p.TransacaoID,
+		Valor:           p.Valor,
+		Status:          string(p.Status),
+		Mensagem:        p.Mensagem,
+		DataCriacao:     p.DataCriacao,
+		DataAtualizacao: p.DataAtualizacao,
+		DataCaptura:     p.DataCaptura,
+		DataEstorno:     p.DataEstorno,
+	}
+}
+
+// ToAvaliacaoModel converts Avaliacao entity to DTO
+func ToAvaliacaoModel(a *model.Avaliacao) dto.AvaliacaoModel {
+	return dto.AvaliacaoModel{
+		ID:           a.ID,
+		Nota:         a.Nota,
+		Comentario:   a.Comentario,
+		Cliente:      dto.UsuarioResumoModel{ID: a.ClienteID, Nome: a.Cliente.Nome},
+		Resposta:     a.Resposta,
+		DataResposta: a.DataResposta,
+		DataCriacao:  a.DataCriacao,
+	}
+}
+
+// ToAvaliacaoModels converts slice of Avaliacao entities to DTOs
+func ToAvaliacaoModels(avaliacoes []model.Avaliacao) []dto.AvaliacaoModel {
+	models := make([]dto.AvaliacaoModel, len(avaliacoes))
+	for i := range avaliacoes {
+		models[i] = ToAvaliacaoModel(&avaliacoes[i])
+	}
+	return models
+}
+
+// ToAvaliacaoEntity converts AvaliacaoInput to entity
+func ToAvaliacaoEntity(input *dto.AvaliacaoInput) *model.Avaliacao {
+	return &model.Avaliacao{
+		Nota:       input.Nota,
+		Comentario: input.Comentario,
+	}
+}
+
+// ToPedidoStatusHistoricoModels converts status history entries to DTOs
+func ToPedidoStatusHistoricoModels(historico []model.PedidoStatusHistorico) []dto.PedidoStatusHistoricoModel {
+	models := make([]dto.PedidoStatusHistoricoModel, len(historico))
+	for i, h := range historico {
+		models[i] = dto.PedidoStatusHistoricoModel{
+			StatusNovo:    string(h.StatusNovo),
+			Motivo:        h.Motivo,
+			DataAlteracao: h.DataAlteracao,
+		}
+		if h.StatusAnterior != nil {
+			anterior := string(*h.StatusAnterior)
+			models[i].StatusAnterior = &anterior
+		}
+		if h.Usuario != nil {
+			models[i].Usuario = &dto.UsuarioResumoModel{ID: h.Usuario.ID, Nome: h.Usuario.Nome}
+		} else if h.UsuarioID != nil {
+			models[i].Usuario = &dto.UsuarioResumoModel{ID: *h.UsuarioID}
+		}
+	}
+	return models
+}
+
 // ToPedidoEntity converts PedidoInput DTO to Pedido entity
 func ToPedidoEntity(input *dto.PedidoInput, clienteID uint64) *model.Pedido {
 	itens := make([]model.ItemPedido, len(input.Itens))
@@ -360,20 +742,210 @@ func ToPedidoEntity(input *dto.PedidoInput, clienteID uint64) *model.Pedido {
 			Quantidade: item.Quantidade,
 			Observacao: item.Observacao,
 		}
+		// Apenas as opções escolhidas; nome e preço são copiados na emissão
+		for _, opcaoID := range item.Opcoes {
+			itens[i].Opcoes = append(itens[i].Opcoes, model.ItemPedidoOpcao{OpcaoID: opcaoID})
+		}
 	}
 
-	return &model.Pedido{
+	pedido := &model.Pedido{
 		RestauranteID:    input.Restaurante.ID,
 		FormaPagamentoID: input.FormaPagamento.ID,
 		ClienteID:        clienteID,
-		EnderecoEntrega: model.EnderecoEntrega{
-			CEP:         input.EnderecoEntrega.CEP,
-			Logradouro:  input.EnderecoEntrega.Logradouro,
-			Numero:      input.EnderecoEntrega.Numero,
-			Complemento: input.EnderecoEntrega.Complemento,
-			Bairro:      input.EnderecoEntrega.Bairro,
-			CidadeID:    input.EnderecoEntrega.Cidade.ID,
+		EnderecoSalvoID:  input.EnderecoID,
+		Itens:            itens,
+		CodigoCupom:      strings.ToUpper(strings.TrimSpace(input.Cupom)),
+	}
+	if input.EnderecoEntrega != nil {
+		pedido.EnderecoEntrega = ToEnderecoEntregaEntity(input.EnderecoEntrega)
+	}
+	return pedido
+}
+
+// ToEnderecoEntregaEntity converts the address input to the delivery address copied into a Pedido
+func ToEnderecoEntregaEntity(input *dto.EnderecoInput) model.EnderecoEntrega {
+	return model.EnderecoEntrega{
+		CEP:         input.CEP,
+		Logradouro:  input.Logradouro,
+		Numero:      input.Numero,
+		Complemento: input.Complemento,
+		Bairro:      input.Bairro,
+		CidadeID:    input.Cidade.ID,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
+	}
+}
+
+// ToEnderecoUsuarioModel converts EnderecoUsuario entity to DTO
+func ToEnderecoUsuarioModel(e *model.EnderecoUsuario) dto.EnderecoUsuarioModel {
+	return dto.EnderecoUsuarioModel{
+		ID:      e.ID,
+		Apelido: e.Apelido,
+		Endereco: dto.EnderecoModel{
+			CEP:         e.Endereco.CEP,
+			Logradouro:  e.Endereco.Logradouro,
+			Numero:      e.Endereco.Numero,
+			Complemento: e.Endereco.Complemento,
+			Bairro:      e.Endereco.Bairro,
+			Cidade: dto.CidadeResumoModel{
+				ID:     e.Endereco.Cidade.ID,
+				Nome:   e.Endereco.Cidade.Nome,
+				Estado: e.Endereco.Cidade.Estado.Nome,
+			},
+			Latitude:  e.Endereco.Latitude,
+			Longitude: e.Endereco.Longitude,
+		},
+		Padrao:          e.Padrao,
+		DataCadastro:    e.DataCadastro,
+		DataAtualizacao: e.DataAtualizacao,
+	}
+}
+
+// ToEnderecoUsuarioModels converts slice of EnderecoUsuario entities to DTOs
+func ToEnderecoUsuarioModels(enderecos []model.EnderecoUsuario) []dto.EnderecoUsuarioModel {
+	models := make([]dto.EnderecoUsuarioModel, len(enderecos))
+	for i := range enderecos {
+		models[i] = ToEnderecoUsuarioModel(&enderecos[i])
+	}
+	return models
+}
+
+// ToEnderecoUsuarioEntity converts EnderecoUsuarioInput to entity
+func ToEnderecoUsuarioEntity(input *dto.EnderecoUsuarioInput) *model.EnderecoUsuario {
+	return &model.EnderecoUsuario{
+		Apelido: input.Apelido,
+		Endereco: model.Endereco{
+			CEP:         input.Endereco.CEP,
+			Logradouro:  input.Endereco.Logradouro,
+			Numero:      input.Endereco.Numero,
+			Complemento: input.Endereco.Complemento,
+			Bairro:      input.Endereco.Bairro,
+			CidadeID:    input.Endereco.Cidade.ID,
+			Latitude:    input.Endereco.Latitude,
+			Longitude:   input.Endereco.Longitude,
 		},
-		Itens: itens,
+		Padrao: input.Padrao,
+	}
+}
+
+// ToFavoritosModel converts the favorite restaurants and products to DTO
+func ToFavoritosModel(restaurantes []model.Restaurante, produtos []model.Produto) dto.FavoritosModel {
+	m := dto.FavoritosModel{
+		Restaurantes: ToRestauranteResumoModels(restaurantes),
+		Produtos:     make([]dto.ProdutoFavoritoModel, len(produtos)),
+	}
+	for i, p := range produtos {
+		m.Produtos[i] = dto.ProdutoFavoritoModel{
+			ID:    p.ID,
+			Nome:  p.Nome,
+			Preco: p.Preco,
+			Ativo: p.Ativo,
+			Restaurante: dto.RestauranteApenasNomeModel{
+				ID:   p.Restaurante.ID,
+				Nome: p.Restaurante.Nome,
+			},
+		}
+	}
+	return m
+}
+
+// ToEventoOutboxModel converts EventoOutbox entity to EventoOutboxModel DTO
+func ToEventoOutboxModel(e *model.EventoOutbox) dto.EventoOutboxModel {
+	return dto.EventoOutboxModel{
+		ID:               e.ID,
+		Tipo:             e.Tipo,
+		Status:           string(e.Status),
+		Tentativas:       e.Tentativas,
+		UltimoErro:       e.UltimoErro,
+		Payload:          e.Payload,
+		DataOcorrencia:   e.DataOcorrencia,
+		DataCriacao:      e.DataCriacao,
+		ProximaTentativa: e.ProximaTentativa,
+		DataPublicacao:   e.DataPublicacao,
+	}
+}
+
+// ToEventoOutboxModels converts slice of EventoOutbox entities
+func ToEventoOutboxModels(eventos []model.EventoOutbox) []dto.EventoOutboxModel {
+	models := make([]dto.EventoOutboxModel, len(eventos))
+	for i, e := range eventos {
+		models[i] = ToEventoOutboxModel(&e)
+	}
+	return models
+}
+
+// ToCarrinhoModel converts Carrinho to DTO
+func ToCarrinhoModel(c *model.Carrinho) dto.CarrinhoModel {
+	itens := make([]dto.ItemCarrinhoModel, len(c.Itens))
+	for i, item := range c.Itens {
+		itens[i] = dto.ItemCarrinhoModel{
+			ID:            item.ID,
+			ProdutoID:     item.ProdutoID,
+			ProdutoNome:   item.ProdutoNome,
+			Quantidade:    item.Quantidade,
+			Observacao:    item.Observacao,
+			Opcoes:        item.Opcoes,
+			PrecoUnitario: item.PrecoUnitario,
+		}
+	}
+
+	result := dto.CarrinhoModel{
+		RestauranteID: c.RestauranteID,
+		Itens:         itens,
+		Avisos:        c.Avisos,
+	}
+	if !c.DataAtualizacao.IsZero() {
+		result.DataAtualizacao = &c.DataAtualizacao
+	}
+	return result
+}
+
+// ToPreviaCarrinhoModel converts the cart and the order simulated from it to DTO
+func ToPreviaCarrinhoModel(c *model.Carrinho, pedido *model.Pedido, pendencias *exception.NegocioException) dto.PreviaCarrinhoModel {
+	previa := dto.PreviaCarrinhoModel{
+		Carrinho: ToCarrinhoModel(c),
+		Itens:    []dto.ItemPedidoModel{},
+	}
+	if pedido != nil {
+		previa.Itens = ToItemPedidoModels(pedido.Itens)
+		previa.Subtotal = pedido.Subtotal
+		previa.TaxaFrete = pedido.TaxaFrete
+		previa.RegraFrete = pedido.RegraFrete
+		previa.DescricaoFrete = pedido.DescricaoFrete
+		previa.Desconto = pedido.Desconto
+		previa.Cupom = pedido.CodigoCupom
+		previa.ValorTotal = pedido.ValorTotal
+	}
+	if pendencias != nil {
+		for _, campo := range pendencias.Campos {
+			previa.Pendencias = append(previa.Pendencias, dto.ObjectError{Name: campo.Nome, UserMessage: campo.Mensagem})
+		}
+		if len(previa.Pendencias) == 0 {
+			previa.Pendencias = append(previa.Pendencias, dto.ObjectError{Name: "pedido", UserMessage: pendencias.Message})
+		}
+	}
+	return previa
+}
+
+// ToItemCarrinhoEntity converts the item input to a cart item
+func ToItemCarrinhoEntity(input *dto.ItemPedidoInput) *model.ItemCarrinho {
+	return &model.ItemCarrinho{
+		ProdutoID:  input.ProdutoID,
+		Quantidade: input.Quantidade,
+		Observacao: input.Observacao,
+		Opcoes:     input.Opcoes,
+	}
+}
+
+// ToCheckoutCarrinhoEntity converts the checkout input to the delivery data of the order
+func ToCheckoutCarrinhoEntity(input *dto.CheckoutCarrinhoInput) *model.Pedido {
+	pedido := &model.Pedido{
+		FormaPagamentoID: input.FormaPagamento.ID,
+		EnderecoSalvoID:  input.EnderecoID,
+		CodigoCupom:      strings.ToUpper(strings.TrimSpace(input.Cupom)),
+	}
+	if input.EnderecoEntrega != nil {
+		pedido.EnderecoEntrega = ToEnderecoEntregaEntity(input.EnderecoEntrega)
 	}
+	return pedido
 }
@@ -1,5 +1,7 @@
 package dto
 
+import "time"
+
 // EstadoInput represents input for creating/updating Estado
 type EstadoInput struct {
 	Nome string `json:"nome" binding:"required,min=2,max=80"`
@@ -50,12 +52,30 @@ type SenhaInput struct {
 	NovaSenha  string `json:"novaSenha" binding:"required,min=6"`
 }
 
+// EsqueciSenhaInput represents input for requesting a password reset link
+type EsqueciSenhaInput struct {
+	Email string `json:"email" binding:"required,email,max=255"`
+}
+
+// RedefinicaoSenhaInput represents input for resetting the password with the e-mailed token
+type RedefinicaoSenhaInput struct {
+	Token     string `json:"token" binding:"required"`
+	NovaSenha string `json:"novaSenha" binding:"required,min=6"`
+}
+
+// VerificacaoEmailInput represents input for confirming the e-mail with the e-mailed token
+type VerificacaoEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
 // RestauranteInput represents input for creating/updating Restaurante
 type RestauranteInput struct {
-	Nome      string         `json:"nome" binding:"required,min=2,max=80"`
-	TaxaFrete float64        `json:"taxaFrete" binding:"required,gte=0"`
-	Cozinha   CozinhaIDInput `json:"cozinha" binding:"required"`
-	Endereco  *EnderecoInput `json:"endereco"`
+	Nome              string         `json:"nome" binding:"required,min=2,max=80"`
+	TaxaFrete         float64        `json:"taxaFrete" binding:"required,gte=0"`
+	ValorMinimoPedido float64        `json:"valorMinimoPedido" binding:"gte=0"`
+	Cozinha           CozinhaIDInput `json:"cozinha" binding:"required"`
+	Endereco          *EnderecoInput `json:"endereco"`
+	FusoHorario       string         `json:"fusoHorario" binding:"max=60"`
 }
 
 // CozinhaIDInput represents Cozinha ID reference
@@ -71,6 +91,30 @@ type EnderecoInput struct {
 	Complemento string        `json:"complemento" binding:"max=60"`
 	Bairro      string        `json:"bairro" binding:"required,max=60"`
 	Cidade      CidadeIDInput `json:"cidade" binding:"required"`
+	Latitude    *float64      `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude   *float64      `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+}
+
+// EnderecoUsuarioInput represents input for an address saved by the customer
+type EnderecoUsuarioInput struct {
+	Apelido  string        `json:"apelido" binding:"required,max=40"`
+	Endereco EnderecoInput `json:"endereco" binding:"required"`
+	Padrao   bool          `json:"padrao"`
+}
+
+// RestauranteFiltroInput represents the query parameters of the Restaurante search
+type RestauranteFiltroInput struct {
+	Nome         string   `form:"nome" binding:"max=80"`
+	CozinhaID    *uint64  `form:"cozinhaId"`
+	CidadeID     *uint64  `form:"cidadeId"`
+	Aberto       *bool    `form:"aberto"`
+	Ativo        *bool    `form:"ativo"`
+	TaxaFreteMin *float64 `form:"taxaFreteMin" binding:"omitempty,gte=0"`
+	TaxaFreteMax *float64 `form:"taxaFreteMax" binding:"omitempty,gte=0"`
+	FreteGratis  *bool    `form:"freteGratis"`
+	Latitude     *float64 `form:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude    *float64 `form:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	RaioKm       *float64 `form:"raioKm" binding:"omitempty,gt=0,lte=100"`
 }
 
 // CidadeIDInput represents Cidade ID reference
@@ -78,6 +122,51 @@ type CidadeIDInput struct {
 	ID uint64 `json:"id" binding:"required"`
 }
 
+// ConfiguracaoFreteInput represents the freight configuration of a Restaurante
+type ConfiguracaoFreteInput struct {
+	Tipo             string                 `json:"tipo" binding:"required,oneof=FIXO LOCALIDADE DISTANCIA"`
+	FreteGratisAcima *float64               `json:"freteGratisAcima" binding:"omitempty,gt=0"`
+	Localidades      []FreteLocalidadeInput `json:"localidades" binding:"max=500,dive"`
+	Faixas           []FaixaFreteInput      `json:"faixas" binding:"max=50,dive"`
+}
+
+// FreteLocalidadeInput represents a city/neighborhood fee. Bairro vazio vale para a cidade inteira.
+type FreteLocalidadeInput struct {
+	Cidade CidadeIDInput `json:"cidade" binding:"required"`
+	Bairro string        `json:"bairro" binding:"max=60"`
+	Taxa   *float64      `json:"taxa" binding:"required,gte=0"`
+}
+
+// FaixaFreteInput represents a distance band
+type FaixaFreteInput struct {
+	DistanciaMaxKm float64  `json:"distanciaMaxKm" binding:"required,gt=0,lte=100"`
+	Taxa           *float64 `json:"taxa" binding:"required,gte=0"`
+}
+
+// SimulacaoFreteInput represents the delivery to be quoted before checkout
+type SimulacaoFreteInput struct {
+	Cidade    CidadeIDInput `json:"cidade" binding:"required"`
+	Bairro    string        `json:"bairro" binding:"max=60"`
+	Latitude  *float64      `json:"latitude" binding:"required_with=Longitude,omitempty,gte=-90,lte=90"`
+	Longitude *float64      `json:"longitude" binding:"required_with=Latitude,omitempty,gte=-180,lte=180"`
+	Subtotal  float64       `json:"subtotal" binding:"gte=0"`
+}
+
+// CupomInput represents input for creating/updating Cupom. Sem restaurante o cupom é global.
+type CupomInput struct {
+	Codigo               string              `json:"codigo" binding:"required,min=3,max=30"`
+	Descricao            string              `json:"descricao" binding:"max=255"`
+	Tipo                 string              `json:"tipo" binding:"required,oneof=PERCENTUAL VALOR_FIXO FRETE_GRATIS"`
+	Valor                float64             `json:"valor" binding:"gte=0"`
+	Restaurante          *RestauranteIDInput `json:"restaurante"`
+	ValorMinimoPedido    *float64            `json:"valorMinimoPedido" binding:"omitempty,gt=0"`
+	ValidoDe             *time.Time          `json:"validoDe"`
+	ValidoAte            *time.Time          `json:"validoAte"`
+	LimiteUsos           *int                `json:"limiteUsos" binding:"omitempty,gt=0"`
+	LimiteUsosPorCliente *int                `json:"limiteUsosPorCliente" binding:"omitempty,gt=0"`
+	Ativo                bool                `json:"ativo"`
+}
+
 // ProdutoInput represents input for creating/updating Produto
 type ProdutoInput struct {
 	Nome      string  `json:"nome" binding:"required,min=2,max=80"`
@@ -86,12 +175,32 @@ type ProdutoInput struct {
 	Ativo     bool    `json:"ativo"`
 }
 
+// GrupoOpcoesInput represents input for creating/updating a product option group
+type GrupoOpcoesInput struct {
+	Nome        string              `json:"nome" binding:"required,min=2,max=60"`
+	MinSelecoes int                 `json:"minSelecoes" binding:"gte=0,lte=50"`
+	MaxSelecoes int                 `json:"maxSelecoes" binding:"required,gte=1,lte=50"`
+	Opcoes      []OpcaoProdutoInput `json:"opcoes" binding:"required,min=1,max=50,dive"`
+}
+
+// OpcaoProdutoInput represents an option of a group. Informe o id para alterar uma
+// opção existente; opções sem id são criadas.
+type OpcaoProdutoInput struct {
+	ID             uint64  `json:"id"`
+	Nome           string  `json:"nome" binding:"required,max=60"`
+	PrecoAdicional float64 `json:"precoAdicional" binding:"gte=0"`
+	Ativo          *bool   `json:"ativo"`
+}
+
 // PedidoInput represents input for creating Pedido
 type PedidoInput struct {
-	Restaurante     RestauranteIDInput    `json:"restaurante" binding:"required"`
-	FormaPagamento  FormaPagamentoIDInput `json:"formaPagamento" binding:"required"`
-	EnderecoEntrega EnderecoInput         `json:"enderecoEntrega" binding:"required"`
-	Itens           []ItemPedidoInput     `json:"itens" binding:"required,min=1,dive"`
+	Restaurante    RestauranteIDInput    `json:"restaurante" binding:"required"`
+	FormaPagamento FormaPagamentoIDInput `json:"formaPagamento" binding:"required"`
+	// Endereço completo ou um endereço salvo (enderecoId); sem nenhum dos dois é usado o padrão do cliente
+	EnderecoEntrega *EnderecoInput    `json:"enderecoEntrega"`
+	EnderecoID      *uint64           `json:"enderecoId" binding:"excluded_with=EnderecoEntrega"`
+	Itens           []ItemPedidoInput `json:"itens" binding:"required,min=1,dive"`
+	Cupom           string            `json:"cupom" binding:"max=30"`
 }
 
 // RestauranteIDInput represents Restaurante ID reference
@@ -106,9 +215,41 @@ type FormaPagamentoIDInput struct {
 
 // ItemPedidoInput represents input for Pedido items
 type ItemPedidoInput struct {
-	ProdutoID  uint64 `json:"produtoId" binding:"required"`
-	Quantidade int    `json:"quantidade" binding:"required,min=1"`
-	Observacao string `json:"observacao" binding:"max=255"`
+	ProdutoID  uint64   `json:"produtoId" binding:"required"`
+	Quantidade int      `json:"quantidade" binding:"required,min=1"`
+	Observacao string   `json:"observacao" binding:"max=255"`
+	Opcoes     []uint64 `json:"opcoes" binding:"max=50"`
+}
+
+// CheckoutCarrinhoInput represents input for turning a cart into a Pedido
+type CheckoutCarrinhoInput struct {
+	FormaPagamento FormaPagamentoIDInput `json:"formaPagamento" binding:"required"`
+	// Endereço completo ou um endereço salvo (enderecoId); sem nenhum dos dois é usado o padrão do cliente
+	EnderecoEntrega *EnderecoInput `json:"enderecoEntrega"`
+	EnderecoID      *uint64        `json:"enderecoId" binding:"excluded_with=EnderecoEntrega"`
+	Cupom           string         `json:"cupom" binding:"max=30"`
+}
+
+// CancelamentoPedidoInput represents the optional body for cancelling a Pedido
+type CancelamentoPedidoInput struct {
+	Motivo string `json:"motivo" binding:"max=255"`
+}
+
+// PagamentoInput represents input for paying a Pedido. O token é gerado pelo front-end
+// diretamente no provedor de pagamento; os dados do cartão não passam pela API.
+type PagamentoInput struct {
+	Token string `json:"token" binding:"required,max=255"`
+}
+
+// AvaliacaoInput represents input for rating a delivered Pedido
+type AvaliacaoInput struct {
+	Nota       int    `json:"nota" binding:"required,gte=1,lte=5"`
+	Comentario string `json:"comentario" binding:"max=500"`
+}
+
+// RespostaAvaliacaoInput represents the restaurant's reply to a rating
+type RespostaAvaliacaoInput struct {
+	Resposta string `json:"resposta" binding:"required,max=500"`
 }
 
 // FotoProdutoInput represents input for uploading product photo
@@ -116,6 +257,22 @@ type FotoProdutoInput struct {
 	Descricao string `form:"descricao" binding:"max=150"`
 }
 
+// HorarioFuncionamentoInput represents input for a weekly opening interval
+type HorarioFuncionamentoInput struct {
+	DiaSemana      string `json:"diaSemana" binding:"required,oneof=DOMINGO SEGUNDA TERCA QUARTA QUINTA SEXTA SABADO"`
+	HoraAbertura   string `json:"horaAbertura" binding:"required,len=5"`
+	HoraFechamento string `json:"horaFechamento" binding:"required,len=5"`
+}
+
+// ExcecaoHorarioInput represents input for a schedule exception (e.g. holiday)
+type ExcecaoHorarioInput struct {
+	Data           string  `json:"data" binding:"required,datetime=2006-01-02"`
+	Descricao      string  `json:"descricao" binding:"max=80"`
+	Fechado        bool    `json:"fechado"`
+	HoraAbertura   *string `json:"horaAbertura" binding:"omitempty,len=5"`
+	HoraFechamento *string `json:"horaFechamento" binding:"omitempty,len=5"`
+}
+
 // AtivacaoRestauranteInput represents input for bulk activation
 type AtivacaoRestauranteInput struct {
 	IDs []uint64 `json:"restauranteIds" binding:"required,min=1"`
@@ -4,3 +4,16 @@ type LoginInput struct {
 	Email string `json:"email" binding:"required,email"`
 	Senha string `json:"senha" binding:"required"`
 }
+
+type RefreshTokenInput struct {
+	RefreshToken string `json:"refreshToken" binding:"required"`
+}
+
+// TokenModel é a resposta do login e da renovação. O campo "token" é o access token,
+// mantido com esse nome para os clientes existentes.
+type TokenModel struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+	TokenType    string `json:"tokenType"`
+	ExpiresIn    int64  `json:"expiresIn"`
+}
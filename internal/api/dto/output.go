@@ -47,23 +47,28 @@ type GrupoModel struct {
 
 // UsuarioModel represents Usuario output
 type UsuarioModel struct {
-	ID           uint64    `json:"id"`
-	Nome         string    `json:"nome"`
-	Email        string    `json:"email"`
-	DataCadastro time.Time `json:"dataCadastro"`
+	ID              uint64    `json:"id"`
+	Nome            string    `json:"nome"`
+	Email           string    `json:"email"`
+	EmailVerificado bool      `json:"emailVerificado"`
+	DataCadastro    time.Time `json:"dataCadastro"`
 }
 
 // RestauranteModel represents full Restaurante output
 type RestauranteModel struct {
-	ID              uint64          `json:"id"`
-	Nome            string          `json:"nome"`
-	TaxaFrete       decimal.Decimal `json:"taxaFrete"`
-	Cozinha         CozinhaModel    `json:"cozinha"`
-	Ativo           bool            `json:"ativo"`
-	Aberto          bool            `json:"aberto"`
-	Endereco        *EnderecoModel  `json:"endereco,omitempty"`
-	DataCadastro    time.Time       `json:"dataCadastro"`
-	DataAtualizacao time.Time       `json:"dataAtualizacao"`
+	ID                uint64          `json:"id"`
+	Nome              string          `json:"nome"`
+	TaxaFrete         decimal.Decimal `json:"taxaFrete"`
+	ValorMinimoPedido decimal.Decimal `json:"valorMinimoPedido"`
+	Cozinha           CozinhaModel    `json:"cozinha"`
+	Ativo             bool            `json:"ativo"`
+	Aberto            bool            `json:"aberto"`
+	FusoHorario       string          `json:"fusoHorario"`
+	Endereco          *EnderecoModel  `json:"endereco,omitempty"`
+	AvaliacaoMedia    decimal.Decimal `json:"avaliacaoMedia"`
+	TotalAvaliacoes   int64           `json:"totalAvaliacoes"`
+	DataCadastro      time.Time       `json:"dataCadastro"`
+	DataAtualizacao   time.Time       `json:"dataAtualizacao"`
 }
 
 // RestauranteResumoModel represents summary Restaurante output
@@ -74,6 +79,77 @@ type RestauranteResumoModel struct {
 	Cozinha   CozinhaModel    `json:"cozinha"`
 	Ativo     bool            `json:"ativo"`
 	Aberto    bool            `json:"aberto"`
+	// Média das avaliações dos clientes (0 sem avaliações)
+	AvaliacaoMedia  decimal.Decimal `json:"avaliacaoMedia"`
+	TotalAvaliacoes int64           `json:"totalAvaliacoes"`
+	// Presente apenas nas buscas por localização
+	DistanciaKm *float64 `json:"distanciaKm,omitempty"`
+}
+
+// ConfiguracaoFreteModel represents the freight configuration output
+type ConfiguracaoFreteModel struct {
+	Tipo             string                 `json:"tipo"`
+	FreteGratisAcima *decimal.Decimal       `json:"freteGratisAcima,omitempty"`
+	Localidades      []FreteLocalidadeModel `json:"localidades"`
+	Faixas           []FaixaFreteModel      `json:"faixas"`
+}
+
+// FreteLocalidadeModel represents a city/neighborhood fee output
+type FreteLocalidadeModel struct {
+	Cidade CidadeResumoModel `json:"cidade"`
+	Bairro string            `json:"bairro,omitempty"`
+	Taxa   decimal.Decimal   `json:"taxa"`
+}
+
+// FaixaFreteModel represents a distance band output
+type FaixaFreteModel struct {
+	DistanciaMaxKm float64         `json:"distanciaMaxKm"`
+	Taxa           decimal.Decimal `json:"taxa"`
+}
+
+// CotacaoFreteModel represents a freight quote output
+type CotacaoFreteModel struct {
+	TaxaFrete   decimal.Decimal `json:"taxaFrete"`
+	Regra       string          `json:"regra"`
+	Descricao   string          `json:"descricao"`
+	DistanciaKm *float64        `json:"distanciaKm,omitempty"`
+}
+
+// CupomModel represents Cupom output
+type CupomModel struct {
+	ID                   uint64                      `json:"id"`
+	Codigo               string                      `json:"codigo"`
+	Descricao            string                      `json:"descricao,omitempty"`
+	Tipo                 string                      `json:"tipo"`
+	Valor                decimal.Decimal             `json:"valor"`
+	Restaurante          *RestauranteApenasNomeModel `json:"restaurante,omitempty"`
+	ValorMinimoPedido    *decimal.Decimal            `json:"valorMinimoPedido,omitempty"`
+	ValidoDe             *time.Time                  `json:"validoDe,omitempty"`
+	ValidoAte            *time.Time                  `json:"validoAte,omitempty"`
+	LimiteUsos           *int                        `json:"limiteUsos,omitempty"`
+	LimiteUsosPorCliente *int                        `json:"limiteUsosPorCliente,omitempty"`
+	TotalUsos            int64                       `json:"totalUsos"`
+	Ativo                bool                        `json:"ativo"`
+	DataCadastro         time.Time                   `json:"dataCadastro"`
+	DataAtualizacao      time.Time                   `json:"dataAtualizacao"`
+}
+
+// HorarioFuncionamentoModel represents a weekly opening interval output
+type HorarioFuncionamentoModel struct {
+	ID             uint64 `json:"id"`
+	DiaSemana      string `json:"diaSemana"`
+	HoraAbertura   string `json:"horaAbertura"`
+	HoraFechamento string `json:"horaFechamento"`
+}
+
+// ExcecaoHorarioModel represents a schedule exception output
+type ExcecaoHorarioModel struct {
+	ID             uint64  `json:"id"`
+	Data           string  `json:"data"`
+	Descricao      string  `json:"descricao,omitempty"`
+	Fechado        bool    `json:"fechado"`
+	HoraAbertura   *string `json:"horaAbertura,omitempty"`
+	HoraFechamento *string `json:"horaFechamento,omitempty"`
 }
 
 // RestauranteApenasNomeModel represents minimal Restaurante output
@@ -90,6 +166,8 @@ type EnderecoModel struct {
 	Complemento string            `json:"complemento,omitempty"`
 	Bairro      string            `json:"bairro"`
 	Cidade      CidadeResumoModel `json:"cidade"`
+	Latitude    *float64          `json:"latitude,omitempty"`
+	Longitude   *float64          `json:"longitude,omitempty"`
 }
 
 // CidadeResumoModel represents summary Cidade output
@@ -108,6 +186,31 @@ type ProdutoModel struct {
 	Ativo     bool            `json:"ativo"`
 }
 
+// ProdutoFavoritoModel represents a favorite product with its restaurant
+type ProdutoFavoritoModel struct {
+	ID          uint64                     `json:"id"`
+	Nome        string                     `json:"nome"`
+	Preco       decimal.Decimal            `json:"preco"`
+	Ativo       bool                       `json:"ativo"`
+	Restaurante RestauranteApenasNomeModel `json:"restaurante"`
+}
+
+// FavoritosModel represents the customer's favorite restaurants and products
+type FavoritosModel struct {
+	Restaurantes []RestauranteResumoModel `json:"restaurantes"`
+	Produtos     []ProdutoFavoritoModel   `json:"produtos"`
+}
+
+// EnderecoUsuarioModel represents an address saved by the customer
+type EnderecoUsuarioModel struct {
+	ID              uint64        `json:"id"`
+	Apelido         string        `json:"apelido"`
+	Endereco        EnderecoModel `json:"endereco"`
+	Padrao          bool          `json:"padrao"`
+	DataCadastro    time.Time     `json:"dataCadastro"`
+	DataAtualizacao time.Time     `json:"dataAtualizacao"`
+}
+
 // FotoProdutoModel represents FotoProduto output
 type FotoProdutoModel struct {
 	NomeArquivo string `json:"nomeArquivo"`
@@ -116,15 +219,40 @@ type FotoProdutoModel struct {
 	Tamanho     int64  `json:"tamanho"`
 }
 
+// GrupoOpcoesModel represents a product option group output
+type GrupoOpcoesModel struct {
+	ID          uint64              `json:"id"`
+	Nome        string              `json:"nome"`
+	MinSelecoes int                 `json:"minSelecoes"`
+	MaxSelecoes int                 `json:"maxSelecoes"`
+	Obrigatorio bool                `json:"obrigatorio"`
+	Opcoes      []OpcaoProdutoModel `json:"opcoes"`
+}
+
+// OpcaoProdutoModel represents a product option output
+type OpcaoProdutoModel struct {
+	ID             uint64          `json:"id"`
+	Nome           string          `json:"nome"`
+	PrecoAdicional decimal.Decimal `json:"precoAdicional"`
+	Ativo          bool            `json:"ativo"`
+}
+
 // PedidoModel represents full Pedido output
 type PedidoModel struct {
 	Codigo           string                     `json:"codigo"`
 	Subtotal         decimal.Decimal            `json:"subtotal"`
 	TaxaFrete        decimal.Decimal            `json:"taxaFrete"`
+	RegraFrete       string                     `json:"regraFrete,omitempty"`
+	DescricaoFrete   string                     `json:"descricaoFrete,omitempty"`
+	Desconto         decimal.Decimal            `json:"desconto"`
+	Cupom            string                     `json:"cupom,omitempty"`
 	ValorTotal       decimal.Decimal            `json:"valorTotal"`
 	Status           string                     `json:"status"`
+	StatusDetalhado  string                     `json:"statusDetalhado"`
 	DataCriacao      time.Time                  `json:"dataCriacao"`
 	DataConfirmacao  *time.Time                 `json:"dataConfirmacao,omitempty"`
+	DataPreparacao   *time.Time                 `json:"dataPreparacao,omitempty"`
+	DataSaidaEntrega *time.Time                 `json:"dataSaidaEntrega,omitempty"`
 	DataCancelamento *time.Time                 `json:"dataCancelamento,omitempty"`
 	DataEntrega      *time.Time                 `json:"dataEntrega,omitempty"`
 	Restaurante      RestauranteApenasNomeModel `json:"restaurante"`
@@ -134,31 +262,158 @@ type PedidoModel struct {
 	Itens            []ItemPedidoModel          `json:"itens"`
 }
 
-// PedidoResumoModel represents summary Pedido output
-type PedidoResumoModel struct {
-	Codigo      string                     `json:"codigo"`
-	Subtotal    decimal.Decimal            `json:"subtotal"`
-	TaxaFrete   decimal.Decimal            `json:"taxaFrete"`
-	ValorTotal  decimal.Decimal            `json:"valorTotal"`
-	Status      string                     `json:"status"`
-	DataCriacao time.Time                  `json:"dataCriacao"`
-	Restaurante RestauranteApenasNomeModel `json:"restaurante"`
-	Cliente     UsuarioModel               `json:"cliente"`
+// RepeticaoPedidoModel represents the result of repeating a Pedido. No dry run o pedido
+// é apenas uma prévia, sem código nem status.
+type RepeticaoPedidoModel struct {
+	CodigoPedidoOriginal string                       `json:"codigoPedidoOriginal"`
+	DryRun               bool                         `json:"dryRun"`
+	ValorTotalAnterior   decimal.Decimal              `json:"valorTotalAnterior"`
+	Pedido               PedidoModel                  `json:"pedido"`
+	ItensAlterados       []ItemRepeticaoAlteradoModel `json:"itensAlterados"`
+	ItensRemovidos       []ItemRepeticaoRemovidoModel `json:"itensRemovidos"`
 }
 
-// ItemPedidoModel represents ItemPedido output
-type ItemPedidoModel struct {
+// ItemRepeticaoAlteradoModel represents an item whose unit price (with options) changed
+type ItemRepeticaoAlteradoModel struct {
+	ProdutoID     uint64          `json:"produtoId"`
+	ProdutoNome   string          `json:"produtoNome"`
+	PrecoAnterior decimal.Decimal `json:"precoAnterior"`
+	PrecoAtual    decimal.Decimal `json:"precoAtual"`
+}
+
+// ItemRepeticaoRemovidoModel represents an item left out of the repeated Pedido
+type ItemRepeticaoRemovidoModel struct {
+	ProdutoID   uint64 `json:"produtoId"`
+	ProdutoNome string `json:"produtoNome"`
+	Quantidade  int    `json:"quantidade"`
+	Motivo      string `json:"motivo"`
+}
+
+// CarrinhoModel represents a shopping cart output. Os avisos listam os itens repreçados ou
+// removidos desde a última prévia.
+type CarrinhoModel struct {
+	RestauranteID   uint64              `json:"restauranteId"`
+	Itens           []ItemCarrinhoModel `json:"itens"`
+	Avisos          []string            `json:"avisos,omitempty"`
+	DataAtualizacao *time.Time          `json:"dataAtualizacao,omitempty"`
+}
+
+// ItemCarrinhoModel represents a cart item with the price saved when it was added
+type ItemCarrinhoModel struct {
+	ID            uint64          `json:"id"`
 	ProdutoID     uint64          `json:"produtoId"`
 	ProdutoNome   string          `json:"produtoNome"`
 	Quantidade    int             `json:"quantidade"`
-	PrecoUnitario decimal.Decimal `json:"precoUnitario"`
-	PrecoTotal    decimal.Decimal `json:"precoTotal"`
 	Observacao    string          `json:"observacao,omitempty"`
+	Opcoes        []uint64        `json:"opcoes,omitempty"`
+	PrecoUnitario decimal.Decimal `json:"precoUnitario"`
+}
+
+// PreviaCarrinhoModel represents the cart with the current prices, freight and discount.
+// Os itens da prévia seguem a ordem dos itens do carrinho.
+type PreviaCarrinhoModel struct {
+	Carrinho       CarrinhoModel     `json:"carrinho"`
+	Itens          []ItemPedidoModel `json:"itens"`
+	Subtotal       decimal.Decimal   `json:"subtotal"`
+	TaxaFrete      decimal.Decimal   `json:"taxaFrete"`
+	RegraFrete     string            `json:"regraFrete,omitempty"`
+	DescricaoFrete string            `json:"descricaoFrete,omitempty"`
+	Desconto       decimal.Decimal   `json:"desconto"`
+	Cupom          string            `json:"cupom,omitempty"`
+	ValorTotal     decimal.Decimal   `json:"valorTotal"`
+	Pendencias     []ObjectError     `json:"pendencias,omitempty"`
+}
+
+// PedidoResumoModel represents summary Pedido output
+type PedidoResumoModel struct {
+	Codigo          string                     `json:"codigo"`
+	Subtotal        decimal.Decimal            `json:"subtotal"`
+	TaxaFrete       decimal.Decimal            `json:"taxaFrete"`
+	Desconto        decimal.Decimal            `json:"desconto"`
+	ValorTotal      decimal.Decimal            `json:"valorTotal"`
+	Status          string                     `json:"status"`
+	StatusDetalhado string                     `json:"statusDetalhado"`
+	DataCriacao     time.Time                  `json:"dataCriacao"`
+	Restaurante     RestauranteApenasNomeModel `json:"restaurante"`
+	Cliente         UsuarioModel               `json:"cliente"`
+}
+
+// PagamentoModel represents Pagamento output
+type PagamentoModel struct {
+	Provedor        string          `json:"provedor"`
+	TransacaoID     string          `json:"transacaoId"`
+	Valor           decimal.Decimal `json:"valor"`
+	Status          string          `json:"status"`
+	Mensagem        string          `json:"mensagem,omitempty"`
+	DataCriacao     time.Time       `json:"dataCriacao"`
+	DataAtualizacao time.Time       `json:"dataAtualizacao"`
+	DataCaptura     *time.Time      `json:"dataCaptura,omitempty"`
+	DataEstorno     *time.Time      `json:"dataEstorno,omitempty"`
+}
+
+// AvaliacaoModel represents Avaliacao output
+type AvaliacaoModel struct {
+	ID           uint64             `json:"id"`
+	Nota         int                `json:"nota"`
+	Comentario   string             `json:"comentario,omitempty"`
+	Cliente      UsuarioResumoModel `json:"cliente"`
+	Resposta     string             `json:"resposta,omitempty"`
+	DataResposta *time.Time         `json:"dataResposta,omitempty"`
+	DataCriacao  time.Time          `json:"dataCriacao"`
+}
+
+// PedidoStatusHistoricoModel represents a status change of a Pedido
+type PedidoStatusHistoricoModel struct {
+	StatusAnterior *string             `json:"statusAnterior"`
+	StatusNovo     string              `json:"statusNovo"`
+	Usuario        *UsuarioResumoModel `json:"usuario,omitempty"`
+	Motivo         *string             `json:"motivo,omitempty"`
+	DataAlteracao  time.Time           `json:"dataAlteracao"`
+}
+
+// UsuarioResumoModel represents Usuario output without contact data
+type UsuarioResumoModel struct {
+	ID   uint64 `json:"id"`
+	Nome string `json:"nome"`
+}
+
+// ItemPedidoModel represents ItemPedido output
+type ItemPedidoModel struct {
+	ProdutoID     uint64                 `json:"produtoId"`
+	ProdutoNome   string                 `json:"produtoNome"`
+	Quantidade    int                    `json:"quantidade"`
+	PrecoUnitario decimal.Decimal        `json:"precoUnitario"`
+	PrecoTotal    decimal.Decimal        `json:"precoTotal"`
+	Observacao    string                 `json:"observacao,omitempty"`
+	Opcoes        []ItemPedidoOpcaoModel `json:"opcoes,omitempty"`
+}
+
+// ItemPedidoOpcaoModel represents an option chosen for an order item
+type ItemPedidoOpcaoModel struct {
+	OpcaoID        uint64          `json:"opcaoId"`
+	Grupo          string          `json:"grupo"`
+	Nome           string          `json:"nome"`
+	PrecoAdicional decimal.Decimal `json:"precoAdicional"`
 }
 
 // VendaDiariaModel represents daily sales output
 type VendaDiariaModel struct {
-	Data          string  `json:"data"`
-	TotalVendas   int64   `json:"totalVendas"`
-	TotalFaturado float64 `json:"totalFaturado"`
+	Data           string  `json:"data"`
+	TotalVendas    int64   `json:"totalVendas"`
+	TotalFaturado  float64 `json:"totalFaturado"`
+	TotalDescontos float64 `json:"totalDescontos"`
+}
+
+// EventoOutboxModel represents EventoOutbox output
+type EventoOutboxModel struct {
+	ID               uint64     `json:"id"`
+	Tipo             string     `json:"tipo"`
+	Status           string     `json:"status"`
+	Tentativas       int        `json:"tentativas"`
+	UltimoErro       string     `json:"ultimoErro,omitempty"`
+	Payload          string     `json:"payload"`
+	DataOcorrencia   time.Time  `json:"dataOcorrencia"`
+	DataCriacao      time.Time  `json:"dataCriacao"`
+	ProximaTentativa time.Time  `json:"proximaTentativa"`
+	DataPublicacao   *time.Time `json:"dataPublicacao,omitempty"`
 }
@@ -15,6 +15,10 @@ const (
 	ProblemTypeInvalidData        ProblemType = "dados-invalidos"
 	ProblemTypeAccessDenied       ProblemType = "acesso-negado"
 	ProblemTypeInvalidCredentials ProblemType = "credenciais-invalidas"
+	ProblemTypeIdempotencyKeyUsed ProblemType = "chave-idempotencia-reutilizada"
+	ProblemTypeRequestInProgress  ProblemType = "requisicao-em-processamento"
+	ProblemTypeTooManyRequests    ProblemType = "limite-requisicoes-excedido"
+	ProblemTypeStatusChanged      ProblemType = "status-alterado"
 )
 
 var problemTypeTitles = map[ProblemType]string{
@@ -27,6 +31,10 @@ var problemTypeTitles = map[ProblemType]string{
 	ProblemTypeInvalidData:        "Dados invalidos",
 	ProblemTypeAccessDenied:       "Acesso negado",
 	ProblemTypeInvalidCredentials: "Credenciais invalidas",
+	ProblemTypeIdempotencyKeyUsed: "Chave de idempotencia reutilizada",
+	ProblemTypeRequestInProgress:  "Requisicao em processamento",
+	ProblemTypeTooManyRequests:    "Limite de requisicoes excedido",
+	ProblemTypeStatusChanged:      "Status alterado",
 }
 
 func (p ProblemType) Title() string {
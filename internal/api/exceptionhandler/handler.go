@@ -2,12 +2,15 @@ package exceptionhandler
 
 import (
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/yurisasc/algafood-go/internal/api/dto"
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
 
 const (
@@ -21,6 +24,12 @@ func HandleError(c *gin.Context, err error) {
 	var entidadeEmUso *exception.EntidadeEmUsoException
 	var negocioException *exception.NegocioException
 	var authenticationException *exception.AuthenticationException
+	var chaveIdempotenciaReutilizada *exception.ChaveIdempotenciaReutilizadaException
+	var requisicaoEmProcessamento *exception.RequisicaoEmProcessamentoException
+	var statusPedidoAlterado *exception.StatusPedidoAlteradoException
+	var limiteExcedido *exception.LimiteRequisicoesExcedidoException
+	var ordenacaoInvalida *pagination.OrdenacaoInvalidaError
+	var cursorInvalido *pagination.CursorInvalidoError
 
 	// Check for specific not found exceptions
 	var estadoNaoEncontrado *exception.EstadoNaoEncontradoException
@@ -34,6 +43,15 @@ func HandleError(c *gin.Context, err error) {
 	var permissaoNaoEncontrada *exception.PermissaoNaoEncontradaException
 	var pedidoNaoEncontrado *exception.PedidoNaoEncontradoException
 	var fotoProdutoNaoEncontrada *exception.FotoProdutoNaoEncontradaException
+	var eventoOutboxNaoEncontrado *exception.EventoOutboxNaoEncontradoException
+	var horarioNaoEncontrado *exception.HorarioFuncionamentoNaoEncontradoException
+	var excecaoHorarioNaoEncontrada *exception.ExcecaoHorarioNaoEncontradaException
+	var cupomNaoEncontrado *exception.CupomNaoEncontradoException
+	var grupoOpcoesNaoEncontrado *exception.GrupoOpcoesNaoEncontradoException
+	var pagamentoNaoEncontrado *exception.PagamentoNaoEncontradoException
+	var avaliacaoNaoEncontrada *exception.AvaliacaoNaoEncontradaException
+	var enderecoUsuarioNaoEncontrado *exception.EnderecoUsuarioNaoEncontradoException
+	var itemCarrinhoNaoEncontrado *exception.ItemCarrinhoNaoEncontradoException
 
 	switch {
 	case errors.As(err, &authenticationException):
@@ -60,12 +78,42 @@ func HandleError(c *gin.Context, err error) {
 		handleNotFound(c, pedidoNaoEncontrado.Message)
 	case errors.As(err, &fotoProdutoNaoEncontrada):
 		handleNotFound(c, fotoProdutoNaoEncontrada.Message)
+	case errors.As(err, &eventoOutboxNaoEncontrado):
+		handleNotFound(c, eventoOutboxNaoEncontrado.Message)
+	case errors.As(err, &horarioNaoEncontrado):
+		handleNotFound(c, horarioNaoEncontrado.Message)
+	case errors.As(err, &excecaoHorarioNaoEncontrada):
+		handleNotFound(c, excecaoHorarioNaoEncontrada.Message)
+	case errors.As(err, &cupomNaoEncontrado):
+		handleNotFound(c, cupomNaoEncontrado.Message)
+	case errors.As(err, &grupoOpcoesNaoEncontrado):
+		handleNotFound(c, grupoOpcoesNaoEncontrado.Message)
+	case errors.As(err, &pagamentoNaoEncontrado):
+		handleNotFound(c, pagamentoNaoEncontrado.Message)
+	case errors.As(err, &avaliacaoNaoEncontrada):
+		handleNotFound(c, avaliacaoNaoEncontrada.Message)
+	case errors.As(err, &enderecoUsuarioNaoEncontrado):
+		handleNotFound(c, enderecoUsuarioNaoEncontrado.Message)
+	case errors.As(err, &itemCarrinhoNaoEncontrado):
+		handleNotFound(c, itemCarrinhoNaoEncontrado.Message)
 	case errors.As(err, &entidadeNaoEncontrada):
 		handleNotFound(c, entidadeNaoEncontrada.Message)
+	case errors.As(err, &chaveIdempotenciaReutilizada):
+		handleProblem(c, http.StatusUnprocessableEntity, dto.ProblemTypeIdempotencyKeyUsed, chaveIdempotenciaReutilizada.Message)
+	case errors.As(err, &requisicaoEmProcessamento):
+		handleProblem(c, http.StatusConflict, dto.ProblemTypeRequestInProgress, requisicaoEmProcessamento.Message)
+	case errors.As(err, &statusPedidoAlterado):
+		handleProblem(c, http.StatusConflict, dto.ProblemTypeStatusChanged, statusPedidoAlterado.Message)
+	case errors.As(err, &ordenacaoInvalida):
+		handleProblem(c, http.StatusBadRequest, dto.ProblemTypeInvalidParameter, ordenacaoInvalida.Message)
+	case errors.As(err, &cursorInvalido):
+		handleProblem(c, http.StatusBadRequest, dto.ProblemTypeInvalidParameter, cursorInvalido.Message)
+	case errors.As(err, &limiteExcedido):
+		handleTooManyRequests(c, limiteExcedido)
 	case errors.As(err, &entidadeEmUso):
 		handleConflict(c, entidadeEmUso.Message)
 	case errors.As(err, &negocioException):
-		handleBadRequest(c, negocioException.Message)
+		handleNegocio(c, negocioException)
 	default:
 		handleInternalError(c, err)
 	}
@@ -104,6 +152,19 @@ func HandleValidationError(c *gin.Context, err error) {
 	c.JSON(http.StatusBadRequest, problem)
 }
 
+// HandleQueryError handles query parameter binding errors: validation errors are
+// reported per field and malformed values as an invalid parameter
+func HandleQueryError(c *gin.Context, err error) {
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		HandleValidationError(c, err)
+		return
+	}
+
+	handleProblem(c, http.StatusBadRequest, dto.ProblemTypeInvalidParameter,
+		"Um ou mais parametros da URL possuem valor invalido. Verifique os tipos informados.")
+}
+
 func handleNotFound(c *gin.Context, message string) {
 	problem := dto.NewProblem(
 		http.StatusNotFound,
@@ -134,6 +195,50 @@ func handleBadRequest(c *gin.Context, message string) {
 	c.JSON(http.StatusBadRequest, problem)
 }
 
+func handleNegocio(c *gin.Context, e *exception.NegocioException) {
+	if len(e.Campos) == 0 {
+		handleBadRequest(c, e.Message)
+		return
+	}
+
+	objects := make([]dto.ObjectError, 0, len(e.Campos))
+	for _, campo := range e.Campos {
+		objects = append(objects, dto.ObjectError{
+			Name:        campo.Nome,
+			UserMessage: campo.Mensagem,
+		})
+	}
+
+	problem := dto.NewProblemWithObjects(
+		http.StatusBadRequest,
+		dto.ProblemTypeBusinessError,
+		e.Message,
+		e.Message,
+		objects,
+	)
+	c.JSON(http.StatusBadRequest, problem)
+}
+
+func handleProblem(c *gin.Context, status int, problemType dto.ProblemType, message string) {
+	problem := dto.NewProblem(
+		status,
+		problemType,
+		message,
+		message,
+	)
+	c.JSON(status, problem)
+}
+
+// handleTooManyRequests responde 429 com Retry-After em segundos (arredondado para cima)
+func handleTooManyRequests(c *gin.Context, e *exception.LimiteRequisicoesExcedidoException) {
+	segundos := int64(math.Ceil(e.RetryAfter.Seconds()))
+	if segundos < 1 {
+		segundos = 1
+	}
+	c.Header("Retry-After", strconv.FormatInt(segundos, 10))
+	handleProblem(c, http.StatusTooManyRequests, dto.ProblemTypeTooManyRequests, e.Message)
+}
+
 func handleInternalError(c *gin.Context, err error) {
 	problem := dto.NewProblem(
 		http.StatusInternalServerError,
@@ -148,6 +253,10 @@ func getValidationMessage(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
 		return fe.Field() + " e obrigatorio"
+	case "required_with":
+		return fe.Field() + " e obrigatorio quando " + fe.Param() + " e informado"
+	case "excluded_with":
+		return fe.Field() + " nao deve ser informado junto com " + fe.Param()
 	case "email":
 		return fe.Field() + " deve ser um e-mail valido"
 	case "min":
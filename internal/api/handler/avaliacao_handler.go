@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+)
+
+type AvaliacaoHandler struct {
+	service *service.AvaliacaoService
+}
+
+func NewAvaliacaoHandler(service *service.AvaliacaoService) *AvaliacaoHandler {
+	return &AvaliacaoHandler{service: service}
+}
+
+func (h *AvaliacaoHandler) ListarPorRestaurante(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	result, err := h.service.FindAllByRestaurante(restauranteID, pagination.NewPageableFromContext(c))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, assembler.ToAvaliacaoModels(result.Content)))
+}
+
+func (h *AvaliacaoHandler) BuscarPorPedido(c *gin.Context) {
+	avaliacao, err := h.service.FindByPedido(c.Param("codigoPedido"))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToAvaliacaoModel(avaliacao))
+}
+
+func (h *AvaliacaoHandler) Avaliar(c *gin.Context) {
+	var input dto.AvaliacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	avaliacao := assembler.ToAvaliacaoEntity(&input)
+	if err := h.service.Avaliar(c.Param("codigoPedido"), avaliacao); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, assembler.ToAvaliacaoModel(avaliacao))
+}
+
+func (h *AvaliacaoHandler) Responder(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	avaliacaoID, _ := strconv.ParseUint(c.Param("avaliacaoId"), 10, 64)
+
+	var input dto.RespostaAvaliacaoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	avaliacao, err := h.service.Responder(restauranteID, avaliacaoID, input.Resposta)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToAvaliacaoModel(avaliacao))
+}
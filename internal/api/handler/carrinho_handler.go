@@ -0,0 +1,157 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/api/middleware"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// CarrinhoHandler gerencia o carrinho do usuário autenticado em cada restaurante
+type CarrinhoHandler struct {
+	service        *service.CarrinhoService
+	idempotencySvc *service.IdempotencyService
+}
+
+func NewCarrinhoHandler(service *service.CarrinhoService, idempotencySvc *service.IdempotencyService) *CarrinhoHandler {
+	return &CarrinhoHandler{
+		service:        service,
+		idempotencySvc: idempotencySvc,
+	}
+}
+
+// Buscar retorna o carrinho com a prévia do pedido. Aceita formaPagamentoId, enderecoId e
+// cupom na query; sem enderecoId o frete é cotado para o endereço padrão do cliente.
+func (h *CarrinhoHandler) Buscar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	dados := &model.Pedido{CodigoCupom: strings.ToUpper(strings.TrimSpace(c.Query("cupom")))}
+	if formaPagamentoIDStr := c.Query("formaPagamentoId"); formaPagamentoIDStr != "" {
+		dados.FormaPagamentoID, _ = strconv.ParseUint(formaPagamentoIDStr, 10, 64)
+	}
+	if enderecoIDStr := c.Query("enderecoId"); enderecoIDStr != "" {
+		enderecoID, _ := strconv.ParseUint(enderecoIDStr, 10, 64)
+		dados.EnderecoSalvoID = &enderecoID
+	}
+
+	previa, err := h.service.Previa(usuario.ID, restauranteID, dados)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToPreviaCarrinhoModel(previa.Carrinho, previa.Pedido, previa.Pendencias))
+}
+
+func (h *CarrinhoHandler) AdicionarItem(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.ItemPedidoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	carrinho, err := h.service.AdicionarItem(usuario.ID, restauranteID, assembler.ToItemCarrinhoEntity(&input))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, assembler.ToCarrinhoModel(carrinho))
+}
+
+func (h *CarrinhoHandler) AtualizarItem(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	itemID, _ := strconv.ParseUint(c.Param("itemId"), 10, 64)
+
+	var input dto.ItemPedidoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	carrinho, err := h.service.AtualizarItem(usuario.ID, restauranteID, itemID, assembler.ToItemCarrinhoEntity(&input))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToCarrinhoModel(carrinho))
+}
+
+func (h *CarrinhoHandler) RemoverItem(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	itemID, _ := strconv.ParseUint(c.Param("itemId"), 10, 64)
+
+	if _, err := h.service.RemoverItem(usuario.ID, restauranteID, itemID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CarrinhoHandler) Limpar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	if err := h.service.Limpar(usuario.ID, restauranteID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// Checkout emite o pedido com os itens do carrinho e os preços atuais. Aceita o header
+// Idempotency-Key como POST /v1/pedidos, para que um retry não emita o pedido duas vezes.
+func (h *CarrinhoHandler) Checkout(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.CheckoutCarrinhoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	escopo := "carrinho-checkout:" + strconv.FormatUint(usuario.ID, 10) + ":" + strconv.FormatUint(restauranteID, 10)
+	criarIdempotente(c, h.idempotencySvc, escopo, &input, http.StatusCreated, func() (any, error) {
+		pedido, err := h.service.Checkout(usuario.ID, restauranteID, assembler.ToCheckoutCarrinhoEntity(&input))
+		if err != nil {
+			return nil, err
+		}
+		return assembler.ToPedidoModel(pedido), nil
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/yurisasc/algafood-go/internal/api/dto"
 	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
 
 type CidadeHandler struct {
@@ -20,12 +21,18 @@ func NewCidadeHandler(service *service.CidadeService) *CidadeHandler {
 }
 
 func (h *CidadeHandler) Listar(c *gin.Context) {
-	cidades, err := h.service.FindAll()
+	pageable := pagination.NewPageableOpcionalFromContext(c)
+	result, err := h.service.FindAll(pageable)
 	if err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, assembler.ToCidadeModels(cidades))
+	models := assembler.ToCidadeModels(result.Content)
+	if !pageable.Paginado() {
+		c.JSON(http.StatusOK, models)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, models))
 }
 
 func (h *CidadeHandler) Buscar(c *gin.Context) {
@@ -28,21 +28,7 @@ func (h *CozinhaHandler) Listar(c *gin.Context) {
 		return
 	}
 
-	// Convert to DTOs
-	content := assembler.ToCozinhaModels(result.Content)
-	response := pagination.Page[dto.CozinhaModel]{
-		Content:          content,
-		TotalElements:    result.TotalElements,
-		TotalPages:       result.TotalPages,
-		Size:             result.Size,
-		Number:           result.Number,
-		NumberOfElements: result.NumberOfElements,
-		First:            result.First,
-		Last:             result.Last,
-		Empty:            result.Empty,
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, pagination.MapPage(result, assembler.ToCozinhaModels(result.Content)))
 }
 
 func (h *CozinhaHandler) Buscar(c *gin.Context) {
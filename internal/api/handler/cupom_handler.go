@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+)
+
+type CupomHandler struct {
+	service *service.CupomService
+}
+
+func NewCupomHandler(service *service.CupomService) *CupomHandler {
+	return &CupomHandler{service: service}
+}
+
+func (h *CupomHandler) Listar(c *gin.Context) {
+	filter := &repository.CupomFilter{}
+	if restauranteIDStr := c.Query("restauranteId"); restauranteIDStr != "" {
+		restauranteID, _ := strconv.ParseUint(restauranteIDStr, 10, 64)
+		filter.RestauranteID = &restauranteID
+	}
+	if ativoStr := c.Query("ativo"); ativoStr != "" {
+		ativo, _ := strconv.ParseBool(ativoStr)
+		filter.Ativo = &ativo
+	}
+
+	result, err := h.service.FindAll(filter, pagination.NewPageableFromContext(c))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, assembler.ToCupomModels(result.Content)))
+}
+
+func (h *CupomHandler) Buscar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("cupomId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	cupom, err := h.service.FindByID(id)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToCupomModel(cupom))
+}
+
+func (h *CupomHandler) Adicionar(c *gin.Context) {
+	var input dto.CupomInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	cupom := assembler.ToCupomEntity(&input)
+	if err := h.service.Save(cupom); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assembler.ToCupomModel(cupom))
+}
+
+func (h *CupomHandler) Atualizar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("cupomId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	var input dto.CupomInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	cupom, err := h.service.FindByID(id)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	// Update fields from input
+	updated := assembler.ToCupomEntity(&input)
+	cupom.Codigo = updated.Codigo
+	cupom.Descricao = updated.Descricao
+	cupom.Tipo = updated.Tipo
+	cupom.Valor = updated.Valor
+	cupom.RestauranteID = updated.RestauranteID
+	cupom.ValorMinimoPedido = updated.ValorMinimoPedido
+	cupom.ValidoDe = updated.ValidoDe
+	cupom.ValidoAte = updated.ValidoAte
+	cupom.LimiteUsos = updated.LimiteUsos
+	cupom.LimiteUsosPorCliente = updated.LimiteUsosPorCliente
+	cupom.Ativo = updated.Ativo
+
+	if err := h.service.Save(cupom); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToCupomModel(cupom))
+}
+
+func (h *CupomHandler) Ativar(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("cupomId"), 10, 64)
+	if err := h.service.Ativar(id); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CupomHandler) Inativar(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("cupomId"), 10, 64)
+	if err := h.service.Inativar(id); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CupomHandler) Remover(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("cupomId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	if err := h.service.Excluir(id); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
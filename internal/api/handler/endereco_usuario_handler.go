@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/api/middleware"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// EnderecoUsuarioHandler gerencia os endereços salvos do usuário autenticado
+type EnderecoUsuarioHandler struct {
+	service *service.EnderecoUsuarioService
+}
+
+func NewEnderecoUsuarioHandler(service *service.EnderecoUsuarioService) *EnderecoUsuarioHandler {
+	return &EnderecoUsuarioHandler{service: service}
+}
+
+func (h *EnderecoUsuarioHandler) Listar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	enderecos, err := h.service.FindAllByUsuario(usuario.ID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToEnderecoUsuarioModels(enderecos))
+}
+
+func (h *EnderecoUsuarioHandler) Buscar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	enderecoID, _ := strconv.ParseUint(c.Param("enderecoId"), 10, 64)
+
+	endereco, err := h.service.FindByID(usuario.ID, enderecoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToEnderecoUsuarioModel(endereco))
+}
+
+func (h *EnderecoUsuarioHandler) Adicionar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	var input dto.EnderecoUsuarioInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	endereco := assembler.ToEnderecoUsuarioEntity(&input)
+	if err := h.service.Save(usuario.ID, endereco); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, assembler.ToEnderecoUsuarioModel(endereco))
+}
+
+func (h *EnderecoUsuarioHandler) Atualizar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	enderecoID, _ := strconv.ParseUint(c.Param("enderecoId"), 10, 64)
+
+	var input dto.EnderecoUsuarioInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	endereco, err := h.service.FindByID(usuario.ID, enderecoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	// Update fields
+	updated := assembler.ToEnderecoUsuarioEntity(&input)
+	endereco.Apelido = updated.Apelido
+	endereco.Endereco = updated.Endereco
+	endereco.Padrao = updated.Padrao
+
+	if err := h.service.Save(usuario.ID, endereco); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToEnderecoUsuarioModel(endereco))
+}
+
+func (h *EnderecoUsuarioHandler) DefinirPadrao(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	enderecoID, _ := strconv.ParseUint(c.Param("enderecoId"), 10, 64)
+
+	if err := h.service.DefinirPadrao(usuario.ID, enderecoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *EnderecoUsuarioHandler) Remover(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	enderecoID, _ := strconv.ParseUint(c.Param("enderecoId"), 10, 64)
+
+	if err := h.service.Excluir(usuario.ID, enderecoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -44,9 +44,10 @@ func (h *EstatisticaHandler) ConsultarVendasDiarias(c *gin.Context) {
 	models := make([]dto.VendaDiariaModel, len(vendas))
 	for i, v := range vendas {
 		models[i] = dto.VendaDiariaModel{
-			Data:          v.Data,
-			TotalVendas:   v.TotalVendas,
-			TotalFaturado: v.TotalFaturado,
+			Data:           v.Data,
+			TotalVendas:    v.TotalVendas,
+			TotalFaturado:  v.TotalFaturado,
+			TotalDescontos: v.TotalDescontos,
 		}
 	}
 
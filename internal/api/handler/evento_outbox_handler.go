@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+)
+
+type EventoOutboxHandler struct {
+	service *service.EventoOutboxService
+}
+
+func NewEventoOutboxHandler(service *service.EventoOutboxService) *EventoOutboxHandler {
+	return &EventoOutboxHandler{service: service}
+}
+
+func (h *EventoOutboxHandler) Pesquisar(c *gin.Context) {
+	page := pagination.NewPageableFromContext(c)
+
+	var status *model.StatusEventoOutbox
+	if statusStr := c.Query("status"); statusStr != "" {
+		s := model.StatusEventoOutbox(statusStr)
+		status = &s
+	}
+
+	result, err := h.service.Pesquisar(status, page)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pagination.MapPage(result, assembler.ToEventoOutboxModels(result.Content)))
+}
+
+func (h *EventoOutboxHandler) Buscar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("eventoId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	evento, err := h.service.FindByID(id)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToEventoOutboxModel(evento))
+}
+
+func (h *EventoOutboxHandler) Reprocessar(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("eventoId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ID invalido"})
+		return
+	}
+
+	if err := h.service.Reprocessar(id); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/api/middleware"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// FavoritoHandler gerencia os restaurantes e produtos favoritos do usuário autenticado
+type FavoritoHandler struct {
+	service *service.FavoritoService
+}
+
+func NewFavoritoHandler(service *service.FavoritoService) *FavoritoHandler {
+	return &FavoritoHandler{service: service}
+}
+
+func (h *FavoritoHandler) Listar(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	restaurantes, err := h.service.FindRestaurantes(usuario.ID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	produtos, err := h.service.FindProdutos(usuario.ID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToFavoritosModel(restaurantes, produtos))
+}
+
+func (h *FavoritoHandler) FavoritarRestaurante(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	if err := h.service.FavoritarRestaurante(usuario.ID, restauranteID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FavoritoHandler) DesfavoritarRestaurante(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	if err := h.service.DesfavoritarRestaurante(usuario.ID, restauranteID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FavoritoHandler) FavoritarProduto(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	if err := h.service.FavoritarProduto(usuario.ID, restauranteID, produtoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *FavoritoHandler) DesfavoritarProduto(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	if err := h.service.DesfavoritarProduto(usuario.ID, restauranteID, produtoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,192 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// defaultMaxFileSize é usado quando storage.max_file_size não está configurado (500KB)
+const defaultMaxFileSize int64 = 500 * 1024
+
+// overheadMultipart cobre os cabeçalhos das partes e o campo de descrição além do arquivo
+const overheadMultipart int64 = 64 * 1024
+
+var contentTypesPermitidos = []string{"image/jpeg", "image/png"}
+
+type FotoProdutoHandler struct {
+	service     *service.FotoProdutoService
+	maxFileSize int64
+}
+
+func NewFotoProdutoHandler(service *service.FotoProdutoService, maxFileSize int64) *FotoProdutoHandler {
+	if maxFileSize <= 0 {
+		maxFileSize = defaultMaxFileSize
+	}
+	return &FotoProdutoHandler{
+		service:     service,
+		maxFileSize: maxFileSize,
+	}
+}
+
+func (h *FotoProdutoHandler) Atualizar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	// Limita o corpo antes do parse do multipart, para não receber arquivos grandes inteiros
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxFileSize+overheadMultipart)
+
+	var input dto.FotoProdutoInput
+	if err := c.ShouldBind(&input); err != nil {
+		if tamanhoExcedido(err) {
+			h.arquivoMuitoGrande(c)
+			return
+		}
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	header, err := c.FormFile("arquivo")
+	if err != nil {
+		if tamanhoExcedido(err) {
+			h.arquivoMuitoGrande(c)
+			return
+		}
+		exceptionhandler.HandleError(c, exception.NewNegocioException("O arquivo da foto e obrigatorio"))
+		return
+	}
+
+	if header.Size > h.maxFileSize {
+		h.arquivoMuitoGrande(c)
+		return
+	}
+
+	arquivo, err := header.Open()
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	defer arquivo.Close()
+
+	// Valida o tipo real do conteudo, nao apenas o header enviado pelo cliente
+	buffer := make([]byte, 512)
+	n, err := io.ReadFull(arquivo, buffer)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	contentType := http.DetectContentType(buffer[:n])
+	if !contentTypePermitido(contentType) {
+		exceptionhandler.HandleError(c, exception.NewNegocioException(
+			"O arquivo deve ser do tipo "+strings.Join(contentTypesPermitidos, " ou ")))
+		return
+	}
+	if _, err := arquivo.Seek(0, io.SeekStart); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	foto := &model.FotoProduto{
+		NomeArquivo: header.Filename,
+		Descricao:   input.Descricao,
+		ContentType: contentType,
+		Tamanho:     header.Size,
+	}
+
+	if err := h.service.Salvar(restauranteID, produtoID, foto, arquivo); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToFotoProdutoModel(foto))
+}
+
+// Buscar retorna os metadados da foto em JSON ou o conteudo da imagem,
+// conforme o header Accept da requisicao.
+func (h *FotoProdutoHandler) Buscar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	foto, err := h.service.FindByID(restauranteID, produtoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	accept := c.GetHeader("Accept")
+	if !aceitaImagem(accept, foto.ContentType) {
+		if accept == "" || strings.Contains(accept, gin.MIMEJSON) || strings.Contains(accept, "*/*") {
+			c.JSON(http.StatusOK, assembler.ToFotoProdutoModel(foto))
+			return
+		}
+		c.Status(http.StatusNotAcceptable)
+		return
+	}
+
+	if url, ok := h.service.URL(foto); ok {
+		c.Redirect(http.StatusFound, url)
+		return
+	}
+
+	conteudo, err := h.service.Recuperar(foto)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	defer conteudo.Close()
+
+	c.DataFromReader(http.StatusOK, foto.Tamanho, foto.ContentType, conteudo, nil)
+}
+
+func (h *FotoProdutoHandler) Remover(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	if err := h.service.Excluir(restauranteID, produtoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func contentTypePermitido(contentType string) bool {
+	for _, permitido := range contentTypesPermitidos {
+		if contentType == permitido {
+			return true
+		}
+	}
+	return false
+}
+
+// aceitaImagem verifica se o header Accept pede explicitamente uma imagem compativel com a foto
+func aceitaImagem(accept, contentType string) bool {
+	for _, mediaType := range strings.Split(accept, ",") {
+		mediaType = strings.TrimSpace(strings.SplitN(mediaType, ";", 2)[0])
+		if mediaType == contentType || mediaType == "image/*" {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *FotoProdutoHandler) arquivoMuitoGrande(c *gin.Context) {
+	exceptionhandler.HandleError(c, exception.NewNegocioException(
+		fmt.Sprintf("O arquivo deve ter no maximo %d bytes", h.maxFileSize)))
+}
+
+// tamanhoExcedido indica que o corpo da requisição passou do limite do MaxBytesReader
+func tamanhoExcedido(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
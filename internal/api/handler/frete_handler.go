@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+type FreteHandler struct {
+	service *service.FreteService
+}
+
+func NewFreteHandler(service *service.FreteService) *FreteHandler {
+	return &FreteHandler{service: service}
+}
+
+func (h *FreteHandler) BuscarConfiguracao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	configuracao, err := h.service.BuscarConfiguracao(restauranteID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToConfiguracaoFreteModel(configuracao))
+}
+
+// AtualizarConfiguracao substitui a configuração de frete do restaurante
+func (h *FreteHandler) AtualizarConfiguracao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.ConfiguracaoFreteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	configuracao := assembler.ToConfiguracaoFreteEntity(&input)
+	if err := h.service.SalvarConfiguracao(restauranteID, configuracao); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToConfiguracaoFreteModel(configuracao))
+}
+
+// Simular cota o frete de uma entrega com a mesma regra usada na emissão do pedido
+func (h *FreteHandler) Simular(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.SimulacaoFreteInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	cotacao, err := h.service.Simular(restauranteID, &service.SolicitacaoFrete{
+		CidadeID:  input.Cidade.ID,
+		Bairro:    input.Bairro,
+		Latitude:  input.Latitude,
+		Longitude: input.Longitude,
+		Subtotal:  decimal.NewFromFloat(input.Subtotal),
+	})
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToCotacaoFreteModel(cotacao))
+}
@@ -0,0 +1,108 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+type GrupoOpcoesHandler struct {
+	service *service.GrupoOpcoesService
+}
+
+func NewGrupoOpcoesHandler(service *service.GrupoOpcoesService) *GrupoOpcoesHandler {
+	return &GrupoOpcoesHandler{service: service}
+}
+
+func (h *GrupoOpcoesHandler) Listar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	grupos, err := h.service.FindAllByProduto(restauranteID, produtoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToGrupoOpcoesModels(grupos))
+}
+
+func (h *GrupoOpcoesHandler) Buscar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+	grupoID, _ := strconv.ParseUint(c.Param("grupoId"), 10, 64)
+
+	grupo, err := h.service.FindByID(restauranteID, produtoID, grupoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToGrupoOpcoesModel(grupo))
+}
+
+func (h *GrupoOpcoesHandler) Adicionar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+
+	var input dto.GrupoOpcoesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	grupo := assembler.ToGrupoOpcoesEntity(&input)
+	if err := h.service.Save(restauranteID, produtoID, grupo); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assembler.ToGrupoOpcoesModel(grupo))
+}
+
+func (h *GrupoOpcoesHandler) Atualizar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+	grupoID, _ := strconv.ParseUint(c.Param("grupoId"), 10, 64)
+
+	var input dto.GrupoOpcoesInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	grupo, err := h.service.FindByID(restauranteID, produtoID, grupoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	// Update fields (as opções informadas substituem as atuais)
+	updated := assembler.ToGrupoOpcoesEntity(&input)
+	grupo.Nome = updated.Nome
+	grupo.MinSelecoes = updated.MinSelecoes
+	grupo.MaxSelecoes = updated.MaxSelecoes
+	grupo.Opcoes = updated.Opcoes
+
+	if err := h.service.Save(restauranteID, produtoID, grupo); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToGrupoOpcoesModel(grupo))
+}
+
+func (h *GrupoOpcoesHandler) Remover(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	produtoID, _ := strconv.ParseUint(c.Param("produtoId"), 10, 64)
+	grupoID, _ := strconv.ParseUint(c.Param("grupoId"), 10, 64)
+
+	if err := h.service.Excluir(restauranteID, produtoID, grupoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
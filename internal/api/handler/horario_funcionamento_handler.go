@@ -0,0 +1,186 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+type HorarioFuncionamentoHandler struct {
+	service *service.HorarioFuncionamentoService
+}
+
+func NewHorarioFuncionamentoHandler(service *service.HorarioFuncionamentoService) *HorarioFuncionamentoHandler {
+	return &HorarioFuncionamentoHandler{service: service}
+}
+
+func (h *HorarioFuncionamentoHandler) Listar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	horarios, err := h.service.FindAllByRestaurante(restauranteID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToHorarioFuncionamentoModels(horarios))
+}
+
+func (h *HorarioFuncionamentoHandler) Buscar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	horarioID, _ := strconv.ParseUint(c.Param("horarioId"), 10, 64)
+
+	horario, err := h.service.FindByID(restauranteID, horarioID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToHorarioFuncionamentoModel(horario))
+}
+
+func (h *HorarioFuncionamentoHandler) Adicionar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.HorarioFuncionamentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	horario := assembler.ToHorarioFuncionamentoEntity(&input)
+	if err := h.service.Save(restauranteID, horario); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assembler.ToHorarioFuncionamentoModel(horario))
+}
+
+func (h *HorarioFuncionamentoHandler) Atualizar(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	horarioID, _ := strconv.ParseUint(c.Param("horarioId"), 10, 64)
+
+	var input dto.HorarioFuncionamentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	horario, err := h.service.FindByID(restauranteID, horarioID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	// Update fields
+	updated := assembler.ToHorarioFuncionamentoEntity(&input)
+	horario.DiaSemana = updated.DiaSemana
+	horario.HoraAbertura = updated.HoraAbertura
+	horario.HoraFechamento = updated.HoraFechamento
+
+	if err := h.service.Save(restauranteID, horario); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToHorarioFuncionamentoModel(horario))
+}
+
+func (h *HorarioFuncionamentoHandler) Remover(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	horarioID, _ := strconv.ParseUint(c.Param("horarioId"), 10, 64)
+
+	if err := h.service.Excluir(restauranteID, horarioID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *HorarioFuncionamentoHandler) ListarExcecoes(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	excecoes, err := h.service.FindAllExcecoes(restauranteID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToExcecaoHorarioModels(excecoes))
+}
+
+func (h *HorarioFuncionamentoHandler) BuscarExcecao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	excecaoID, _ := strconv.ParseUint(c.Param("excecaoId"), 10, 64)
+
+	excecao, err := h.service.FindExcecaoByID(restauranteID, excecaoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToExcecaoHorarioModel(excecao))
+}
+
+func (h *HorarioFuncionamentoHandler) AdicionarExcecao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	var input dto.ExcecaoHorarioInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	excecao := assembler.ToExcecaoHorarioEntity(&input)
+	if err := h.service.SaveExcecao(restauranteID, excecao); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, assembler.ToExcecaoHorarioModel(excecao))
+}
+
+func (h *HorarioFuncionamentoHandler) AtualizarExcecao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	excecaoID, _ := strconv.ParseUint(c.Param("excecaoId"), 10, 64)
+
+	var input dto.ExcecaoHorarioInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	excecao, err := h.service.FindExcecaoByID(restauranteID, excecaoID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	// Update fields
+	updated := assembler.ToExcecaoHorarioEntity(&input)
+	excecao.Data = updated.Data
+	excecao.Descricao = updated.Descricao
+	excecao.Fechado = updated.Fechado
+	excecao.HoraAbertura = updated.HoraAbertura
+	excecao.HoraFechamento = updated.HoraFechamento
+
+	if err := h.service.SaveExcecao(restauranteID, excecao); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, assembler.ToExcecaoHorarioModel(excecao))
+}
+
+func (h *HorarioFuncionamentoHandler) RemoverExcecao(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	excecaoID, _ := strconv.ParseUint(c.Param("excecaoId"), 10, 64)
+
+	if err := h.service.ExcluirExcecao(restauranteID, excecaoID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// criarIdempotente responde a uma criação protegida pelo header Idempotency-Key: retries
+// com a mesma chave e o mesmo payload devolvem a resposta original em vez de criar de novo.
+// criar deve retornar erro apenas quando nada foi gravado, pois o erro libera a chave para
+// uma nova tentativa; depois da gravação, a resposta é sempre registrada.
+func criarIdempotente(c *gin.Context, idempotencySvc *service.IdempotencyService, escopo string, payload any, status int, criar func() (any, error)) {
+	chave := c.GetHeader(idempotencyKeyHeader)
+	if chave == "" {
+		resposta, err := criar()
+		if err != nil {
+			exceptionhandler.HandleError(c, err)
+			return
+		}
+		c.JSON(status, resposta)
+		return
+	}
+
+	fingerprint, err := fingerprintPayload(payload)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	original, err := idempotencySvc.Iniciar(escopo, chave, fingerprint)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	if original != nil {
+		c.Header("Idempotent-Replayed", "true")
+		c.Data(original.Status, "application/json; charset=utf-8", original.Body)
+		return
+	}
+
+	resposta, err := criar()
+	if err != nil {
+		if liberarErr := idempotencySvc.Liberar(escopo, chave); liberarErr != nil {
+			log.Printf("Aviso: Falha ao liberar chave de idempotencia %s: %v", chave, liberarErr)
+		}
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	body, err := json.Marshal(resposta)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	if err := idempotencySvc.Concluir(escopo, chave, fingerprint, status, body); err != nil {
+		log.Printf("Aviso: Falha ao registrar resposta da chave de idempotencia %s: %v", chave, err)
+	}
+
+	c.Data(status, "application/json; charset=utf-8", body)
+}
+
+// fingerprintPayload identifica o payload da requisição para detectar reuso da chave
+func fingerprintPayload(payload any) (string, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/security"
+)
+
+// JwksHandler publica as chaves públicas usadas para assinar os tokens
+type JwksHandler struct {
+	keySet *security.KeySet
+}
+
+func NewJwksHandler(keySet *security.KeySet) *JwksHandler {
+	return &JwksHandler{keySet: keySet}
+}
+
+func (h *JwksHandler) Listar(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.keySet.JWKS())
+}
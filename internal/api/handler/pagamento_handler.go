@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/assembler"
+	"github.com/yurisasc/algafood-go/internal/api/dto"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// maxWebhookBodySize limita o corpo das notificações do provedor de pagamento
+const maxWebhookBodySize = 1 << 20
+
+type PagamentoHandler struct {
+	service      *service.PagamentoService
+	pedidoSvc    *service.PedidoService
+	fluxoService *service.FluxoPedidoService
+}
+
+func NewPagamentoHandler(service *service.PagamentoService, pedidoSvc *service.PedidoService, fluxoService *service.FluxoPedidoService) *PagamentoHandler {
+	return &PagamentoHandler{
+		service:      service,
+		pedidoSvc:    pedidoSvc,
+		fluxoService: fluxoService,
+	}
+}
+
+func (h *PagamentoHandler) Buscar(c *gin.Context) {
+	pedido, err := h.pedidoSvc.FindByCodigo(c.Param("codigoPedido"))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	pagamento, err := h.service.FindByPedido(pedido)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToPagamentoModel(pagamento))
+}
+
+func (h *PagamentoHandler) Pagar(c *gin.Context) {
+	var input dto.PagamentoInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	pagamento, err := h.fluxoService.Pagar(c.Request.Context(), c.Param("codigoPedido"), input.Token)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, assembler.ToPagamentoModel(pagamento))
+}
+
+// Webhook recebe as notificações do provedor de pagamento. A autenticação é a assinatura
+// do corpo, por isso ele é lido sem alterações.
+func (h *PagamentoHandler) Webhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxWebhookBodySize))
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	if err := h.fluxoService.ProcessarWebhookPagamento(c.Request.Context(), c.Request.Header, body); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusOK)
+}
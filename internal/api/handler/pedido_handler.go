@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"io"
+	"log"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yurisasc/algafood-go/internal/api/assembler"
@@ -16,14 +20,16 @@ import (
 )
 
 type PedidoHandler struct {
-	service      *service.PedidoService
-	fluxoService *service.FluxoPedidoService
+	service        *service.PedidoService
+	fluxoService   *service.FluxoPedidoService
+	idempotencySvc *service.IdempotencyService
 }
 
-func NewPedidoHandler(service *service.PedidoService, fluxoService *service.FluxoPedidoService) *PedidoHandler {
+func NewPedidoHandler(service *service.PedidoService, fluxoService *service.FluxoPedidoService, idempotencySvc *service.IdempotencyService) *PedidoHandler {
 	return &PedidoHandler{
-		service:      service,
-		fluxoService: fluxoService,
+		service:        service,
+		fluxoService:   fluxoService,
+		idempotencySvc: idempotencySvc,
 	}
 }
 
@@ -51,6 +57,21 @@ func (h *PedidoHandler) Pesquisar(c *gin.Context) {
 		statusPedido := model.StatusPedido(status)
 		filter.Status = &statusPedido
 	}
+	if status := c.Query("statusDetalhado"); status != "" {
+		statusPedido := model.StatusPedido(status)
+		filter.StatusDetalhado = &statusPedido
+	}
+
+	// ?cursor= (vazio na primeira página) ativa a paginação por keyset
+	if page.Keyset() {
+		result, err := h.service.PesquisarPorCursor(filter, page)
+		if err != nil {
+			exceptionhandler.HandleError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, pagination.MapCursorPage(result, assembler.ToPedidoResumoModels(result.Content)))
+		return
+	}
 
 	result, err := h.service.Pesquisar(filter, page)
 	if err != nil {
@@ -58,21 +79,7 @@ func (h *PedidoHandler) Pesquisar(c *gin.Context) {
 		return
 	}
 
-	// Convert to DTOs
-	content := assembler.ToPedidoResumoModels(result.Content)
-	response := pagination.Page[dto.PedidoResumoModel]{
-		Content:          content,
-		TotalElements:    result.TotalElements,
-		TotalPages:       result.TotalPages,
-		Size:             result.Size,
-		Number:           result.Number,
-		NumberOfElements: result.NumberOfElements,
-		First:            result.First,
-		Last:             result.Last,
-		Empty:            result.Empty,
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, pagination.MapPage(result, assembler.ToPedidoResumoModels(result.Content)))
 }
 
 func (h *PedidoHandler) Buscar(c *gin.Context) {
@@ -86,6 +93,8 @@ func (h *PedidoHandler) Buscar(c *gin.Context) {
 	c.JSON(http.StatusOK, assembler.ToPedidoModel(pedido))
 }
 
+// Adicionar emite um novo pedido. Quando o header Idempotency-Key é informado,
+// retries com o mesmo payload devolvem a resposta original em vez de criar outro pedido.
 func (h *PedidoHandler) Adicionar(c *gin.Context) {
 	var input dto.PedidoInput
 	if err := c.ShouldBindJSON(&input); err != nil {
@@ -100,31 +109,128 @@ func (h *PedidoHandler) Adicionar(c *gin.Context) {
 		return
 	}
 
-	pedido := assembler.ToPedidoEntity(&input, usuario.ID)
-	if err := h.service.Emitir(pedido); err != nil {
-		exceptionhandler.HandleError(c, err)
+	escopo := "pedido:" + strconv.FormatUint(usuario.ID, 10)
+	criarIdempotente(c, h.idempotencySvc, escopo, &input, http.StatusCreated, func() (any, error) {
+		pedido := assembler.ToPedidoEntity(&input, usuario.ID)
+		if err := h.service.Emitir(pedido); err != nil {
+			return nil, err
+		}
+		return assembler.ToPedidoModel(h.recarregar(pedido)), nil
+	})
+}
+
+// recarregar busca o pedido gravado com todos os dados. O pedido já existe, então uma
+// falha aqui não pode virar erro: responde com os dados usados na emissão.
+func (h *PedidoHandler) recarregar(pedido *model.Pedido) *model.Pedido {
+	completo, err := h.service.FindByCodigo(pedido.Codigo)
+	if err != nil {
+		log.Printf("Aviso: Falha ao recarregar o pedido %s: %v", pedido.Codigo, err)
+		return pedido
+	}
+	return completo
+}
+
+// Repetir cria um novo pedido com os itens de um pedido anterior do cliente, com os preços
+// atuais. Com ?dryRun=true apenas calcula o novo pedido e lista as alterações, sem emiti-lo.
+func (h *PedidoHandler) Repetir(c *gin.Context) {
+	codigoPedido := c.Param("codigoPedido")
+	dryRun, _ := strconv.ParseBool(c.Query("dryRun"))
+
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if dryRun {
+		repeticao, err := h.service.Repetir(codigoPedido, usuario.ID, true)
+		if err != nil {
+			exceptionhandler.HandleError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, assembler.ToRepeticaoPedidoModel(repeticao))
 		return
 	}
 
-	// Reload to get full data
-	pedido, _ = h.service.FindByCodigo(pedido.Codigo)
-	c.JSON(http.StatusCreated, assembler.ToPedidoModel(pedido))
+	// A emissão aceita o header Idempotency-Key como POST /v1/pedidos
+	escopo := "pedido-repetir:" + strconv.FormatUint(usuario.ID, 10)
+	payload := struct {
+		CodigoPedido string `json:"codigoPedido"`
+	}{CodigoPedido: codigoPedido}
+	criarIdempotente(c, h.idempotencySvc, escopo, payload, http.StatusCreated, func() (any, error) {
+		repeticao, err := h.service.Repetir(codigoPedido, usuario.ID, false)
+		if err != nil {
+			return nil, err
+		}
+		return assembler.ToRepeticaoPedidoModel(repeticao), nil
+	})
 }
 
 func (h *PedidoHandler) Confirmar(c *gin.Context) {
 	codigoPedido := c.Param("codigoPedido")
 
-	if err := h.fluxoService.Confirmar(codigoPedido); err != nil {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.fluxoService.Confirmar(codigoPedido, usuario.ID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PedidoHandler) IniciarPreparacao(c *gin.Context) {
+	codigoPedido := c.Param("codigoPedido")
+
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.fluxoService.IniciarPreparacao(codigoPedido, usuario.ID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *PedidoHandler) SairParaEntrega(c *gin.Context) {
+	codigoPedido := c.Param("codigoPedido")
+
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.fluxoService.SairParaEntrega(codigoPedido, usuario.ID); err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
 
+// Cancelar aceita um corpo opcional com o motivo do cancelamento
 func (h *PedidoHandler) Cancelar(c *gin.Context) {
 	codigoPedido := c.Param("codigoPedido")
 
-	if err := h.fluxoService.Cancelar(codigoPedido); err != nil {
+	var input dto.CancelamentoPedidoInput
+	if err := c.ShouldBindJSON(&input); err != nil && !errors.Is(err, io.EOF) {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.fluxoService.Cancelar(codigoPedido, usuario.ID, strings.TrimSpace(input.Motivo)); err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
@@ -134,9 +240,27 @@ func (h *PedidoHandler) Cancelar(c *gin.Context) {
 func (h *PedidoHandler) Entregar(c *gin.Context) {
 	codigoPedido := c.Param("codigoPedido")
 
-	if err := h.fluxoService.Entregar(codigoPedido); err != nil {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.fluxoService.Entregar(codigoPedido, usuario.ID); err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
 	c.Status(http.StatusNoContent)
 }
+
+// Historico lista as mudanças de status do pedido
+func (h *PedidoHandler) Historico(c *gin.Context) {
+	codigoPedido := c.Param("codigoPedido")
+
+	historico, err := h.service.FindHistorico(codigoPedido)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToPedidoStatusHistoricoModels(historico))
+}
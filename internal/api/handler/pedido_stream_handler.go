@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// sseHeartbeatInterval mantém a conexão viva através de proxies com timeout de inatividade
+const sseHeartbeatInterval = 25 * time.Second
+
+// PedidoStreamHandler expõe as mudanças de status dos pedidos como Server-Sent Events
+type PedidoStreamHandler struct {
+	pedidoSvc      *service.PedidoService
+	restauranteSvc *service.RestauranteService
+	streamSvc      *service.PedidoStreamService
+}
+
+func NewPedidoStreamHandler(
+	pedidoSvc *service.PedidoService,
+	restauranteSvc *service.RestauranteService,
+	streamSvc *service.PedidoStreamService,
+) *PedidoStreamHandler {
+	return &PedidoStreamHandler{
+		pedidoSvc:      pedidoSvc,
+		restauranteSvc: restauranteSvc,
+		streamSvc:      streamSvc,
+	}
+}
+
+// AcompanharPedido envia o status atual do pedido e as mudanças seguintes.
+// O stream termina quando o pedido é entregue ou cancelado.
+func (h *PedidoStreamHandler) AcompanharPedido(c *gin.Context) {
+	codigoPedido := c.Param("codigoPedido")
+
+	// Assina antes de ler o status atual: uma mudança entre a leitura e a assinatura se perderia
+	assinatura, err := h.streamSvc.AssinarPedido(c.Request.Context(), codigoPedido)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	defer assinatura.Close()
+
+	pedido, err := h.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	atual := service.NewStatusPedidoAlterado(pedido)
+	h.transmitir(c, assinatura, &atual, true)
+}
+
+// AcompanharRestaurante envia as mudanças de status de todos os pedidos do restaurante
+func (h *PedidoStreamHandler) AcompanharRestaurante(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+
+	if _, err := h.restauranteSvc.FindByID(restauranteID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	assinatura, err := h.streamSvc.AssinarRestaurante(c.Request.Context(), restauranteID)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	defer assinatura.Close()
+
+	h.transmitir(c, assinatura, nil, false)
+}
+
+func (h *PedidoStreamHandler) transmitir(c *gin.Context, assinatura *service.AssinaturaPedidos, inicial *service.StatusPedidoAlterado, encerrarAoFinalizar bool) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	if inicial != nil {
+		c.SSEvent("status", inicial)
+		c.Writer.Flush()
+		if encerrarAoFinalizar && inicial.Status.Finalizado() {
+			return
+		}
+	}
+
+	// No stream de um pedido, notificações recebidas antes da leitura do status atual
+	// repetem o status inicial
+	var ultimo model.StatusPedido
+	if inicial != nil {
+		ultimo = inicial.Status
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case <-c.Request.Context().Done():
+			return false
+		case <-h.streamSvc.Encerrado():
+			return false
+		case evt, ok := <-assinatura.Eventos:
+			if !ok {
+				return false
+			}
+			if inicial != nil {
+				if evt.Status == ultimo {
+					return true
+				}
+				ultimo = evt.Status
+			}
+			c.SSEvent("status", evt)
+			return !(encerrarAoFinalizar && evt.Status.Finalizado())
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": ping\n\n")
+			return true
+		}
+	})
+}
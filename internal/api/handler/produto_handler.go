@@ -9,6 +9,7 @@ import (
 	"github.com/yurisasc/algafood-go/internal/api/dto"
 	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
 
 type ProdutoHandler struct {
@@ -23,12 +24,18 @@ func (h *ProdutoHandler) Listar(c *gin.Context) {
 	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
 	incluirInativos := c.Query("incluirInativos") == "true"
 
-	produtos, err := h.service.FindAllByRestaurante(restauranteID, incluirInativos)
+	pageable := pagination.NewPageableOpcionalFromContext(c)
+	result, err := h.service.FindAllByRestaurante(restauranteID, incluirInativos, pageable)
 	if err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, assembler.ToProdutoModels(produtos))
+	models := assembler.ToProdutoModels(result.Content)
+	if !pageable.Paginado() {
+		c.JSON(http.StatusOK, models)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, models))
 }
 
 func (h *ProdutoHandler) Buscar(c *gin.Context) {
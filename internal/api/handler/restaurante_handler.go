@@ -5,10 +5,13 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/shopspring/decimal"
 	"github.com/yurisasc/algafood-go/internal/api/assembler"
 	"github.com/yurisasc/algafood-go/internal/api/dto"
 	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
 
 type RestauranteHandler struct {
@@ -19,13 +22,51 @@ func NewRestauranteHandler(service *service.RestauranteService) *RestauranteHand
 	return &RestauranteHandler{service: service}
 }
 
+// Listar pesquisa restaurantes. Com latitude e longitude, retorna apenas os restaurantes
+// a até raioKm do ponto, com a distância, ordenados do mais próximo por padrão.
 func (h *RestauranteHandler) Listar(c *gin.Context) {
-	restaurantes, err := h.service.FindAll()
+	var input dto.RestauranteFiltroInput
+	if err := c.ShouldBindQuery(&input); err != nil {
+		exceptionhandler.HandleQueryError(c, err)
+		return
+	}
+
+	filter := &repository.RestauranteFilter{
+		CozinhaID:   input.CozinhaID,
+		CidadeID:    input.CidadeID,
+		Aberto:      input.Aberto,
+		Ativo:       input.Ativo,
+		FreteGratis: input.FreteGratis,
+		Latitude:    input.Latitude,
+		Longitude:   input.Longitude,
+	}
+	if input.Nome != "" {
+		filter.Nome = &input.Nome
+	}
+	if input.TaxaFreteMin != nil {
+		taxaFreteMin := decimal.NewFromFloat(*input.TaxaFreteMin)
+		filter.TaxaFreteMin = &taxaFreteMin
+	}
+	if input.TaxaFreteMax != nil {
+		taxaFreteMax := decimal.NewFromFloat(*input.TaxaFreteMax)
+		filter.TaxaFreteMax = &taxaFreteMax
+	}
+	if input.RaioKm != nil {
+		filter.RaioKm = *input.RaioKm
+	}
+
+	pageable := pagination.NewPageableOpcionalFromContext(c)
+	result, err := h.service.FindAll(filter, pageable)
 	if err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, assembler.ToRestauranteResumoModels(restaurantes))
+	models := assembler.ToRestauranteResumoModels(result.Content)
+	if !pageable.Paginado() {
+		c.JSON(http.StatusOK, models)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, models))
 }
 
 func (h *RestauranteHandler) Buscar(c *gin.Context) {
@@ -84,10 +125,14 @@ func (h *RestauranteHandler) Atualizar(c *gin.Context) {
 	updated := assembler.ToRestauranteEntity(&input)
 	restaurante.Nome = updated.Nome
 	restaurante.TaxaFrete = updated.TaxaFrete
+	restaurante.ValorMinimoPedido = updated.ValorMinimoPedido
 	restaurante.CozinhaID = updated.CozinhaID
 	if input.Endereco != nil {
 		restaurante.Endereco = updated.Endereco
 	}
+	if input.FusoHorario != "" {
+		restaurante.FusoHorario = updated.FusoHorario
+	}
 
 	if err := h.service.Save(restaurante); err != nil {
 		exceptionhandler.HandleError(c, err)
@@ -196,6 +241,39 @@ func (h *RestauranteHandler) DesassociarFormaPagamento(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ListarCidadesEntrega lists the cities in the restaurant's delivery area
+func (h *RestauranteHandler) ListarCidadesEntrega(c *gin.Context) {
+	id, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	cidades, err := h.service.FindCidadesEntrega(id)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, assembler.ToCidadeModels(cidades))
+}
+
+func (h *RestauranteHandler) AssociarCidadeEntrega(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	cidadeID, _ := strconv.ParseUint(c.Param("cidadeId"), 10, 64)
+
+	if err := h.service.AssociarCidadeEntrega(restauranteID, cidadeID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *RestauranteHandler) DesassociarCidadeEntrega(c *gin.Context) {
+	restauranteID, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
+	cidadeID, _ := strconv.ParseUint(c.Param("cidadeId"), 10, 64)
+
+	if err := h.service.DesassociarCidadeEntrega(restauranteID, cidadeID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // ListarResponsaveis lists responsible users of a restaurant
 func (h *RestauranteHandler) ListarResponsaveis(c *gin.Context) {
 	id, _ := strconv.ParseUint(c.Param("restauranteId"), 10, 64)
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"log"
 	"net/http"
 	"strconv"
 	"strings"
@@ -11,18 +12,21 @@ import (
 	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
 	"github.com/yurisasc/algafood-go/internal/api/middleware" // Importado
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
 
 type UsuarioHandler struct {
 	service               *service.UsuarioService
 	authService           *service.AuthService
+	contaService          *service.ContaService
 	tokenBlacklistService *service.TokenBlacklistService
 }
 
-func NewUsuarioHandler(service *service.UsuarioService, authService *service.AuthService, tokenBlacklistService *service.TokenBlacklistService) *UsuarioHandler {
+func NewUsuarioHandler(service *service.UsuarioService, authService *service.AuthService, contaService *service.ContaService, tokenBlacklistService *service.TokenBlacklistService) *UsuarioHandler {
 	return &UsuarioHandler{
 		service:               service,
 		authService:           authService,
+		contaService:          contaService,
 		tokenBlacklistService: tokenBlacklistService,
 	}
 }
@@ -54,16 +58,42 @@ func (h *UsuarioHandler) Login(c *gin.Context) {
 		return
 	}
 
-	token, err := h.authService.GenerateToken(user)
+	tokens, err := h.authService.Login(user)
 	if err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"token": token})
+	c.JSON(http.StatusOK, toTokenModel(tokens))
 }
 
-// Logout invalida o token JWT atual.
+// RenovarToken troca o refresh token por um novo par de tokens (rotação).
+func (h *UsuarioHandler) RenovarToken(c *gin.Context) {
+	var input dto.RefreshTokenInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	tokens, err := h.authService.Refresh(input.RefreshToken)
+	if err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toTokenModel(tokens))
+}
+
+func toTokenModel(tokens *service.TokensAutenticacao) dto.TokenModel {
+	return dto.TokenModel{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(tokens.ExpiresIn.Seconds()),
+	}
+}
+
+// Logout invalida o token JWT atual e revoga os refresh tokens da sessão.
 func (h *UsuarioHandler) Logout(c *gin.Context) {
 	authHeader := c.GetHeader("Authorization")
 	if authHeader == "" {
@@ -78,22 +108,29 @@ func (h *UsuarioHandler) Logout(c *gin.Context) {
 	}
 
 	tokenString := parts[1]
-	if err := h.tokenBlacklistService.InvalidateToken(tokenString); err != nil {
-		// Mesmo se houver erro ao invalidar, retornamos sucesso para o cliente
-		c.Status(http.StatusNoContent)
-		return
+	if err := h.authService.Logout(tokenString); err != nil {
+		log.Printf("Aviso: Falha ao revogar sessao no logout: %v", err)
 	}
 
+	// Mesmo se houver erro ao invalidar, retornamos sucesso para o cliente
+	_ = h.tokenBlacklistService.InvalidateToken(tokenString)
+
 	c.Status(http.StatusNoContent)
 }
 
 func (h *UsuarioHandler) Listar(c *gin.Context) {
-	usuarios, err := h.service.FindAll()
+	pageable := pagination.NewPageableOpcionalFromContext(c)
+	result, err := h.service.FindAll(pageable)
 	if err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
-	c.JSON(http.StatusOK, assembler.ToUsuarioModels(usuarios))
+	models := assembler.ToUsuarioModels(result.Content)
+	if !pageable.Paginado() {
+		c.JSON(http.StatusOK, models)
+		return
+	}
+	c.JSON(http.StatusOK, pagination.MapPage(result, models))
 }
 
 func (h *UsuarioHandler) Buscar(c *gin.Context) {
@@ -123,6 +160,7 @@ func (h *UsuarioHandler) Adicionar(c *gin.Context) {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
+	h.contaService.EnviarVerificacaoEmail(usuario)
 
 	c.JSON(http.StatusCreated, assembler.ToUsuarioModel(usuario))
 }
@@ -146,12 +184,21 @@ func (h *UsuarioHandler) Atualizar(c *gin.Context) {
 		return
 	}
 
+	// Um novo e-mail precisa ser verificado de novo
+	emailAlterado := !strings.EqualFold(usuario.Email, input.Email)
 	usuario.Nome = input.Nome
 	usuario.Email = input.Email
+	if emailAlterado {
+		usuario.EmailVerificado = false
+		usuario.DataVerificacaoEmail = nil
+	}
 	if err := h.service.Save(usuario); err != nil {
 		exceptionhandler.HandleError(c, err)
 		return
 	}
+	if emailAlterado {
+		h.contaService.EnviarVerificacaoEmail(usuario)
+	}
 
 	c.JSON(http.StatusOK, assembler.ToUsuarioModel(usuario))
 }
@@ -177,6 +224,68 @@ func (h *UsuarioHandler) AlterarSenha(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// EsqueciSenha envia o link de redefinição de senha. A resposta é sempre 202,
+// exista ou não uma conta com o e-mail informado.
+func (h *UsuarioHandler) EsqueciSenha(c *gin.Context) {
+	var input dto.EsqueciSenhaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	h.contaService.SolicitarRedefinicaoSenha(input.Email)
+
+	c.Status(http.StatusAccepted)
+}
+
+// RedefinirSenha troca a senha usando o token recebido por e-mail
+func (h *UsuarioHandler) RedefinirSenha(c *gin.Context) {
+	var input dto.RedefinicaoSenhaInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.contaService.RedefinirSenha(input.Token, input.NovaSenha); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// VerificarEmail confirma o e-mail usando o token recebido por e-mail
+func (h *UsuarioHandler) VerificarEmail(c *gin.Context) {
+	var input dto.VerificacaoEmailInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		exceptionhandler.HandleValidationError(c, err)
+		return
+	}
+
+	if err := h.contaService.VerificarEmail(input.Token); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ReenviarVerificacaoEmail envia um novo link de verificação ao usuário autenticado
+func (h *UsuarioHandler) ReenviarVerificacaoEmail(c *gin.Context) {
+	usuario, ok := middleware.GetCurrentUser(c)
+	if !ok {
+		exceptionhandler.HandleUnauthorized(c)
+		return
+	}
+
+	if err := h.contaService.ReenviarVerificacaoEmail(usuario.ID); err != nil {
+		exceptionhandler.HandleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
 // ListarGrupos lists groups of a user
 func (h *UsuarioHandler) ListarGrupos(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("usuarioId"), 10, 64)
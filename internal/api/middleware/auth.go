@@ -1,16 +1,14 @@
 package middleware
 
 import (
-	"net/http"
+	"errors"
 	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
-	"github.com/yurisasc/algafood-go/internal/config"
-	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/security"
 )
 
 type contextKey string
@@ -20,8 +18,9 @@ const (
 	currentUserKey contextKey = "currentUser"
 )
 
-// AuthMiddleware valida o token JWT e carrega o usuário completo no contexto.
-func AuthMiddleware(cfg *config.JWTConfig, usuarioSvc *service.UsuarioService, tokenBlacklistSvc *service.TokenBlacklistService) gin.HandlerFunc {
+// AuthMiddleware valida o token com a estratégia configurada (JWT, JWKS remoto ou
+// introspecção) e carrega o usuário completo no contexto.
+func AuthMiddleware(validator security.TokenValidator, usuarioSvc *service.UsuarioService, tokenBlacklistSvc *service.TokenBlacklistService) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -46,48 +45,50 @@ func AuthMiddleware(cfg *config.JWTConfig, usuarioSvc *service.UsuarioService, t
 			return
 		}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, exception.NewAuthenticationException("Método de assinatura de token inválido")
-			}
-			return []byte(cfg.SecretKey), nil
-		})
-
-		if err != nil || !token.Valid {
+		identidade, err := validator.Validar(c.Request.Context(), tokenString)
+		if err != nil {
 			exceptionhandler.HandleUnauthorized(c)
 			c.Abort()
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
+		usuario, err := carregarUsuario(usuarioSvc, identidade)
+		if err != nil {
+			// Se o usuário não for encontrado no DB (ex: foi deletado), a autenticação falha.
 			exceptionhandler.HandleUnauthorized(c)
 			c.Abort()
 			return
 		}
 
-		// Extrai o ID do usuário e carrega o objeto de usuário completo
-		if userIDFloat, ok := claims["usuario_id"].(float64); ok {
-			userID := uint64(userIDFloat)
-			usuario, err := usuarioSvc.FindByID(userID)
-			if err != nil {
-				// Se o usuário não for encontrado no DB (ex: foi deletado), a autenticação falha.
-				exceptionhandler.HandleUnauthorized(c)
-				c.Abort()
-				return
-			}
-			// Armazena o objeto de usuário completo no contexto
-			c.Set(string(currentUserKey), usuario)
-		} else {
-			exceptionhandler.HandleUnauthorized(c)
-			c.Abort()
-			return
-		}
+		// Armazena o objeto de usuário completo no contexto
+		c.Set(string(currentUserKey), usuario)
 
 		c.Next()
 	}
 }
 
+// carregarUsuario mapeia a identidade do token para o usuário cadastrado: pelo ID dos
+// tokens da API, ou pelo e-mail dos tokens de servidores externos. Identidades externas
+// só são associadas a contas com o e-mail verificado.
+func carregarUsuario(usuarioSvc *service.UsuarioService, identidade *security.Identidade) (*model.Usuario, error) {
+	if identidade.UsuarioID > 0 {
+		return usuarioSvc.FindByID(identidade.UsuarioID)
+	}
+
+	if identidade.Email == "" {
+		return nil, errors.New("token sem usuario_id ou email")
+	}
+	usuario, err := usuarioSvc.FindByEmail(identidade.Email)
+	if err != nil {
+		return nil, err
+	}
+	if !usuario.EmailVerificado {
+		return nil, errors.New("e-mail do usuario nao verificado")
+	}
+	// Recarrega pelo ID para trazer grupos e permissões (usa cache)
+	return usuarioSvc.FindByID(usuario.ID)
+}
+
 // GetCurrentUser extrai o usuário autenticado do contexto do Gin.
 // Isso é análogo ao SecurityContextHolder.getContext().getAuthentication().getPrincipal() do Spring.
 func GetCurrentUser(c *gin.Context) (*model.Usuario, bool) {
@@ -121,7 +122,7 @@ func HasAuthority(c *gin.Context, authority string) bool {
 func RequireAuthority(authority string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		if !HasAuthority(c, authority) {
-			c.JSON(http.StatusForbidden, gin.H{"error": "Acesso negado"})
+			exceptionhandler.HandleAccessDenied(c)
 			c.Abort()
 			return
 		}
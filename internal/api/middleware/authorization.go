@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// Rule é uma regra de autorização avaliada sobre a requisição atual.
+type Rule func(c *gin.Context) bool
+
+// ValueExtractor extrai um valor (ID, código) da requisição.
+type ValueExtractor func(c *gin.Context) string
+
+// Param extrai o valor de um parâmetro de rota.
+func Param(name string) ValueExtractor {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// Query extrai o valor de um parâmetro de query string.
+func Query(name string) ValueExtractor {
+	return func(c *gin.Context) string {
+		return c.Query(name)
+	}
+}
+
+// Authorize é um middleware que permite o acesso se pelo menos uma das regras for satisfeita.
+// Caso contrário, responde com o Problem de acesso negado.
+func Authorize(rules ...Rule) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, rule := range rules {
+			if rule(c) {
+				c.Next()
+				return
+			}
+		}
+		exceptionhandler.HandleAccessDenied(c)
+		c.Abort()
+	}
+}
+
+// Authenticated é satisfeita por qualquer usuário autenticado.
+func Authenticated() Rule {
+	return func(c *gin.Context) bool {
+		_, ok := GetCurrentUser(c)
+		return ok
+	}
+}
+
+// Authority é satisfeita quando o usuário autenticado possui a permissão informada.
+func Authority(authority string) Rule {
+	return func(c *gin.Context) bool {
+		return HasAuthority(c, authority)
+	}
+}
+
+// UsuarioAutenticadoIgual é satisfeita quando o ID extraído é o do próprio usuário autenticado.
+func UsuarioAutenticadoIgual(usuarioID ValueExtractor) Rule {
+	return func(c *gin.Context) bool {
+		usuario, ok := GetCurrentUser(c)
+		if !ok {
+			return false
+		}
+		id, err := strconv.ParseUint(usuarioID(c), 10, 64)
+		if err != nil {
+			return false
+		}
+		return usuario.ID == id
+	}
+}
+
+// AlgaSecurity reúne as regras de autorização que dependem de dados de domínio,
+// como a responsabilidade sobre restaurantes e pedidos.
+// É análogo ao componente AlgaSecurity do projeto original em Spring.
+type AlgaSecurity struct {
+	restauranteSvc *service.RestauranteService
+	pedidoSvc      *service.PedidoService
+}
+
+func NewAlgaSecurity(restauranteSvc *service.RestauranteService, pedidoSvc *service.PedidoService) *AlgaSecurity {
+	return &AlgaSecurity{
+		restauranteSvc: restauranteSvc,
+		pedidoSvc:      pedidoSvc,
+	}
+}
+
+// GerenciaRestaurante é satisfeita quando o usuário autenticado é responsável pelo restaurante.
+func (s *AlgaSecurity) GerenciaRestaurante(restauranteID ValueExtractor) Rule {
+	return func(c *gin.Context) bool {
+		usuario, ok := GetCurrentUser(c)
+		if !ok {
+			return false
+		}
+		id, err := strconv.ParseUint(restauranteID(c), 10, 64)
+		if err != nil {
+			return false
+		}
+
+		gerencia, err := s.restauranteSvc.ExisteResponsavel(id, usuario.ID)
+		if err != nil {
+			log.Printf("Erro ao verificar responsável do restaurante %d: %v", id, err)
+			return false
+		}
+		return gerencia
+	}
+}
+
+// GerenciaRestauranteDoPedido é satisfeita quando o usuário autenticado é responsável
+// pelo restaurante do pedido.
+func (s *AlgaSecurity) GerenciaRestauranteDoPedido(codigoPedido ValueExtractor) Rule {
+	return func(c *gin.Context) bool {
+		usuario, ok := GetCurrentUser(c)
+		if !ok {
+			return false
+		}
+		codigo := codigoPedido(c)
+
+		gerencia, err := s.pedidoSvc.IsPedidoGerenciadoPor(codigo, usuario.ID)
+		if err != nil {
+			log.Printf("Erro ao verificar responsável do pedido %s: %v", codigo, err)
+			return false
+		}
+		return gerencia
+	}
+}
+
+// ClienteDoPedido é satisfeita quando o usuário autenticado é o cliente do pedido.
+func (s *AlgaSecurity) ClienteDoPedido(codigoPedido ValueExtractor) Rule {
+	return func(c *gin.Context) bool {
+		usuario, ok := GetCurrentUser(c)
+		if !ok {
+			return false
+		}
+
+		pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido(c))
+		if err != nil {
+			return false
+		}
+		return pedido.ClienteID == usuario.ID
+	}
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yurisasc/algafood-go/internal/api/exceptionhandler"
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/service"
+)
+
+// maxCorpoChaveConta limita quanto do corpo é lido para extrair a conta
+const maxCorpoChaveConta = 64 << 10
+
+// ChaveConta extrai a conta usada na janela "por conta". Retornar "" ignora a janela.
+type ChaveConta func(c *gin.Context) string
+
+// RateLimit aplica as janelas deslizantes do grupo de rotas, por IP e por conta.
+// Requisições acima do limite recebem 429 com o cabeçalho Retry-After.
+func RateLimit(rateLimitSvc *service.RateLimitService, grupo string, cfg config.RateLimitGrupoConfig, chaveConta ChaveConta) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := rateLimitSvc.Permitir(grupo+":ip:"+c.ClientIP(), cfg.PorIP); err != nil {
+			exceptionhandler.HandleError(c, err)
+			c.Abort()
+			return
+		}
+
+		if chaveConta != nil {
+			if conta := chaveConta(c); conta != "" {
+				if err := rateLimitSvc.Permitir(grupo+":conta:"+conta, cfg.PorConta); err != nil {
+					exceptionhandler.HandleError(c, err)
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// UsuarioAutenticadoChave usa o ID do usuário autenticado como conta
+func UsuarioAutenticadoChave() ChaveConta {
+	return func(c *gin.Context) string {
+		usuario, ok := GetCurrentUser(c)
+		if !ok {
+			return ""
+		}
+		return strconv.FormatUint(usuario.ID, 10)
+	}
+}
+
+// EmailChave usa o campo "email" do corpo JSON como conta (rotas públicas, como o login).
+// O corpo é restaurado para o handler.
+func EmailChave() ChaveConta {
+	return func(c *gin.Context) string {
+		if c.Request.Body == nil {
+			return ""
+		}
+
+		corpo, err := io.ReadAll(io.LimitReader(c.Request.Body, maxCorpoChaveConta))
+		if err != nil {
+			return ""
+		}
+		c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(corpo), c.Request.Body))
+
+		var payload struct {
+			Email string `json:"email"`
+		}
+		if err := json.Unmarshal(corpo, &payload); err != nil {
+			return ""
+		}
+		return service.ChaveConta(payload.Email)
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"github.com/yurisasc/algafood-go/internal/api/handler"
 	"github.com/yurisasc/algafood-go/internal/api/middleware"
 	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/service"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/security"
 )
 
 type Router struct {
@@ -18,10 +20,27 @@ type Router struct {
 	usuarioHandler        *handler.UsuarioHandler
 	restauranteHandler    *handler.RestauranteHandler
 	produtoHandler        *handler.ProdutoHandler
+	fotoProdutoHandler    *handler.FotoProdutoHandler
+	horarioHandler        *handler.HorarioFuncionamentoHandler
+	freteHandler          *handler.FreteHandler
+	cupomHandler          *handler.CupomHandler
+	grupoOpcoesHandler    *handler.GrupoOpcoesHandler
 	pedidoHandler         *handler.PedidoHandler
+	pedidoStreamHandler   *handler.PedidoStreamHandler
+	pagamentoHandler      *handler.PagamentoHandler
+	avaliacaoHandler      *handler.AvaliacaoHandler
+	enderecoHandler       *handler.EnderecoUsuarioHandler
+	favoritoHandler       *handler.FavoritoHandler
+	carrinhoHandler       *handler.CarrinhoHandler
 	estatisticaHandler    *handler.EstatisticaHandler
+	eventoOutboxHandler   *handler.EventoOutboxHandler
+	jwksHandler           *handler.JwksHandler
 	usuarioSvc            *service.UsuarioService
+	restauranteSvc        *service.RestauranteService
+	pedidoSvc             *service.PedidoService
 	tokenBlacklistSvc     *service.TokenBlacklistService
+	tokenValidator        security.TokenValidator
+	rateLimitSvc          *service.RateLimitService
 	cfg                   *config.Config
 }
 
@@ -35,10 +54,27 @@ func NewRouter(
 	usuarioHandler *handler.UsuarioHandler,
 	restauranteHandler *handler.RestauranteHandler,
 	produtoHandler *handler.ProdutoHandler,
+	fotoProdutoHandler *handler.FotoProdutoHandler,
+	horarioHandler *handler.HorarioFuncionamentoHandler,
+	freteHandler *handler.FreteHandler,
+	cupomHandler *handler.CupomHandler,
+	grupoOpcoesHandler *handler.GrupoOpcoesHandler,
 	pedidoHandler *handler.PedidoHandler,
+	pedidoStreamHandler *handler.PedidoStreamHandler,
+	pagamentoHandler *handler.PagamentoHandler,
+	avaliacaoHandler *handler.AvaliacaoHandler,
+	enderecoHandler *handler.EnderecoUsuarioHandler,
+	favoritoHandler *handler.FavoritoHandler,
+	carrinhoHandler *handler.CarrinhoHandler,
 	estatisticaHandler *handler.EstatisticaHandler,
+	eventoOutboxHandler *handler.EventoOutboxHandler,
+	jwksHandler *handler.JwksHandler,
 	usuarioSvc *service.UsuarioService,
+	restauranteSvc *service.RestauranteService,
+	pedidoSvc *service.PedidoService,
 	tokenBlacklistSvc *service.TokenBlacklistService,
+	tokenValidator security.TokenValidator,
+	rateLimitSvc *service.RateLimitService,
 	cfg *config.Config,
 ) *Router {
 	return &Router{
@@ -51,10 +87,27 @@ func NewRouter(
 		usuarioHandler:        usuarioHandler,
 		restauranteHandler:    restauranteHandler,
 		produtoHandler:        produtoHandler,
+		fotoProdutoHandler:    fotoProdutoHandler,
+		horarioHandler:        horarioHandler,
+		freteHandler:          freteHandler,
+		cupomHandler:          cupomHandler,
+		grupoOpcoesHandler:    grupoOpcoesHandler,
 		pedidoHandler:         pedidoHandler,
+		pedidoStreamHandler:   pedidoStreamHandler,
+		pagamentoHandler:      pagamentoHandler,
+		avaliacaoHandler:      avaliacaoHandler,
+		enderecoHandler:       enderecoHandler,
+		favoritoHandler:       favoritoHandler,
+		carrinhoHandler:       carrinhoHandler,
 		estatisticaHandler:    estatisticaHandler,
+		eventoOutboxHandler:   eventoOutboxHandler,
+		jwksHandler:           jwksHandler,
 		usuarioSvc:            usuarioSvc,
+		restauranteSvc:        restauranteSvc,
+		pedidoSvc:             pedidoSvc,
 		tokenBlacklistSvc:     tokenBlacklistSvc,
+		tokenValidator:        tokenValidator,
+		rateLimitSvc:          rateLimitSvc,
 		cfg:                   cfg,
 	}
 }
@@ -65,6 +118,9 @@ func (r *Router) Setup(engine *gin.Engine) {
 	engine.Use(middleware.LoggerMiddleware())
 	engine.Use(middleware.RecoveryMiddleware())
 
+	// Chaves públicas para outros serviços validarem os tokens da API
+	engine.GET("/.well-known/jwks.json", r.jwksHandler.Listar)
+
 	// API v1 routes
 	v1 := engine.Group("/v1")
 	{
@@ -72,142 +128,321 @@ func (r *Router) Setup(engine *gin.Engine) {
 		r.setupPublicRoutes(v1)
 
 		// Protected routes (auth required)
-		v1.Use(middleware.AuthMiddleware(&r.cfg.JWT, r.usuarioSvc, r.tokenBlacklistSvc))
+		v1.Use(middleware.AuthMiddleware(r.tokenValidator, r.usuarioSvc, r.tokenBlacklistSvc))
 		r.setupProtectedRoutes(v1)
 	}
 }
 
 func (r *Router) setupPublicRoutes(rg *gin.RouterGroup) {
-	rg.POST("/login", r.usuarioHandler.Login)
-	rg.POST("/usuarios", r.usuarioHandler.Adicionar)
+	limiteLogin := r.rateLimit("login", middleware.EmailChave())
+	limiteCadastro := r.rateLimit("cadastro", middleware.EmailChave())
+	limiteConta := r.rateLimit("conta", middleware.EmailChave())
+
+	rg.POST("/login", limiteLogin, r.usuarioHandler.Login)
+	rg.POST("/token/refresh", limiteLogin, r.usuarioHandler.RenovarToken)
+	rg.POST("/usuarios", limiteCadastro, r.usuarioHandler.Adicionar)
+	rg.POST("/usuarios/senha/esqueci", limiteConta, r.usuarioHandler.EsqueciSenha)
+	rg.POST("/usuarios/senha/redefinir", limiteConta, r.usuarioHandler.RedefinirSenha)
+	rg.POST("/usuarios/verificacao-email", limiteConta, r.usuarioHandler.VerificarEmail)
+
+	// Notificações do provedor de pagamento, autenticadas pela assinatura do corpo
+	rg.POST("/pagamentos/webhook", r.pagamentoHandler.Webhook)
+}
+
+// rateLimit cria o limitador do grupo de rotas com os limites de rate_limit.grupos
+func (r *Router) rateLimit(grupo string, chaveConta middleware.ChaveConta) gin.HandlerFunc {
+	return middleware.RateLimit(r.rateLimitSvc, grupo, r.cfg.RateLimit.Grupo(grupo), chaveConta)
 }
 
 func (r *Router) setupProtectedRoutes(rg *gin.RouterGroup) {
+	security := middleware.NewAlgaSecurity(r.restauranteSvc, r.pedidoSvc)
+
+	autenticado := middleware.Authorize(middleware.Authenticated())
+
+	// Usuarios, grupos e permissoes
+	podeConsultarUsuarios := middleware.Authorize(middleware.Authority(model.PermissaoConsultarUsuariosGruposPermissoes))
+	podeEditarUsuarios := middleware.Authorize(middleware.Authority(model.PermissaoEditarUsuariosGruposPermissoes))
+	podeConsultarUsuario := middleware.Authorize(
+		middleware.Authority(model.PermissaoConsultarUsuariosGruposPermissoes),
+		middleware.UsuarioAutenticadoIgual(middleware.Param("usuarioId")),
+	)
+	podeAlterarUsuario := middleware.Authorize(
+		middleware.Authority(model.PermissaoEditarUsuariosGruposPermissoes),
+		middleware.UsuarioAutenticadoIgual(middleware.Param("usuarioId")),
+	)
+	podeAlterarPropriaSenha := middleware.Authorize(middleware.UsuarioAutenticadoIgual(middleware.Param("usuarioId")))
+
+	// Cadastros basicos
+	podeEditarEstados := middleware.Authorize(middleware.Authority(model.PermissaoEditarEstados))
+	podeEditarCidades := middleware.Authorize(middleware.Authority(model.PermissaoEditarCidades))
+	podeEditarCozinhas := middleware.Authorize(middleware.Authority(model.PermissaoEditarCozinhas))
+	podeEditarFormasPagamento := middleware.Authorize(middleware.Authority(model.PermissaoEditarFormasPagamento))
+
+	// Restaurantes e produtos
+	podeGerenciarCadastroRestaurantes := middleware.Authorize(middleware.Authority(model.PermissaoEditarRestaurantes))
+	podeGerenciarFuncionamentoRestaurante := middleware.Authorize(
+		middleware.Authority(model.PermissaoEditarRestaurantes),
+		security.GerenciaRestaurante(middleware.Param("restauranteId")),
+	)
+	podeEditarProdutos := middleware.Authorize(
+		middleware.Authority(model.PermissaoEditarProdutos),
+		security.GerenciaRestaurante(middleware.Param("restauranteId")),
+	)
+
+	// Pedidos
+	podePesquisarPedidos := middleware.Authorize(
+		middleware.Authority(model.PermissaoConsultarPedidos),
+		middleware.UsuarioAutenticadoIgual(middleware.Query("clienteId")),
+		security.GerenciaRestaurante(middleware.Query("restauranteId")),
+	)
+	podeBuscarPedido := middleware.Authorize(
+		middleware.Authority(model.PermissaoConsultarPedidos),
+		security.ClienteDoPedido(middleware.Param("codigoPedido")),
+		security.GerenciaRestauranteDoPedido(middleware.Param("codigoPedido")),
+	)
+	podeAcompanharPedidosRestaurante := middleware.Authorize(
+		middleware.Authority(model.PermissaoConsultarPedidos),
+		security.GerenciaRestaurante(middleware.Param("restauranteId")),
+	)
+	podeGerenciarPedido := middleware.Authorize(
+		middleware.Authority(model.PermissaoGerenciarPedidos),
+		security.GerenciaRestauranteDoPedido(middleware.Param("codigoPedido")),
+	)
+	// Pagamento e avaliação são feitos apenas pelo cliente do pedido
+	somenteClienteDoPedido := middleware.Authorize(security.ClienteDoPedido(middleware.Param("codigoPedido")))
+
+	// Cupons de desconto
+	podeEditarCupons := middleware.Authorize(middleware.Authority(model.PermissaoEditarCupons))
+
+	// Estatisticas
+	podeGerarRelatorios := middleware.Authorize(middleware.Authority(model.PermissaoGerarRelatorios))
+
+	// Outbox de eventos
+	podeGerenciarEventosOutbox := middleware.Authorize(middleware.Authority(model.PermissaoGerenciarEventosOutbox))
+
 	// Logout
-	rg.POST("/logout", r.usuarioHandler.Logout)
+	rg.POST("/logout", autenticado, r.usuarioHandler.Logout)
 
 	// Usuarios
 	usuarios := rg.Group("/usuarios")
 	{
-		usuarios.GET("/eu", r.usuarioHandler.Eu) // Nova rota
-		usuarios.GET("", r.usuarioHandler.Listar)
-		usuarios.GET("/:usuarioId", r.usuarioHandler.Buscar)
-		usuarios.PUT("/:usuarioId", r.usuarioHandler.Atualizar)
-		usuarios.PUT("/:usuarioId/senha", r.usuarioHandler.AlterarSenha)
+		usuarios.GET("/eu", autenticado, r.usuarioHandler.Eu)
+		usuarios.POST("/eu/verificacao-email/reenvio", autenticado, r.usuarioHandler.ReenviarVerificacaoEmail)
+
+		// Enderecos salvos e favoritos do usuario autenticado
+		usuarios.GET("/eu/enderecos", autenticado, r.enderecoHandler.Listar)
+		usuarios.GET("/eu/enderecos/:enderecoId", autenticado, r.enderecoHandler.Buscar)
+		usuarios.POST("/eu/enderecos", autenticado, r.enderecoHandler.Adicionar)
+		usuarios.PUT("/eu/enderecos/:enderecoId", autenticado, r.enderecoHandler.Atualizar)
+		usuarios.PUT("/eu/enderecos/:enderecoId/padrao", autenticado, r.enderecoHandler.DefinirPadrao)
+		usuarios.DELETE("/eu/enderecos/:enderecoId", autenticado, r.enderecoHandler.Remover)
+		usuarios.GET("/eu/favoritos", autenticado, r.favoritoHandler.Listar)
+		usuarios.PUT("/eu/favoritos/restaurantes/:restauranteId", autenticado, r.favoritoHandler.FavoritarRestaurante)
+		usuarios.DELETE("/eu/favoritos/restaurantes/:restauranteId", autenticado, r.favoritoHandler.DesfavoritarRestaurante)
+		usuarios.PUT("/eu/favoritos/restaurantes/:restauranteId/produtos/:produtoId", autenticado, r.favoritoHandler.FavoritarProduto)
+		usuarios.DELETE("/eu/favoritos/restaurantes/:restauranteId/produtos/:produtoId", autenticado, r.favoritoHandler.DesfavoritarProduto)
+
+		usuarios.GET("", podeConsultarUsuarios, r.usuarioHandler.Listar)
+		usuarios.GET("/:usuarioId", podeConsultarUsuario, r.usuarioHandler.Buscar)
+		usuarios.PUT("/:usuarioId", podeAlterarUsuario, r.usuarioHandler.Atualizar)
+		usuarios.PUT("/:usuarioId/senha", podeAlterarPropriaSenha, r.usuarioHandler.AlterarSenha)
 
 		// Usuario Grupos
-		usuarios.GET("/:usuarioId/grupos", r.usuarioHandler.ListarGrupos)
-		usuarios.PUT("/:usuarioId/grupos/:grupoId", r.usuarioHandler.AssociarGrupo)
-		usuarios.DELETE("/:usuarioId/grupos/:grupoId", r.usuarioHandler.DesassociarGrupo)
+		usuarios.GET("/:usuarioId/grupos", podeConsultarUsuario, r.usuarioHandler.ListarGrupos)
+		usuarios.PUT("/:usuarioId/grupos/:grupoId", podeEditarUsuarios, r.usuarioHandler.AssociarGrupo)
+		usuarios.DELETE("/:usuarioId/grupos/:grupoId", podeEditarUsuarios, r.usuarioHandler.DesassociarGrupo)
 	}
 
 	// Estados
 	estados := rg.Group("/estados")
 	{
-		estados.GET("", r.estadoHandler.Listar)
-		estados.GET("/:estadoId", r.estadoHandler.Buscar)
-		estados.POST("", r.estadoHandler.Adicionar)
-		estados.PUT("/:estadoId", r.estadoHandler.Atualizar)
-		estados.DELETE("/:estadoId", r.estadoHandler.Remover)
+		estados.GET("", autenticado, r.estadoHandler.Listar)
+		estados.GET("/:estadoId", autenticado, r.estadoHandler.Buscar)
+		estados.POST("", podeEditarEstados, r.estadoHandler.Adicionar)
+		estados.PUT("/:estadoId", podeEditarEstados, r.estadoHandler.Atualizar)
+		estados.DELETE("/:estadoId", podeEditarEstados, r.estadoHandler.Remover)
 	}
 
 	// Cidades
 	cidades := rg.Group("/cidades")
 	{
-		cidades.GET("", r.cidadeHandler.Listar)
-		cidades.GET("/:cidadeId", r.cidadeHandler.Buscar)
-		cidades.POST("", r.cidadeHandler.Adicionar)
-		cidades.PUT("/:cidadeId", r.cidadeHandler.Atualizar)
-		cidades.DELETE("/:cidadeId", r.cidadeHandler.Remover)
+		cidades.GET("", autenticado, r.cidadeHandler.Listar)
+		cidades.GET("/:cidadeId", autenticado, r.cidadeHandler.Buscar)
+		cidades.POST("", podeEditarCidades, r.cidadeHandler.Adicionar)
+		cidades.PUT("/:cidadeId", podeEditarCidades, r.cidadeHandler.Atualizar)
+		cidades.DELETE("/:cidadeId", podeEditarCidades, r.cidadeHandler.Remover)
 	}
 
 	// Cozinhas
 	cozinhas := rg.Group("/cozinhas")
 	{
-		cozinhas.GET("", r.cozinhaHandler.Listar)
-		cozinhas.GET("/:cozinhaId", r.cozinhaHandler.Buscar)
-		cozinhas.POST("", r.cozinhaHandler.Adicionar)
-		cozinhas.PUT("/:cozinhaId", r.cozinhaHandler.Atualizar)
-		cozinhas.DELETE("/:cozinhaId", r.cozinhaHandler.Remover)
+		cozinhas.GET("", autenticado, r.cozinhaHandler.Listar)
+		cozinhas.GET("/:cozinhaId", autenticado, r.cozinhaHandler.Buscar)
+		cozinhas.POST("", podeEditarCozinhas, r.cozinhaHandler.Adicionar)
+		cozinhas.PUT("/:cozinhaId", podeEditarCozinhas, r.cozinhaHandler.Atualizar)
+		cozinhas.DELETE("/:cozinhaId", podeEditarCozinhas, r.cozinhaHandler.Remover)
 	}
 
 	// Formas de Pagamento
 	formasPagamento := rg.Group("/formas-pagamento")
 	{
-		formasPagamento.GET("", r.formaPagamentoHandler.Listar)
-		formasPagamento.GET("/:formaPagamentoId", r.formaPagamentoHandler.Buscar)
-		formasPagamento.POST("", r.formaPagamentoHandler.Adicionar)
-		formasPagamento.PUT("/:formaPagamentoId", r.formaPagamentoHandler.Atualizar)
-		formasPagamento.DELETE("/:formaPagamentoId", r.formaPagamentoHandler.Remover)
+		formasPagamento.GET("", autenticado, r.formaPagamentoHandler.Listar)
+		formasPagamento.GET("/:formaPagamentoId", autenticado, r.formaPagamentoHandler.Buscar)
+		formasPagamento.POST("", podeEditarFormasPagamento, r.formaPagamentoHandler.Adicionar)
+		formasPagamento.PUT("/:formaPagamentoId", podeEditarFormasPagamento, r.formaPagamentoHandler.Atualizar)
+		formasPagamento.DELETE("/:formaPagamentoId", podeEditarFormasPagamento, r.formaPagamentoHandler.Remover)
 	}
 
 	// Permissoes
 	permissoes := rg.Group("/permissoes")
 	{
-		permissoes.GET("", r.permissaoHandler.Listar)
-		permissoes.GET("/:permissaoId", r.permissaoHandler.Buscar)
+		permissoes.GET("", podeConsultarUsuarios, r.permissaoHandler.Listar)
+		permissoes.GET("/:permissaoId", podeConsultarUsuarios, r.permissaoHandler.Buscar)
 	}
 
 	// Grupos
 	grupos := rg.Group("/grupos")
 	{
-		grupos.GET("", r.grupoHandler.Listar)
-		grupos.GET("/:grupoId", r.grupoHandler.Buscar)
-		grupos.POST("", r.grupoHandler.Adicionar)
-		grupos.PUT("/:grupoId", r.grupoHandler.Atualizar)
-		grupos.DELETE("/:grupoId", r.grupoHandler.Remover)
+		grupos.GET("", podeConsultarUsuarios, r.grupoHandler.Listar)
+		grupos.GET("/:grupoId", podeConsultarUsuarios, r.grupoHandler.Buscar)
+		grupos.POST("", podeEditarUsuarios, r.grupoHandler.Adicionar)
+		grupos.PUT("/:grupoId", podeEditarUsuarios, r.grupoHandler.Atualizar)
+		grupos.DELETE("/:grupoId", podeEditarUsuarios, r.grupoHandler.Remover)
 
 		// Grupo Permissoes
-		grupos.GET("/:grupoId/permissoes", r.grupoHandler.ListarPermissoes)
-		grupos.PUT("/:grupoId/permissoes/:permissaoId", r.grupoHandler.AssociarPermissao)
-		grupos.DELETE("/:grupoId/permissoes/:permissaoId", r.grupoHandler.DesassociarPermissao)
+		grupos.GET("/:grupoId/permissoes", podeConsultarUsuarios, r.grupoHandler.ListarPermissoes)
+		grupos.PUT("/:grupoId/permissoes/:permissaoId", podeEditarUsuarios, r.grupoHandler.AssociarPermissao)
+		grupos.DELETE("/:grupoId/permissoes/:permissaoId", podeEditarUsuarios, r.grupoHandler.DesassociarPermissao)
 	}
 
 	// Restaurantes
 	restaurantes := rg.Group("/restaurantes")
 	{
-		restaurantes.GET("", r.restauranteHandler.Listar)
-		restaurantes.GET("/:restauranteId", r.restauranteHandler.Buscar)
-		restaurantes.POST("", r.restauranteHandler.Adicionar)
-		restaurantes.PUT("/:restauranteId", r.restauranteHandler.Atualizar)
-		restaurantes.PUT("/:restauranteId/ativo", r.restauranteHandler.Ativar)
-		restaurantes.DELETE("/:restauranteId/ativo", r.restauranteHandler.Inativar)
-		restaurantes.PUT("/ativacoes", r.restauranteHandler.AtivarEmMassa)
-		restaurantes.DELETE("/ativacoes", r.restauranteHandler.InativarEmMassa)
-		restaurantes.PUT("/:restauranteId/abertura", r.restauranteHandler.Abrir)
-		restaurantes.PUT("/:restauranteId/fechamento", r.restauranteHandler.Fechar)
+		restaurantes.GET("", autenticado, r.restauranteHandler.Listar)
+		restaurantes.GET("/:restauranteId", autenticado, r.restauranteHandler.Buscar)
+		restaurantes.POST("", podeGerenciarCadastroRestaurantes, r.restauranteHandler.Adicionar)
+		restaurantes.PUT("/:restauranteId", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.Atualizar)
+		restaurantes.PUT("/:restauranteId/ativo", podeGerenciarCadastroRestaurantes, r.restauranteHandler.Ativar)
+		restaurantes.DELETE("/:restauranteId/ativo", podeGerenciarCadastroRestaurantes, r.restauranteHandler.Inativar)
+		restaurantes.PUT("/ativacoes", podeGerenciarCadastroRestaurantes, r.restauranteHandler.AtivarEmMassa)
+		restaurantes.DELETE("/ativacoes", podeGerenciarCadastroRestaurantes, r.restauranteHandler.InativarEmMassa)
+		restaurantes.PUT("/:restauranteId/abertura", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.Abrir)
+		restaurantes.PUT("/:restauranteId/fechamento", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.Fechar)
 
 		// Restaurante Formas Pagamento
-		restaurantes.GET("/:restauranteId/formas-pagamento", r.restauranteHandler.ListarFormasPagamento)
-		restaurantes.PUT("/:restauranteId/formas-pagamento/:formaPagamentoId", r.restauranteHandler.AssociarFormaPagamento)
-		restaurantes.DELETE("/:restauranteId/formas-pagamento/:formaPagamentoId", r.restauranteHandler.DesassociarFormaPagamento)
+		restaurantes.GET("/:restauranteId/formas-pagamento", autenticado, r.restauranteHandler.ListarFormasPagamento)
+		restaurantes.PUT("/:restauranteId/formas-pagamento/:formaPagamentoId", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.AssociarFormaPagamento)
+		restaurantes.DELETE("/:restauranteId/formas-pagamento/:formaPagamentoId", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.DesassociarFormaPagamento)
 
 		// Restaurante Responsaveis
-		restaurantes.GET("/:restauranteId/responsaveis", r.restauranteHandler.ListarResponsaveis)
-		restaurantes.PUT("/:restauranteId/responsaveis/:usuarioId", r.restauranteHandler.AssociarResponsavel)
-		restaurantes.DELETE("/:restauranteId/responsaveis/:usuarioId", r.restauranteHandler.DesassociarResponsavel)
+		restaurantes.GET("/:restauranteId/responsaveis", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.ListarResponsaveis)
+		restaurantes.PUT("/:restauranteId/responsaveis/:usuarioId", podeGerenciarCadastroRestaurantes, r.restauranteHandler.AssociarResponsavel)
+		restaurantes.DELETE("/:restauranteId/responsaveis/:usuarioId", podeGerenciarCadastroRestaurantes, r.restauranteHandler.DesassociarResponsavel)
+
+		// Restaurante Area de Entrega
+		restaurantes.GET("/:restauranteId/cidades-entrega", autenticado, r.restauranteHandler.ListarCidadesEntrega)
+		restaurantes.PUT("/:restauranteId/cidades-entrega/:cidadeId", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.AssociarCidadeEntrega)
+		restaurantes.DELETE("/:restauranteId/cidades-entrega/:cidadeId", podeGerenciarFuncionamentoRestaurante, r.restauranteHandler.DesassociarCidadeEntrega)
 
 		// Restaurante Produtos
-		restaurantes.GET("/:restauranteId/produtos", r.produtoHandler.Listar)
-		restaurantes.GET("/:restauranteId/produtos/:produtoId", r.produtoHandler.Buscar)
-		restaurantes.POST("/:restauranteId/produtos", r.produtoHandler.Adicionar)
-		restaurantes.PUT("/:restauranteId/produtos/:produtoId", r.produtoHandler.Atualizar)
+		restaurantes.GET("/:restauranteId/produtos", autenticado, r.produtoHandler.Listar)
+		restaurantes.GET("/:restauranteId/produtos/:produtoId", autenticado, r.produtoHandler.Buscar)
+		restaurantes.POST("/:restauranteId/produtos", podeEditarProdutos, r.produtoHandler.Adicionar)
+		restaurantes.PUT("/:restauranteId/produtos/:produtoId", podeEditarProdutos, r.produtoHandler.Atualizar)
+
+		// Grupos de opções (tamanhos, adicionais)
+		restaurantes.GET("/:restauranteId/produtos/:produtoId/opcoes", autenticado, r.grupoOpcoesHandler.Listar)
+		restaurantes.GET("/:restauranteId/produtos/:produtoId/opcoes/:grupoId", autenticado, r.grupoOpcoesHandler.Buscar)
+		restaurantes.POST("/:restauranteId/produtos/:produtoId/opcoes", podeEditarProdutos, r.grupoOpcoesHandler.Adicionar)
+		restaurantes.PUT("/:restauranteId/produtos/:produtoId/opcoes/:grupoId", podeEditarProdutos, r.grupoOpcoesHandler.Atualizar)
+		restaurantes.DELETE("/:restauranteId/produtos/:produtoId/opcoes/:grupoId", podeEditarProdutos, r.grupoOpcoesHandler.Remover)
+
+		// Restaurante Produto Foto
+		restaurantes.GET("/:restauranteId/produtos/:produtoId/foto", autenticado, r.fotoProdutoHandler.Buscar)
+		restaurantes.PUT("/:restauranteId/produtos/:produtoId/foto", podeEditarProdutos, r.fotoProdutoHandler.Atualizar)
+		restaurantes.DELETE("/:restauranteId/produtos/:produtoId/foto", podeEditarProdutos, r.fotoProdutoHandler.Remover)
+
+		// Restaurante Pedidos (stream para painel do restaurante)
+		restaurantes.GET("/:restauranteId/pedidos/stream", podeAcompanharPedidosRestaurante, r.pedidoStreamHandler.AcompanharRestaurante)
+
+		// Restaurante Horarios de Funcionamento
+		restaurantes.GET("/:restauranteId/horarios", autenticado, r.horarioHandler.Listar)
+		restaurantes.GET("/:restauranteId/horarios/:horarioId", autenticado, r.horarioHandler.Buscar)
+		restaurantes.POST("/:restauranteId/horarios", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.Adicionar)
+		restaurantes.PUT("/:restauranteId/horarios/:horarioId", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.Atualizar)
+		restaurantes.DELETE("/:restauranteId/horarios/:horarioId", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.Remover)
+
+		// Restaurante Excecoes de Horario (feriados)
+		restaurantes.GET("/:restauranteId/horarios/excecoes", autenticado, r.horarioHandler.ListarExcecoes)
+		restaurantes.GET("/:restauranteId/horarios/excecoes/:excecaoId", autenticado, r.horarioHandler.BuscarExcecao)
+		restaurantes.POST("/:restauranteId/horarios/excecoes", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.AdicionarExcecao)
+		restaurantes.PUT("/:restauranteId/horarios/excecoes/:excecaoId", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.AtualizarExcecao)
+		restaurantes.DELETE("/:restauranteId/horarios/excecoes/:excecaoId", podeGerenciarFuncionamentoRestaurante, r.horarioHandler.RemoverExcecao)
+
+		// Restaurante Frete
+		restaurantes.GET("/:restauranteId/frete", autenticado, r.freteHandler.BuscarConfiguracao)
+		restaurantes.PUT("/:restauranteId/frete", podeGerenciarFuncionamentoRestaurante, r.freteHandler.AtualizarConfiguracao)
+		restaurantes.POST("/:restauranteId/frete/simulacao", autenticado, r.freteHandler.Simular)
+
+		// Restaurante Avaliacoes
+		restaurantes.GET("/:restauranteId/avaliacoes", autenticado, r.avaliacaoHandler.ListarPorRestaurante)
+		restaurantes.PUT("/:restauranteId/avaliacoes/:avaliacaoId/resposta", podeGerenciarFuncionamentoRestaurante, r.avaliacaoHandler.Responder)
 	}
 
 	// Pedidos
 	pedidos := rg.Group("/pedidos")
 	{
-		pedidos.GET("", r.pedidoHandler.Pesquisar)
-		pedidos.GET("/:codigoPedido", r.pedidoHandler.Buscar)
-		pedidos.POST("", r.pedidoHandler.Adicionar)
-		pedidos.PUT("/:codigoPedido/confirmacao", r.pedidoHandler.Confirmar)
-		pedidos.PUT("/:codigoPedido/cancelamento", r.pedidoHandler.Cancelar)
-		pedidos.PUT("/:codigoPedido/entrega", r.pedidoHandler.Entregar)
+		pedidos.GET("", podePesquisarPedidos, r.pedidoHandler.Pesquisar)
+		pedidos.GET("/:codigoPedido", podeBuscarPedido, r.pedidoHandler.Buscar)
+		pedidos.GET("/:codigoPedido/historico", podeBuscarPedido, r.pedidoHandler.Historico)
+		pedidos.GET("/:codigoPedido/eventos", podeBuscarPedido, r.pedidoStreamHandler.AcompanharPedido)
+		pedidos.POST("", autenticado, r.rateLimit("pedidos", middleware.UsuarioAutenticadoChave()), r.pedidoHandler.Adicionar)
+		pedidos.POST("/:codigoPedido/repetir", somenteClienteDoPedido, r.rateLimit("pedidos", middleware.UsuarioAutenticadoChave()), r.pedidoHandler.Repetir)
+		pedidos.PUT("/:codigoPedido/confirmacao", podeGerenciarPedido, r.pedidoHandler.Confirmar)
+		pedidos.PUT("/:codigoPedido/preparacao", podeGerenciarPedido, r.pedidoHandler.IniciarPreparacao)
+		pedidos.PUT("/:codigoPedido/saida-entrega", podeGerenciarPedido, r.pedidoHandler.SairParaEntrega)
+		pedidos.PUT("/:codigoPedido/cancelamento", podeGerenciarPedido, r.pedidoHandler.Cancelar)
+		pedidos.PUT("/:codigoPedido/entrega", podeGerenciarPedido, r.pedidoHandler.Entregar)
+		pedidos.GET("/:codigoPedido/pagamento", podeBuscarPedido, r.pagamentoHandler.Buscar)
+		pedidos.POST("/:codigoPedido/pagamento", somenteClienteDoPedido, r.pagamentoHandler.Pagar)
+		pedidos.GET("/:codigoPedido/avaliacao", podeBuscarPedido, r.avaliacaoHandler.BuscarPorPedido)
+		pedidos.POST("/:codigoPedido/avaliacao", somenteClienteDoPedido, r.avaliacaoHandler.Avaliar)
+	}
+
+	// Carrinhos do usuario autenticado, um por restaurante
+	carrinhos := rg.Group("/carrinhos")
+	{
+		carrinhos.GET("/:restauranteId", autenticado, r.carrinhoHandler.Buscar)
+		carrinhos.DELETE("/:restauranteId", autenticado, r.carrinhoHandler.Limpar)
+		carrinhos.POST("/:restauranteId/itens", autenticado, r.carrinhoHandler.AdicionarItem)
+		carrinhos.PUT("/:restauranteId/itens/:itemId", autenticado, r.carrinhoHandler.AtualizarItem)
+		carrinhos.DELETE("/:restauranteId/itens/:itemId", autenticado, r.carrinhoHandler.RemoverItem)
+		carrinhos.POST("/:restauranteId/checkout", autenticado, r.rateLimit("pedidos", middleware.UsuarioAutenticadoChave()), r.carrinhoHandler.Checkout)
+	}
+
+	// Cupons
+	cupons := rg.Group("/cupons")
+	{
+		cupons.GET("", podeEditarCupons, r.cupomHandler.Listar)
+		cupons.GET("/:cupomId", podeEditarCupons, r.cupomHandler.Buscar)
+		cupons.POST("", podeEditarCupons, r.cupomHandler.Adicionar)
+		cupons.PUT("/:cupomId", podeEditarCupons, r.cupomHandler.Atualizar)
+		cupons.DELETE("/:cupomId", podeEditarCupons, r.cupomHandler.Remover)
+		cupons.PUT("/:cupomId/ativo", podeEditarCupons, r.cupomHandler.Ativar)
+		cupons.DELETE("/:cupomId/ativo", podeEditarCupons, r.cupomHandler.Inativar)
 	}
 
 	// Estatisticas
 	estatisticas := rg.Group("/estatisticas")
 	{
-		estatisticas.GET("/vendas-diarias", r.estatisticaHandler.ConsultarVendasDiarias)
+		estatisticas.GET("/vendas-diarias", podeGerarRelatorios, r.estatisticaHandler.ConsultarVendasDiarias)
+	}
+
+	// Eventos Outbox
+	eventosOutbox := rg.Group("/eventos-outbox")
+	{
+		eventosOutbox.GET("", podeGerenciarEventosOutbox, r.eventoOutboxHandler.Pesquisar)
+		eventosOutbox.GET("/:eventoId", podeGerenciarEventosOutbox, r.eventoOutboxHandler.Buscar)
+		eventosOutbox.PUT("/:eventoId/reprocessamento", podeGerenciarEventosOutbox, r.eventoOutboxHandler.Reprocessar)
 	}
 }
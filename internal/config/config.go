@@ -17,6 +17,12 @@ type Config struct {
 	Email       EmailConfig       `mapstructure:"email"`
 	EventBridge EventBridgeConfig `mapstructure:"eventbridge"`
 	SQS         SQSConfig         `mapstructure:"sqs"`
+	Outbox      OutboxConfig      `mapstructure:"outbox"`
+	Horario     HorarioConfig     `mapstructure:"horario"`
+	Pedido      PedidoConfig      `mapstructure:"pedido"`
+	Pagamento   PagamentoConfig   `mapstructure:"pagamento"`
+	Conta       ContaConfig       `mapstructure:"conta"`
+	RateLimit   RateLimitConfig   `mapstructure:"rate_limit"`
 	AWS         AWSConfig         `mapstructure:"aws"`
 	SpringDoc   SpringDocConfig   `mapstructure:"springdoc"`
 }
@@ -26,6 +32,9 @@ type ServerConfig struct {
 	Mode                   string `mapstructure:"mode"`
 	CompressionEnabled     bool   `mapstructure:"compression_enabled"`
 	ForwardHeadersStrategy string `mapstructure:"forward_headers_strategy"`
+	// Proxies (IPs ou CIDRs) cujos X-Forwarded-For são aceitos para identificar o cliente.
+	// Vazio: nenhum, o IP do cliente é o da conexão.
+	TrustedProxies []string `mapstructure:"trusted_proxies"`
 }
 
 type DatabaseConfig struct {
@@ -38,6 +47,8 @@ type DatabaseConfig struct {
 	ParseTime                bool   `mapstructure:"parseTime"`
 	Loc                      string `mapstructure:"loc"`
 	CreateDatabaseIfNotExist bool   `mapstructure:"create_database_if_not_exist"`
+	// AutoMigrate aplica as migrações pendentes ao iniciar a API
+	AutoMigrate bool `mapstructure:"auto_migrate"`
 }
 
 func (d *DatabaseConfig) DSN() string {
@@ -45,17 +56,39 @@ func (d *DatabaseConfig) DSN() string {
 		d.User, d.Password, d.Host, d.Port, d.Name, d.Charset, d.ParseTime, d.Loc)
 }
 
+// MigrationDSN habilita múltiplos comandos por Exec, necessário para rodar os scripts de migração
+func (d *DatabaseConfig) MigrationDSN() string {
+	return d.DSN() + "&multiStatements=true"
+}
+
 type JWTConfig struct {
 	JWKSURL   string         `mapstructure:"jwks_url"`
 	Issuer    string         `mapstructure:"issuer"`
+	Audience  string         `mapstructure:"audience"`
 	SecretKey string         `mapstructure:"secret_key"`
 	Keystore  KeystoreConfig `mapstructure:"keystore"`
+	// iss e aud esperados nos tokens do JWKS remoto; vazios, valem issuer e audience
+	JWKSIssuer   string `mapstructure:"jwks_issuer"`
+	JWKSAudience string `mapstructure:"jwks_audience"`
+	// Validade do access token (padrão 15 minutos) e do refresh token (padrão 30 dias)
+	AccessTokenTTLMinutes int `mapstructure:"access_token_ttl_minutes"`
+	RefreshTokenTTLHours  int `mapstructure:"refresh_token_ttl_hours"`
 }
 
 type KeystoreConfig struct {
 	JKSLocation  string `mapstructure:"jks_location"`
 	Password     string `mapstructure:"password"`
 	KeypairAlias string `mapstructure:"keypair_alias"`
+	// Chaves PEM para assinatura RS256/ES256. Sem chaves, os tokens usam HS256 com secret_key.
+	Keys      []ChaveConfig `mapstructure:"keys"`
+	ActiveKid string        `mapstructure:"active_kid"`
+}
+
+// ChaveConfig aponta para um arquivo PEM com a chave privada (assinatura) ou
+// apenas a pública (validação de tokens emitidos com uma chave já rotacionada)
+type ChaveConfig struct {
+	Kid  string `mapstructure:"kid"`
+	Path string `mapstructure:"path"`
 }
 
 type RedisConfig struct {
@@ -66,7 +99,9 @@ type RedisConfig struct {
 }
 
 type AuthConfig struct {
-	ProviderURL string            `mapstructure:"provider_url"`
+	ProviderURL string `mapstructure:"provider_url"`
+	// Strategy define como os tokens Bearer são validados: jwt (padrão), jwks ou introspection
+	Strategy    string            `mapstructure:"strategy"`
 	OpaqueToken OpaqueTokenConfig `mapstructure:"opaque_token"`
 }
 
@@ -77,9 +112,10 @@ type OpaqueTokenConfig struct {
 }
 
 type StorageConfig struct {
-	Type  string             `mapstructure:"type"`
-	Local LocalStorageConfig `mapstructure:"local"`
-	S3    S3StorageConfig    `mapstructure:"s3"`
+	Type        string             `mapstructure:"type"`
+	MaxFileSize int64              `mapstructure:"max_file_size"`
+	Local       LocalStorageConfig `mapstructure:"local"`
+	S3          S3StorageConfig    `mapstructure:"s3"`
 }
 
 type LocalStorageConfig struct {
@@ -131,6 +167,106 @@ type SQSConfig struct {
 	VisibilityTimeout int    `mapstructure:"visibility_timeout"`
 }
 
+type OutboxConfig struct {
+	PollIntervalSeconds int `mapstructure:"poll_interval_seconds"`
+	BatchSize           int `mapstructure:"batch_size"`
+	MaxTentativas       int `mapstructure:"max_tentativas"`
+	BackoffBaseSeconds  int `mapstructure:"backoff_base_seconds"`
+	BackoffMaxSeconds   int `mapstructure:"backoff_max_seconds"`
+}
+
+type HorarioConfig struct {
+	SchedulerIntervalSeconds int `mapstructure:"scheduler_interval_seconds"`
+}
+
+type PedidoConfig struct {
+	MaxQuantidadePorItem int `mapstructure:"max_quantidade_por_item"`
+	// CarrinhoTTLHours é por quanto tempo um carrinho sem alterações fica guardado
+	CarrinhoTTLHours int `mapstructure:"carrinho_ttl_hours"`
+}
+
+// PagamentoConfig seleciona o provedor de pagamento: fake (padrão, em memória) ou
+// gateway. WebhookSecret assina as notificações enviadas pelo provedor.
+type PagamentoConfig struct {
+	Type          string                 `mapstructure:"type"`
+	Moeda         string                 `mapstructure:"moeda"`
+	WebhookSecret string                 `mapstructure:"webhook_secret"`
+	Gateway       GatewayPagamentoConfig `mapstructure:"gateway"`
+	// EstornoIntervalSeconds é o intervalo entre as tentativas de refazer estornos que falharam
+	EstornoIntervalSeconds int `mapstructure:"estorno_interval_seconds"`
+}
+
+type GatewayPagamentoConfig struct {
+	BaseURL        string `mapstructure:"base_url"`
+	APIKey         string `mapstructure:"api_key"`
+	TimeoutSeconds int    `mapstructure:"timeout_seconds"`
+}
+
+// ContaConfig configura os links enviados por e-mail para redefinição de senha e
+// verificação de e-mail. O token é acrescentado à URL no parâmetro "token".
+type ContaConfig struct {
+	URLRedefinicaoSenha        string `mapstructure:"url_redefinicao_senha"`
+	URLVerificacaoEmail        string `mapstructure:"url_verificacao_email"`
+	RedefinicaoSenhaTTLMinutes int    `mapstructure:"redefinicao_senha_ttl_minutes"`
+	VerificacaoEmailTTLHours   int    `mapstructure:"verificacao_email_ttl_hours"`
+}
+
+// RateLimitConfig configura os limites por grupo de rotas (login, cadastro, conta, pedidos)
+// e o bloqueio de contas após falhas de login. Grupos sem configuração usam os padrões.
+type RateLimitConfig struct {
+	Grupos        map[string]RateLimitGrupoConfig `mapstructure:"grupos"`
+	BloqueioLogin BloqueioLoginConfig             `mapstructure:"bloqueio_login"`
+}
+
+// RateLimitGrupoConfig define janelas deslizantes independentes por IP e por conta
+// (e-mail informado ou usuário autenticado). Limite zero desativa a janela.
+type RateLimitGrupoConfig struct {
+	PorIP    JanelaRateLimitConfig `mapstructure:"por_ip"`
+	PorConta JanelaRateLimitConfig `mapstructure:"por_conta"`
+}
+
+type JanelaRateLimitConfig struct {
+	Limite        int `mapstructure:"limite"`
+	JanelaSeconds int `mapstructure:"janela_seconds"`
+}
+
+// BloqueioLoginConfig bloqueia a conta após MaxTentativas falhas seguidas. O bloqueio
+// dobra a cada nova falha, de BloqueioBaseSeconds até BloqueioMaxSeconds. As falhas são
+// esquecidas após JanelaFalhasSeconds sem novas tentativas ou com um login bem-sucedido.
+type BloqueioLoginConfig struct {
+	MaxTentativas       int `mapstructure:"max_tentativas"`
+	BloqueioBaseSeconds int `mapstructure:"bloqueio_base_seconds"`
+	BloqueioMaxSeconds  int `mapstructure:"bloqueio_max_seconds"`
+	JanelaFalhasSeconds int `mapstructure:"janela_falhas_seconds"`
+}
+
+// gruposRateLimitPadrao é usado para os grupos ausentes da configuração
+var gruposRateLimitPadrao = map[string]RateLimitGrupoConfig{
+	"login": {
+		PorIP:    JanelaRateLimitConfig{Limite: 20, JanelaSeconds: 60},
+		PorConta: JanelaRateLimitConfig{Limite: 5, JanelaSeconds: 60},
+	},
+	"cadastro": {
+		PorIP: JanelaRateLimitConfig{Limite: 10, JanelaSeconds: 3600},
+	},
+	"conta": {
+		PorIP:    JanelaRateLimitConfig{Limite: 20, JanelaSeconds: 3600},
+		PorConta: JanelaRateLimitConfig{Limite: 5, JanelaSeconds: 3600},
+	},
+	"pedidos": {
+		PorIP:    JanelaRateLimitConfig{Limite: 60, JanelaSeconds: 60},
+		PorConta: JanelaRateLimitConfig{Limite: 10, JanelaSeconds: 60},
+	},
+}
+
+// Grupo retorna a configuração do grupo de rotas, ou o padrão quando não configurado
+func (c *RateLimitConfig) Grupo(nome string) RateLimitGrupoConfig {
+	if grupo, ok := c.Grupos[nome]; ok {
+		return grupo
+	}
+	return gruposRateLimitPadrao[nome]
+}
+
 type AWSConfig struct {
 	EndpointURL string               `mapstructure:"endpoint_url"`
 	Region      string               `mapstructure:"region"`
@@ -50,6 +50,25 @@ func NewDatabase(cfg *DatabaseConfig) (*gorm.DB, error) {
 	return db, nil
 }
 
+// NewMigrationDB abre uma conexão dedicada às migrações
+func NewMigrationDB(cfg *DatabaseConfig) (*sql.DB, error) {
+	if cfg.CreateDatabaseIfNotExist {
+		if err := createDatabaseIfNotExists(cfg); err != nil {
+			log.Printf("Warning: could not create database: %v", err)
+		}
+	}
+
+	db, err := sql.Open("mysql", cfg.MigrationDSN())
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+	return db, nil
+}
+
 func createDatabaseIfNotExists(cfg *DatabaseConfig) error {
 	// Connect without specifying database name
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%d)/?charset=%s&parseTime=%t&loc=%s",
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/shopspring/decimal"
@@ -72,6 +73,7 @@ type PedidoCanceladoEvent struct {
 	RestauranteNome  string          `json:"restauranteNome"`
 	ValorTotal       decimal.Decimal `json:"valorTotal"`
 	DataCancelamento time.Time       `json:"dataCancelamento"`
+	Motivo           string          `json:"motivo,omitempty"`
 }
 
 func (e PedidoCanceladoEvent) EventType() string {
@@ -87,6 +89,7 @@ func NewPedidoCanceladoEvent(
 	restauranteNome string,
 	valorTotal decimal.Decimal,
 	dataCancelamento time.Time,
+	motivo string,
 ) PedidoCanceladoEvent {
 	return PedidoCanceladoEvent{
 		BaseEvent:        BaseEvent{Timestamp: time.Now()},
@@ -98,6 +101,7 @@ func NewPedidoCanceladoEvent(
 		RestauranteNome:  restauranteNome,
 		ValorTotal:       valorTotal,
 		DataCancelamento: dataCancelamento,
+		Motivo:           motivo,
 	}
 }
 
@@ -140,3 +144,103 @@ func NewPedidoEntregueEvent(
 		DataEntrega:     dataEntrega,
 	}
 }
+
+// PedidoEmPreparacaoEvent é emitido quando a cozinha começa a preparar o pedido
+type PedidoEmPreparacaoEvent struct {
+	BaseEvent
+	PedidoCodigo    string          `json:"pedidoCodigo"`
+	ClienteID       uint64          `json:"clienteId"`
+	ClienteNome     string          `json:"clienteNome"`
+	ClienteEmail    string          `json:"clienteEmail"`
+	RestauranteID   uint64          `json:"restauranteId"`
+	RestauranteNome string          `json:"restauranteNome"`
+	ValorTotal      decimal.Decimal `json:"valorTotal"`
+	DataPreparacao  time.Time       `json:"dataPreparacao"`
+}
+
+func (e PedidoEmPreparacaoEvent) EventType() string {
+	return "PedidoEmPreparacao"
+}
+
+func NewPedidoEmPreparacaoEvent(
+	pedidoCodigo string,
+	clienteID uint64,
+	clienteNome string,
+	clienteEmail string,
+	restauranteID uint64,
+	restauranteNome string,
+	valorTotal decimal.Decimal,
+	dataPreparacao time.Time,
+) PedidoEmPreparacaoEvent {
+	return PedidoEmPreparacaoEvent{
+		BaseEvent:       BaseEvent{Timestamp: time.Now()},
+		PedidoCodigo:    pedidoCodigo,
+		ClienteID:       clienteID,
+		ClienteNome:     clienteNome,
+		ClienteEmail:    clienteEmail,
+		RestauranteID:   restauranteID,
+		RestauranteNome: restauranteNome,
+		ValorTotal:      valorTotal,
+		DataPreparacao:  dataPreparacao,
+	}
+}
+
+// PedidoSaiuParaEntregaEvent é emitido quando o pedido sai para entrega
+type PedidoSaiuParaEntregaEvent struct {
+	BaseEvent
+	PedidoCodigo     string          `json:"pedidoCodigo"`
+	ClienteID        uint64          `json:"clienteId"`
+	ClienteNome      string          `json:"clienteNome"`
+	ClienteEmail     string          `json:"clienteEmail"`
+	RestauranteID    uint64          `json:"restauranteId"`
+	RestauranteNome  string          `json:"restauranteNome"`
+	ValorTotal       decimal.Decimal `json:"valorTotal"`
+	DataSaidaEntrega time.Time       `json:"dataSaidaEntrega"`
+}
+
+func (e PedidoSaiuParaEntregaEvent) EventType() string {
+	return "PedidoSaiuParaEntrega"
+}
+
+func NewPedidoSaiuParaEntregaEvent(
+	pedidoCodigo string,
+	clienteID uint64,
+	clienteNome string,
+	clienteEmail string,
+	restauranteID uint64,
+	restauranteNome string,
+	valorTotal decimal.Decimal,
+	dataSaidaEntrega time.Time,
+) PedidoSaiuParaEntregaEvent {
+	return PedidoSaiuParaEntregaEvent{
+		BaseEvent:        BaseEvent{Timestamp: time.Now()},
+		PedidoCodigo:     pedidoCodigo,
+		ClienteID:        clienteID,
+		ClienteNome:      clienteNome,
+		ClienteEmail:     clienteEmail,
+		RestauranteID:    restauranteID,
+		RestauranteNome:  restauranteNome,
+		ValorTotal:       valorTotal,
+		DataSaidaEntrega: dataSaidaEntrega,
+	}
+}
+
+// RawEvent é um evento já serializado, usado para publicar eventos armazenados no outbox
+type RawEvent struct {
+	Type      string
+	Payload   json.RawMessage
+	Timestamp time.Time
+}
+
+func (e RawEvent) EventType() string {
+	return e.Type
+}
+
+func (e RawEvent) OccurredAt() time.Time {
+	return e.Timestamp
+}
+
+// MarshalJSON retorna o payload original do evento
+func (e RawEvent) MarshalJSON() ([]byte, error) {
+	return e.Payload, nil
+}
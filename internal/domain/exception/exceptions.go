@@ -1,10 +1,20 @@
 package exception
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // NegocioException represents a business logic error
 type NegocioException struct {
 	Message string
+	Campos  []CampoInvalido
+}
+
+// CampoInvalido details which field violated a business rule
+type CampoInvalido struct {
+	Nome     string
+	Mensagem string
 }
 
 func (e *NegocioException) Error() string {
@@ -15,6 +25,11 @@ func NewNegocioException(message string) *NegocioException {
 	return &NegocioException{Message: message}
 }
 
+// NewNegocioExceptionComCampos creates a business error with field details
+func NewNegocioExceptionComCampos(message string, campos ...CampoInvalido) *NegocioException {
+	return &NegocioException{Message: message, Campos: campos}
+}
+
 // EntidadeNaoEncontradaException represents a not found error
 type EntidadeNaoEncontradaException struct {
 	Message string
@@ -170,3 +185,192 @@ func NewFotoProdutoNaoEncontradaException(restauranteID, produtoID uint64) *Foto
 		},
 	}
 }
+
+type EventoOutboxNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewEventoOutboxNaoEncontradoException(eventoID uint64) *EventoOutboxNaoEncontradoException {
+	return &EventoOutboxNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um evento de outbox com codigo %d", eventoID),
+		},
+	}
+}
+
+type HorarioFuncionamentoNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewHorarioFuncionamentoNaoEncontradoException(restauranteID, horarioID uint64) *HorarioFuncionamentoNaoEncontradoException {
+	return &HorarioFuncionamentoNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um cadastro de horario de funcionamento com codigo %d para o restaurante de codigo %d", horarioID, restauranteID),
+		},
+	}
+}
+
+type ExcecaoHorarioNaoEncontradaException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewExcecaoHorarioNaoEncontradaException(restauranteID, excecaoID uint64) *ExcecaoHorarioNaoEncontradaException {
+	return &ExcecaoHorarioNaoEncontradaException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um cadastro de excecao de horario com codigo %d para o restaurante de codigo %d", excecaoID, restauranteID),
+		},
+	}
+}
+
+type CupomNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewCupomNaoEncontradoException(cupomID uint64) *CupomNaoEncontradoException {
+	return &CupomNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um cadastro de cupom com codigo %d", cupomID),
+		},
+	}
+}
+
+type GrupoOpcoesNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewGrupoOpcoesNaoEncontradoException(produtoID, grupoID uint64) *GrupoOpcoesNaoEncontradoException {
+	return &GrupoOpcoesNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um grupo de opcoes com codigo %d para o produto de codigo %d", grupoID, produtoID),
+		},
+	}
+}
+
+// PagamentoNaoEncontradoException is returned when the order has no payment
+type PagamentoNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewPagamentoNaoEncontradoException(codigoPedido string) *PagamentoNaoEncontradoException {
+	return &PagamentoNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um pagamento para o pedido de codigo %s", codigoPedido),
+		},
+	}
+}
+
+// AvaliacaoNaoEncontradaException is returned when a rating is not found
+type AvaliacaoNaoEncontradaException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewAvaliacaoNaoEncontradaException(restauranteID, avaliacaoID uint64) *AvaliacaoNaoEncontradaException {
+	return &AvaliacaoNaoEncontradaException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe uma avaliacao com codigo %d para o restaurante de codigo %d", avaliacaoID, restauranteID),
+		},
+	}
+}
+
+func NewAvaliacaoPedidoNaoEncontradaException(codigoPedido string) *AvaliacaoNaoEncontradaException {
+	return &AvaliacaoNaoEncontradaException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("O pedido de codigo %s ainda nao foi avaliado", codigoPedido),
+		},
+	}
+}
+
+// EnderecoUsuarioNaoEncontradoException is returned when a saved address is not found
+type EnderecoUsuarioNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewEnderecoUsuarioNaoEncontradoException(enderecoID uint64) *EnderecoUsuarioNaoEncontradoException {
+	return &EnderecoUsuarioNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um endereco salvo com codigo %d", enderecoID),
+		},
+	}
+}
+
+// ItemCarrinhoNaoEncontradoException is returned when a cart item is not found
+type ItemCarrinhoNaoEncontradoException struct {
+	EntidadeNaoEncontradaException
+}
+
+func NewItemCarrinhoNaoEncontradoException(itemID uint64) *ItemCarrinhoNaoEncontradoException {
+	return &ItemCarrinhoNaoEncontradoException{
+		EntidadeNaoEncontradaException{
+			Message: fmt.Sprintf("Nao existe um item com codigo %d no carrinho", itemID),
+		},
+	}
+}
+
+// ChaveIdempotenciaReutilizadaException is returned when an Idempotency-Key is reused with a different payload
+type ChaveIdempotenciaReutilizadaException struct {
+	Message string
+}
+
+func (e *ChaveIdempotenciaReutilizadaException) Error() string {
+	return e.Message
+}
+
+func NewChaveIdempotenciaReutilizadaException(chave string) *ChaveIdempotenciaReutilizadaException {
+	return &ChaveIdempotenciaReutilizadaException{
+		Message: fmt.Sprintf("A chave de idempotencia %s ja foi utilizada com uma requisicao diferente", chave),
+	}
+}
+
+// StatusPedidoAlteradoException is returned when another request changed the order status first
+type StatusPedidoAlteradoException struct {
+	Message string
+}
+
+func (e *StatusPedidoAlteradoException) Error() string {
+	return e.Message
+}
+
+func NewStatusPedidoAlteradoException(codigo string) *StatusPedidoAlteradoException {
+	return &StatusPedidoAlteradoException{
+		Message: fmt.Sprintf("O status do pedido %s foi alterado por outra requisicao. Consulte o pedido e tente novamente", codigo),
+	}
+}
+
+// RequisicaoEmProcessamentoException is returned when a request with the same Idempotency-Key is still running
+type RequisicaoEmProcessamentoException struct {
+	Message string
+}
+
+func (e *RequisicaoEmProcessamentoException) Error() string {
+	return e.Message
+}
+
+func NewRequisicaoEmProcessamentoException(chave string) *RequisicaoEmProcessamentoException {
+	return &RequisicaoEmProcessamentoException{
+		Message: fmt.Sprintf("Uma requisicao com a chave de idempotencia %s ainda esta em processamento", chave),
+	}
+}
+
+// LimiteRequisicoesExcedidoException is returned when a rate limit or a login lockout rejects the request
+type LimiteRequisicoesExcedidoException struct {
+	Message    string
+	RetryAfter time.Duration
+}
+
+func (e *LimiteRequisicoesExcedidoException) Error() string {
+	return e.Message
+}
+
+func NewLimiteRequisicoesExcedidoException(retryAfter time.Duration) *LimiteRequisicoesExcedidoException {
+	return &LimiteRequisicoesExcedidoException{
+		Message:    "Muitas requisicoes. Aguarde alguns instantes e tente novamente",
+		RetryAfter: retryAfter,
+	}
+}
+
+func NewLoginBloqueadoException(retryAfter time.Duration) *LimiteRequisicoesExcedidoException {
+	return &LimiteRequisicoesExcedidoException{
+		Message:    "Muitas tentativas de login sem sucesso. A conta esta temporariamente bloqueada",
+		RetryAfter: retryAfter,
+	}
+}
@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	NotaMinimaAvaliacao = 1
+	NotaMaximaAvaliacao = 5
+)
+
+// Avaliacao represents the customer's rating of a delivered order. Cada pedido tem no
+// máximo uma avaliação, que o restaurante pode responder.
+type Avaliacao struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	PedidoID      uint64     `gorm:"not null;uniqueIndex" json:"pedidoId"`
+	Pedido        *Pedido    `gorm:"foreignKey:PedidoID" json:"-"`
+	RestauranteID uint64     `gorm:"not null;index" json:"restauranteId"`
+	ClienteID     uint64     `gorm:"column:usuario_cliente_id;not null" json:"clienteId"`
+	Cliente       Usuario    `gorm:"foreignKey:ClienteID" json:"cliente,omitempty"`
+	Nota          int        `gorm:"not null" json:"nota"`
+	Comentario    string     `gorm:"size:500" json:"comentario,omitempty"`
+	Resposta      string     `gorm:"size:500" json:"resposta,omitempty"`
+	DataResposta  *time.Time `json:"dataResposta,omitempty"`
+	DataCriacao   time.Time  `gorm:"autoCreateTime" json:"dataCriacao"`
+}
+
+func (Avaliacao) TableName() string {
+	return "avaliacao"
+}
+
+// Responder records the restaurant's reply, replacing a previous one
+func (a *Avaliacao) Responder(resposta string) {
+	agora := time.Now()
+	a.Resposta = resposta
+	a.DataResposta = &agora
+}
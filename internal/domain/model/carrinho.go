@@ -0,0 +1,143 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Carrinho é a cesta de um cliente em um restaurante, guardada no Redis até o checkout.
+// Os preços dos itens são os do momento em que foram adicionados ou repreçados; a prévia
+// e o checkout sempre usam os preços atuais.
+type Carrinho struct {
+	UsuarioID       uint64         `json:"usuarioId"`
+	RestauranteID   uint64         `json:"restauranteId"`
+	Itens           []ItemCarrinho `json:"itens"`
+	ProximoItemID   uint64         `json:"proximoItemId"`
+	Avisos          []string       `json:"avisos,omitempty"`
+	DataAtualizacao time.Time      `json:"dataAtualizacao"`
+}
+
+// ItemCarrinho é um produto escolhido no carrinho, com as opções escolhidas
+type ItemCarrinho struct {
+	ID            uint64          `json:"id"`
+	ProdutoID     uint64          `json:"produtoId"`
+	ProdutoNome   string          `json:"produtoNome"`
+	Quantidade    int             `json:"quantidade"`
+	Observacao    string          `json:"observacao,omitempty"`
+	Opcoes        []uint64        `json:"opcoes,omitempty"`
+	PrecoUnitario decimal.Decimal `json:"precoUnitario"`
+}
+
+// NewCarrinho creates an empty cart for the user in the restaurant
+func NewCarrinho(usuarioID, restauranteID uint64) *Carrinho {
+	return &Carrinho{
+		UsuarioID:     usuarioID,
+		RestauranteID: restauranteID,
+		Itens:         []ItemCarrinho{},
+		ProximoItemID: 1,
+	}
+}
+
+// Vazio returns true when the cart has no items
+func (c *Carrinho) Vazio() bool {
+	return len(c.Itens) == 0
+}
+
+// AdicionarItem adds the item with a new id
+func (c *Carrinho) AdicionarItem(item ItemCarrinho) *ItemCarrinho {
+	if c.ProximoItemID == 0 {
+		c.ProximoItemID = 1
+	}
+	item.ID = c.ProximoItemID
+	c.ProximoItemID++
+	c.Itens = append(c.Itens, item)
+	return &c.Itens[len(c.Itens)-1]
+}
+
+// BuscarItem returns the item with the given id, or nil
+func (c *Carrinho) BuscarItem(itemID uint64) *ItemCarrinho {
+	for i := range c.Itens {
+		if c.Itens[i].ID == itemID {
+			return &c.Itens[i]
+		}
+	}
+	return nil
+}
+
+// RemoverItem removes the item with the given id and reports whether it existed
+func (c *Carrinho) RemoverItem(itemID uint64) bool {
+	for i := range c.Itens {
+		if c.Itens[i].ID == itemID {
+			c.Itens = append(c.Itens[:i], c.Itens[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// ContemProduto returns true when some item refers to the product
+func (c *Carrinho) ContemProduto(produtoID uint64) bool {
+	for _, item := range c.Itens {
+		if item.ProdutoID == produtoID {
+			return true
+		}
+	}
+	return false
+}
+
+// ParaPedido builds an order with the cart items; prices and options are filled on emission
+func (c *Carrinho) ParaPedido() *Pedido {
+	pedido := &Pedido{
+		RestauranteID: c.RestauranteID,
+		ClienteID:     c.UsuarioID,
+		Itens:         make([]ItemPedido, len(c.Itens)),
+	}
+	for i, item := range c.Itens {
+		pedido.Itens[i] = ItemPedido{
+			ProdutoID:  item.ProdutoID,
+			Quantidade: item.Quantidade,
+			Observacao: item.Observacao,
+		}
+		for _, opcaoID := range item.Opcoes {
+			pedido.Itens[i].Opcoes = append(pedido.Itens[i].Opcoes, ItemPedidoOpcao{OpcaoID: opcaoID})
+		}
+	}
+	return pedido
+}
+
+// AplicarAlteracaoProduto removes the product items when it was deactivated or reprices them
+// when its price changed, recording a notice for the customer. Reports whether the cart changed.
+func (c *Carrinho) AplicarAlteracaoProduto(produto *Produto) bool {
+	var precoAnterior *decimal.Decimal
+	removido := false
+	itens := c.Itens[:0]
+	for _, item := range c.Itens {
+		if item.ProdutoID != produto.ID {
+			itens = append(itens, item)
+			continue
+		}
+		if !produto.Ativo {
+			removido = true
+			continue
+		}
+		if !item.PrecoUnitario.Equal(produto.Preco) {
+			anterior := item.PrecoUnitario
+			precoAnterior = &anterior
+			item.PrecoUnitario = produto.Preco
+		}
+		item.ProdutoNome = produto.Nome
+		itens = append(itens, item)
+	}
+	c.Itens = itens
+
+	switch {
+	case removido:
+		c.Avisos = append(c.Avisos, fmt.Sprintf("O produto %s nao esta mais disponivel e foi removido do carrinho", produto.Nome))
+	case precoAnterior != nil:
+		c.Avisos = append(c.Avisos, fmt.Sprintf("O preco do produto %s mudou de %s para %s",
+			produto.Nome, precoAnterior.StringFixed(2), produto.Preco.StringFixed(2)))
+	}
+	return removido || precoAnterior != nil
+}
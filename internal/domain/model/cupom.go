@@ -0,0 +1,93 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TipoDescontoCupom identifies how a coupon discounts the order
+type TipoDescontoCupom string
+
+const (
+	// TipoDescontoPercentual desconta um percentual (Valor) do subtotal
+	TipoDescontoPercentual TipoDescontoCupom = "PERCENTUAL"
+	// TipoDescontoValorFixo desconta um valor fixo do subtotal
+	TipoDescontoValorFixo TipoDescontoCupom = "VALOR_FIXO"
+	// TipoDescontoFreteGratis desconta a taxa de frete
+	TipoDescontoFreteGratis TipoDescontoCupom = "FRETE_GRATIS"
+)
+
+// Cupom represents a discount coupon. Sem RestauranteID o cupom vale para todos os restaurantes.
+// Os limites de uso não contam pedidos cancelados.
+type Cupom struct {
+	ID                   uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	Codigo               string            `gorm:"size:30;uniqueIndex;not null" json:"codigo"`
+	Descricao            string            `gorm:"size:255" json:"descricao"`
+	Tipo                 TipoDescontoCupom `gorm:"size:20;not null" json:"tipo"`
+	Valor                decimal.Decimal   `gorm:"type:decimal(10,2);not null" json:"valor"`
+	RestauranteID        *uint64           `json:"restauranteId,omitempty"`
+	Restaurante          *Restaurante      `gorm:"foreignKey:RestauranteID" json:"restaurante,omitempty"`
+	ValorMinimoPedido    *decimal.Decimal  `gorm:"type:decimal(10,2)" json:"valorMinimoPedido,omitempty"`
+	ValidoDe             *time.Time        `json:"validoDe,omitempty"`
+	ValidoAte            *time.Time        `json:"validoAte,omitempty"`
+	LimiteUsos           *int              `json:"limiteUsos,omitempty"`
+	LimiteUsosPorCliente *int              `json:"limiteUsosPorCliente,omitempty"`
+	Ativo                bool              `gorm:"not null" json:"ativo"`
+	DataCadastro         time.Time         `gorm:"autoCreateTime" json:"dataCadastro"`
+	DataAtualizacao      time.Time         `gorm:"autoUpdateTime" json:"dataAtualizacao"`
+
+	// TotalUsos é calculado nas consultas (somente leitura)
+	TotalUsos int64 `gorm:"column:total_usos;->;-:migration" json:"totalUsos"`
+}
+
+func (Cupom) TableName() string {
+	return "cupom"
+}
+
+// Vigente checks if the coupon is active and inside its validity window
+func (c *Cupom) Vigente(instante time.Time) bool {
+	if !c.Ativo {
+		return false
+	}
+	if c.ValidoDe != nil && instante.Before(*c.ValidoDe) {
+		return false
+	}
+	if c.ValidoAte != nil && instante.After(*c.ValidoAte) {
+		return false
+	}
+	return true
+}
+
+// ValidoPara checks if the coupon can be used in the restaurant
+func (c *Cupom) ValidoPara(restauranteID uint64) bool {
+	return c.RestauranteID == nil || *c.RestauranteID == restauranteID
+}
+
+// CalcularDesconto returns the discount for the order values. O desconto nunca é
+// maior que o valor sobre o qual incide.
+func (c *Cupom) CalcularDesconto(subtotal, taxaFrete decimal.Decimal) decimal.Decimal {
+	switch c.Tipo {
+	case TipoDescontoPercentual:
+		return decimal.Min(subtotal, subtotal.Mul(c.Valor).Div(decimal.NewFromInt(100)).Round(2))
+	case TipoDescontoValorFixo:
+		return decimal.Min(subtotal, c.Valor)
+	case TipoDescontoFreteGratis:
+		return taxaFrete
+	default:
+		return decimal.Zero
+	}
+}
+
+// CupomUso records the use of a coupon by an order
+type CupomUso struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	CupomID   uint64    `gorm:"not null" json:"cupomId"`
+	PedidoID  uint64    `gorm:"not null" json:"pedidoId"`
+	ClienteID uint64    `gorm:"column:usuario_cliente_id;not null" json:"clienteId"`
+	DataUso   time.Time `gorm:"autoCreateTime" json:"dataUso"`
+}
+
+func (CupomUso) TableName() string {
+	return "cupom_uso"
+}
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestCupomCalcularDesconto(t *testing.T) {
+	valor := decimal.RequireFromString
+
+	tests := []struct {
+		name      string
+		tipo      TipoDescontoCupom
+		valor     string
+		subtotal  string
+		taxaFrete string
+		want      string
+	}{
+		{name: "percentual", tipo: TipoDescontoPercentual, valor: "10", subtotal: "80.00", taxaFrete: "5.00", want: "8.00"},
+		{name: "percentual arredonda em centavos", tipo: TipoDescontoPercentual, valor: "15", subtotal: "33.33", taxaFrete: "5.00", want: "5.00"},
+		{name: "percentual limitado ao subtotal", tipo: TipoDescontoPercentual, valor: "150", subtotal: "40.00", taxaFrete: "5.00", want: "40.00"},
+		{name: "valor fixo", tipo: TipoDescontoValorFixo, valor: "15.00", subtotal: "80.00", taxaFrete: "5.00", want: "15.00"},
+		{name: "valor fixo limitado ao subtotal", tipo: TipoDescontoValorFixo, valor: "50.00", subtotal: "30.00", taxaFrete: "5.00", want: "30.00"},
+		{name: "frete gratis desconta o frete", tipo: TipoDescontoFreteGratis, valor: "0", subtotal: "80.00", taxaFrete: "7.50", want: "7.50"},
+		{name: "frete gratis sem frete", tipo: TipoDescontoFreteGratis, valor: "0", subtotal: "80.00", taxaFrete: "0", want: "0"},
+		{name: "tipo desconhecido", tipo: "OUTRO", valor: "10", subtotal: "80.00", taxaFrete: "5.00", want: "0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cupom := Cupom{Tipo: tt.tipo, Valor: valor(tt.valor)}
+			got := cupom.CalcularDesconto(valor(tt.subtotal), valor(tt.taxaFrete))
+			if !got.Equal(valor(tt.want)) {
+				t.Errorf("CalcularDesconto = %s, esperava %s", got, tt.want)
+			}
+		})
+	}
+}
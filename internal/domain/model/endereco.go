@@ -9,4 +9,12 @@ type Endereco struct {
 	Bairro      string `gorm:"column:endereco_bairro;size:60" json:"bairro"`
 	CidadeID    uint64 `gorm:"column:endereco_cidade_id" json:"cidadeId"`
 	Cidade      Cidade `gorm:"foreignKey:CidadeID" json:"cidade,omitempty"`
+	// Coordenadas opcionais, usadas na busca por raio
+	Latitude  *float64 `gorm:"column:endereco_latitude;type:decimal(10,7)" json:"latitude,omitempty"`
+	Longitude *float64 `gorm:"column:endereco_longitude;type:decimal(10,7)" json:"longitude,omitempty"`
+}
+
+// PossuiCoordenadas checks if the address has latitude and longitude
+func (e *Endereco) PossuiCoordenadas() bool {
+	return e.Latitude != nil && e.Longitude != nil
 }
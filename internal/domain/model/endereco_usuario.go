@@ -0,0 +1,34 @@
+package model
+
+import "time"
+
+// EnderecoUsuario is an address saved by the customer. Ao emitir um pedido o endereço é
+// copiado para EnderecoEntrega, então alterá-lo ou removê-lo não muda pedidos já feitos.
+type EnderecoUsuario struct {
+	ID              uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	UsuarioID       uint64    `gorm:"not null;index" json:"usuarioId"`
+	Apelido         string    `gorm:"size:40;not null" json:"apelido"`
+	Endereco        Endereco  `gorm:"embedded" json:"endereco"`
+	Padrao          bool      `gorm:"not null" json:"padrao"`
+	DataCadastro    time.Time `gorm:"autoCreateTime" json:"dataCadastro"`
+	DataAtualizacao time.Time `gorm:"autoUpdateTime" json:"dataAtualizacao"`
+}
+
+func (EnderecoUsuario) TableName() string {
+	return "usuario_endereco"
+}
+
+// ParaEntrega copies the saved address into a delivery address snapshot
+func (e *EnderecoUsuario) ParaEntrega() EnderecoEntrega {
+	return EnderecoEntrega{
+		CEP:         e.Endereco.CEP,
+		Logradouro:  e.Endereco.Logradouro,
+		Numero:      e.Endereco.Numero,
+		Complemento: e.Endereco.Complemento,
+		Bairro:      e.Endereco.Bairro,
+		CidadeID:    e.Endereco.CidadeID,
+		Cidade:      e.Endereco.Cidade,
+		Latitude:    e.Endereco.Latitude,
+		Longitude:   e.Endereco.Longitude,
+	}
+}
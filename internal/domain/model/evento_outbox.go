@@ -0,0 +1,73 @@
+package model
+
+import "time"
+
+// StatusEventoOutbox represents the delivery status of an outbox event
+type StatusEventoOutbox string
+
+const (
+	StatusEventoOutboxPendente  StatusEventoOutbox = "PENDENTE"
+	StatusEventoOutboxPublicado StatusEventoOutbox = "PUBLICADO"
+	StatusEventoOutboxFalhou    StatusEventoOutbox = "FALHOU"
+)
+
+// EventoOutbox represents a domain event waiting to be published (transactional outbox)
+type EventoOutbox struct {
+	ID               uint64             `gorm:"primaryKey;autoIncrement" json:"id"`
+	Tipo             string             `gorm:"size:60;not null" json:"tipo"`
+	Payload          string             `gorm:"type:text;not null" json:"payload"`
+	Status           StatusEventoOutbox `gorm:"type:varchar(15);not null;default:'PENDENTE'" json:"status"`
+	Tentativas       int                `gorm:"not null;default:0" json:"tentativas"`
+	UltimoErro       string             `gorm:"size:1000" json:"ultimoErro"`
+	DataOcorrencia   time.Time          `gorm:"not null" json:"dataOcorrencia"`
+	DataCriacao      time.Time          `gorm:"autoCreateTime" json:"dataCriacao"`
+	ProximaTentativa time.Time          `gorm:"not null" json:"proximaTentativa"`
+	DataPublicacao   *time.Time         `json:"dataPublicacao,omitempty"`
+}
+
+func (EventoOutbox) TableName() string {
+	return "evento_outbox"
+}
+
+// MarcarPublicado marks the event as successfully published
+func (e *EventoOutbox) MarcarPublicado() {
+	e.Status = StatusEventoOutboxPublicado
+	now := time.Now()
+	e.DataPublicacao = &now
+	e.UltimoErro = ""
+}
+
+// RegistrarFalha records a failed publish attempt. When maxTentativas is reached
+// the event is moved to the dead-letter state (FALHOU); otherwise it is rescheduled
+// using exponential backoff.
+func (e *EventoOutbox) RegistrarFalha(err error, maxTentativas int, backoffBase, backoffMax time.Duration) {
+	e.Tentativas++
+	e.UltimoErro = truncate(err.Error(), 1000)
+
+	if e.Tentativas >= maxTentativas {
+		e.Status = StatusEventoOutboxFalhou
+		return
+	}
+
+	backoff := backoffMax
+	if e.Tentativas <= 30 {
+		if exp := backoffBase << (e.Tentativas - 1); exp > 0 && exp < backoffMax {
+			backoff = exp
+		}
+	}
+	e.ProximaTentativa = time.Now().Add(backoff)
+}
+
+// Reprocessar resets the event so the relay publishes it again
+func (e *EventoOutbox) Reprocessar() {
+	e.Status = StatusEventoOutboxPendente
+	e.Tentativas = 0
+	e.ProximaTentativa = time.Now()
+}
+
+func truncate(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	return s[:max]
+}
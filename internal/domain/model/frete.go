@@ -0,0 +1,70 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// TipoFrete identifies the freight strategy used by a restaurant
+type TipoFrete string
+
+const (
+	// TipoFreteFixo cobra a TaxaFrete do restaurante em qualquer entrega
+	TipoFreteFixo TipoFrete = "FIXO"
+	// TipoFreteLocalidade usa a tabela de taxas por cidade ou bairro
+	TipoFreteLocalidade TipoFrete = "LOCALIDADE"
+	// TipoFreteDistancia usa faixas de distância entre o restaurante e a entrega
+	TipoFreteDistancia TipoFrete = "DISTANCIA"
+)
+
+// RegraFreteGratis é registrada no pedido quando o subtotal atinge o mínimo para frete grátis
+const RegraFreteGratis = "FRETE_GRATIS"
+
+// ConfiguracaoFrete is the freight configuration of a restaurant. Restaurantes sem
+// configuração usam o frete fixo (TaxaFrete).
+type ConfiguracaoFrete struct {
+	RestauranteID uint64    `gorm:"primaryKey;autoIncrement:false" json:"restauranteId"`
+	Tipo          TipoFrete `gorm:"size:20;not null" json:"tipo"`
+	// FreteGratisAcima zera o frete de pedidos com subtotal a partir do valor, em qualquer estratégia
+	FreteGratisAcima *decimal.Decimal      `gorm:"type:decimal(10,2)" json:"freteGratisAcima,omitempty"`
+	Localidades      []FreteLocalidade     `gorm:"foreignKey:RestauranteID;references:RestauranteID" json:"localidades,omitempty"`
+	Faixas           []FaixaFreteDistancia `gorm:"foreignKey:RestauranteID;references:RestauranteID" json:"faixas,omitempty"`
+}
+
+func (ConfiguracaoFrete) TableName() string {
+	return "restaurante_frete"
+}
+
+// FreteLocalidade is a fee of the city/neighborhood table. Bairro vazio vale para a cidade inteira.
+type FreteLocalidade struct {
+	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	RestauranteID uint64          `gorm:"not null" json:"restauranteId"`
+	CidadeID      uint64          `gorm:"not null" json:"cidadeId"`
+	Cidade        Cidade          `gorm:"foreignKey:CidadeID" json:"cidade,omitempty"`
+	Bairro        string          `gorm:"size:60;not null;default:''" json:"bairro,omitempty"`
+	Taxa          decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"taxa"`
+}
+
+func (FreteLocalidade) TableName() string {
+	return "restaurante_frete_localidade"
+}
+
+// FaixaFreteDistancia is a distance band: entregas a até DistanciaMaxKm pagam Taxa
+type FaixaFreteDistancia struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	RestauranteID  uint64          `gorm:"not null" json:"restauranteId"`
+	DistanciaMaxKm float64         `gorm:"type:decimal(6,2);not null" json:"distanciaMaxKm"`
+	Taxa           decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"taxa"`
+}
+
+func (FaixaFreteDistancia) TableName() string {
+	return "restaurante_frete_faixa"
+}
+
+// CotacaoFrete is the result of a freight calculation. Regra e Descricao são
+// gravadas no pedido para auditoria.
+type CotacaoFrete struct {
+	Taxa        decimal.Decimal
+	Regra       string
+	Descricao   string
+	DistanciaKm *float64
+}
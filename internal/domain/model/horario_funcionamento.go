@@ -0,0 +1,167 @@
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// FusoHorarioPadrao é usado quando o restaurante não informa um fuso horário
+const FusoHorarioPadrao = "America/Sao_Paulo"
+
+// DiaSemana represents a day of the week
+type DiaSemana string
+
+const (
+	DiaSemanaDomingo DiaSemana = "DOMINGO"
+	DiaSemanaSegunda DiaSemana = "SEGUNDA"
+	DiaSemanaTerca   DiaSemana = "TERCA"
+	DiaSemanaQuarta  DiaSemana = "QUARTA"
+	DiaSemanaQuinta  DiaSemana = "QUINTA"
+	DiaSemanaSexta   DiaSemana = "SEXTA"
+	DiaSemanaSabado  DiaSemana = "SABADO"
+)
+
+var diasSemana = map[time.Weekday]DiaSemana{
+	time.Sunday:    DiaSemanaDomingo,
+	time.Monday:    DiaSemanaSegunda,
+	time.Tuesday:   DiaSemanaTerca,
+	time.Wednesday: DiaSemanaQuarta,
+	time.Thursday:  DiaSemanaQuinta,
+	time.Friday:    DiaSemanaSexta,
+	time.Saturday:  DiaSemanaSabado,
+}
+
+// DiaSemanaDe returns the DiaSemana for the given instant
+func DiaSemanaDe(t time.Time) DiaSemana {
+	return diasSemana[t.Weekday()]
+}
+
+// HorarioFuncionamento represents a weekly opening interval of a restaurant.
+// Um intervalo cuja hora de fechamento é menor ou igual à de abertura termina no dia seguinte.
+type HorarioFuncionamento struct {
+	ID             uint64    `gorm:"primaryKey;autoIncrement" json:"id"`
+	RestauranteID  uint64    `gorm:"not null" json:"restauranteId"`
+	DiaSemana      DiaSemana `gorm:"size:10;not null" json:"diaSemana"`
+	HoraAbertura   string    `gorm:"size:5;not null" json:"horaAbertura"`
+	HoraFechamento string    `gorm:"size:5;not null" json:"horaFechamento"`
+}
+
+func (HorarioFuncionamento) TableName() string {
+	return "restaurante_horario_funcionamento"
+}
+
+// ExcecaoHorario represents a date (e.g. a holiday) that overrides the weekly schedule
+type ExcecaoHorario struct {
+	ID             uint64  `gorm:"primaryKey;autoIncrement" json:"id"`
+	RestauranteID  uint64  `gorm:"not null" json:"restauranteId"`
+	Data           string  `gorm:"size:10;not null" json:"data"` // YYYY-MM-DD, no fuso do restaurante
+	Descricao      string  `gorm:"size:80" json:"descricao"`
+	Fechado        bool    `gorm:"not null" json:"fechado"`
+	HoraAbertura   *string `gorm:"size:5" json:"horaAbertura,omitempty"`
+	HoraFechamento *string `gorm:"size:5" json:"horaFechamento,omitempty"`
+}
+
+func (ExcecaoHorario) TableName() string {
+	return "restaurante_excecao_horario"
+}
+
+// FormatoData is the layout used by ExcecaoHorario.Data
+const FormatoData = "2006-01-02"
+
+// MesmaData checks if the exception applies to the calendar date of t
+func (e *ExcecaoHorario) MesmaData(t time.Time) bool {
+	return e.Data == t.Format(FormatoData)
+}
+
+// ParseHora converts "HH:MM" into minutes since midnight. "24:00" is accepted as end of day.
+func ParseHora(hora string) (int, error) {
+	var h, m int
+	if len(hora) != 5 || hora[2] != ':' {
+		return 0, fmt.Errorf("hora %q deve estar no formato HH:MM", hora)
+	}
+	if _, err := fmt.Sscanf(hora, "%02d:%02d", &h, &m); err != nil {
+		return 0, fmt.Errorf("hora %q deve estar no formato HH:MM", hora)
+	}
+	if h == 24 && m == 0 {
+		return 24 * 60, nil
+	}
+	if h < 0 || h > 23 || m < 0 || m > 59 {
+		return 0, fmt.Errorf("hora %q invalida", hora)
+	}
+	return h*60 + m, nil
+}
+
+// intervalo represents an opening interval in minutes since midnight
+type intervalo struct {
+	abertura   int
+	fechamento int
+}
+
+func (i intervalo) viraDia() bool {
+	return i.fechamento <= i.abertura
+}
+
+func novoIntervalo(abertura, fechamento string) (intervalo, bool) {
+	a, err := ParseHora(abertura)
+	if err != nil || a == 24*60 {
+		return intervalo{}, false
+	}
+	f, err := ParseHora(fechamento)
+	if err != nil {
+		return intervalo{}, false
+	}
+	return intervalo{abertura: a, fechamento: f}, true
+}
+
+// intervalosDoDia returns the intervals that start on the calendar date of t
+func intervalosDoDia(horarios []HorarioFuncionamento, excecoes []ExcecaoHorario, t time.Time) []intervalo {
+	for _, e := range excecoes {
+		if !e.MesmaData(t) {
+			continue
+		}
+		if e.Fechado || e.HoraAbertura == nil || e.HoraFechamento == nil {
+			return nil
+		}
+		if i, ok := novoIntervalo(*e.HoraAbertura, *e.HoraFechamento); ok {
+			return []intervalo{i}
+		}
+		return nil
+	}
+
+	dia := DiaSemanaDe(t)
+	var intervalos []intervalo
+	for _, h := range horarios {
+		if h.DiaSemana != dia {
+			continue
+		}
+		if i, ok := novoIntervalo(h.HoraAbertura, h.HoraFechamento); ok {
+			intervalos = append(intervalos, i)
+		}
+	}
+	return intervalos
+}
+
+// EstaAbertoEm checks if the schedule allows the restaurant to be open at instant t.
+// O instante deve estar no fuso horário do restaurante.
+func EstaAbertoEm(horarios []HorarioFuncionamento, excecoes []ExcecaoHorario, t time.Time) bool {
+	minuto := t.Hour()*60 + t.Minute()
+
+	for _, i := range intervalosDoDia(horarios, excecoes, t) {
+		if i.viraDia() {
+			if minuto >= i.abertura {
+				return true
+			}
+		} else if minuto >= i.abertura && minuto < i.fechamento {
+			return true
+		}
+	}
+
+	// Intervalos do dia anterior que terminam depois da meia-noite
+	for _, i := range intervalosDoDia(horarios, excecoes, t.AddDate(0, 0, -1)) {
+		if i.viraDia() && minuto < i.fechamento {
+			return true
+		}
+	}
+
+	return false
+}
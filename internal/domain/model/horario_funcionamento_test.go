@@ -0,0 +1,80 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEstaAbertoEm(t *testing.T) {
+	hora := func(h string) *string { return &h }
+	// 2026-10-12 é uma segunda-feira
+	em := func(data, hora string) time.Time {
+		instante, err := time.Parse(FormatoData+" 15:04", data+" "+hora)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return instante
+	}
+
+	semana := []HorarioFuncionamento{
+		{DiaSemana: DiaSemanaSegunda, HoraAbertura: "11:00", HoraFechamento: "14:00"},
+		{DiaSemana: DiaSemanaSegunda, HoraAbertura: "18:00", HoraFechamento: "23:00"},
+		{DiaSemana: DiaSemanaSexta, HoraAbertura: "18:00", HoraFechamento: "02:00"},
+		{DiaSemana: DiaSemanaSabado, HoraAbertura: "10:00", HoraFechamento: "24:00"},
+	}
+
+	tests := []struct {
+		name     string
+		excecoes []ExcecaoHorario
+		instante time.Time
+		want     bool
+	}{
+		{name: "dentro do primeiro intervalo", instante: em("2026-10-12", "11:00"), want: true},
+		{name: "fechamento e exclusivo", instante: em("2026-10-12", "14:00"), want: false},
+		{name: "entre intervalos", instante: em("2026-10-12", "16:30"), want: false},
+		{name: "segundo intervalo", instante: em("2026-10-12", "22:59"), want: true},
+		{name: "dia sem horario", instante: em("2026-10-13", "12:00"), want: false},
+		{name: "virada de dia antes da meia-noite", instante: em("2026-10-16", "23:30"), want: true},
+		{name: "virada de dia depois da meia-noite", instante: em("2026-10-17", "01:59"), want: true},
+		{name: "fechamento 24:00 vai ate o fim do dia", instante: em("2026-10-17", "23:59"), want: true},
+		{name: "fechamento 24:00 nao vira o dia", instante: em("2026-10-18", "00:30"), want: false},
+		{
+			name:     "excecao fechada substitui a semana",
+			excecoes: []ExcecaoHorario{{Data: "2026-10-12", Fechado: true}},
+			instante: em("2026-10-12", "12:00"),
+			want:     false,
+		},
+		{
+			name:     "excecao com horario especial",
+			excecoes: []ExcecaoHorario{{Data: "2026-10-12", HoraAbertura: hora("15:00"), HoraFechamento: hora("17:00")}},
+			instante: em("2026-10-12", "16:00"),
+			want:     true,
+		},
+		{
+			name:     "horario especial descarta os intervalos semanais",
+			excecoes: []ExcecaoHorario{{Data: "2026-10-12", HoraAbertura: hora("15:00"), HoraFechamento: hora("17:00")}},
+			instante: em("2026-10-12", "12:00"),
+			want:     false,
+		},
+		{
+			name:     "excecao fechada no dia seguinte nao corta a virada",
+			excecoes: []ExcecaoHorario{{Data: "2026-10-17", Fechado: true}},
+			instante: em("2026-10-17", "01:00"),
+			want:     true,
+		},
+		{
+			name:     "excecao fechada no dia da virada",
+			excecoes: []ExcecaoHorario{{Data: "2026-10-16", Fechado: true}},
+			instante: em("2026-10-17", "01:00"),
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EstaAbertoEm(semana, tt.excecoes, tt.instante); got != tt.want {
+				t.Errorf("EstaAbertoEm(%s) = %v, esperava %v", tt.instante.Format("Mon 15:04"), got, tt.want)
+			}
+		})
+	}
+}
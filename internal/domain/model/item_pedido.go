@@ -4,21 +4,31 @@ import "github.com/shopspring/decimal"
 
 // ItemPedido represents an order item
 type ItemPedido struct {
-	ID            uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
-	PedidoID      uint64          `gorm:"not null" json:"pedidoId"`
-	ProdutoID     uint64          `gorm:"not null" json:"produtoId"`
-	Produto       Produto         `gorm:"foreignKey:ProdutoID" json:"produto,omitempty"`
-	Quantidade    int             `gorm:"not null" json:"quantidade"`
-	PrecoUnitario decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"precoUnitario"`
-	PrecoTotal    decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"precoTotal"`
-	Observacao    string          `gorm:"size:255" json:"observacao"`
+	ID            uint64            `gorm:"primaryKey;autoIncrement" json:"id"`
+	PedidoID      uint64            `gorm:"not null" json:"pedidoId"`
+	ProdutoID     uint64            `gorm:"not null" json:"produtoId"`
+	Produto       Produto           `gorm:"foreignKey:ProdutoID" json:"produto,omitempty"`
+	Quantidade    int               `gorm:"not null" json:"quantidade"`
+	PrecoUnitario decimal.Decimal   `gorm:"type:decimal(10,2);not null" json:"precoUnitario"`
+	PrecoTotal    decimal.Decimal   `gorm:"type:decimal(10,2);not null" json:"precoTotal"`
+	Observacao    string            `gorm:"size:255" json:"observacao"`
+	Opcoes        []ItemPedidoOpcao `gorm:"foreignKey:ItemPedidoID" json:"opcoes,omitempty"`
 }
 
 func (ItemPedido) TableName() string {
 	return "item_pedido"
 }
 
-// CalcularPrecoTotal calculates the total price of this item
+// CalcularPrecoTotal calculates the total price of this item, including the chosen options
 func (i *ItemPedido) CalcularPrecoTotal() {
-	i.PrecoTotal = i.PrecoUnitario.Mul(decimal.NewFromInt(int64(i.Quantidade)))
+	i.PrecoTotal = i.PrecoUnitarioComOpcoes().Mul(decimal.NewFromInt(int64(i.Quantidade)))
+}
+
+// PrecoUnitarioComOpcoes returns the unit price plus the additional price of the chosen options
+func (i *ItemPedido) PrecoUnitarioComOpcoes() decimal.Decimal {
+	preco := i.PrecoUnitario
+	for _, opcao := range i.Opcoes {
+		preco = preco.Add(opcao.PrecoAdicional)
+	}
+	return preco
 }
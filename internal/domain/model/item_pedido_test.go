@@ -0,0 +1,39 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestItemPedidoCalcularPrecoTotal(t *testing.T) {
+	preco := decimal.RequireFromString
+
+	tests := []struct {
+		name       string
+		unitario   string
+		quantidade int
+		adicionais []string
+		want       string
+	}{
+		{name: "sem opcoes", unitario: "25.90", quantidade: 1, want: "25.90"},
+		{name: "quantidade multiplica", unitario: "25.90", quantidade: 3, want: "77.70"},
+		{name: "opcoes somam ao unitario", unitario: "30.00", quantidade: 2, adicionais: []string{"8.50", "4.00"}, want: "85.00"},
+		{name: "opcao gratuita", unitario: "12.00", quantidade: 1, adicionais: []string{"0"}, want: "12.00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := ItemPedido{PrecoUnitario: preco(tt.unitario), Quantidade: tt.quantidade}
+			for _, adicional := range tt.adicionais {
+				item.Opcoes = append(item.Opcoes, ItemPedidoOpcao{PrecoAdicional: preco(adicional)})
+			}
+
+			item.CalcularPrecoTotal()
+
+			if !item.PrecoTotal.Equal(preco(tt.want)) {
+				t.Errorf("PrecoTotal = %s, esperava %s", item.PrecoTotal, tt.want)
+			}
+		})
+	}
+}
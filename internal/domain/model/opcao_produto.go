@@ -0,0 +1,52 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// GrupoOpcoes is a group of options of a product (ex.: tamanho, adicionais).
+// O cliente escolhe entre MinSelecoes e MaxSelecoes opções do grupo; com
+// MinSelecoes zero o grupo é opcional.
+type GrupoOpcoes struct {
+	ID          uint64         `gorm:"primaryKey;autoIncrement" json:"id"`
+	ProdutoID   uint64         `gorm:"not null" json:"produtoId"`
+	Nome        string         `gorm:"size:60;not null" json:"nome"`
+	MinSelecoes int            `gorm:"not null;default:0" json:"minSelecoes"`
+	MaxSelecoes int            `gorm:"not null;default:1" json:"maxSelecoes"`
+	Opcoes      []OpcaoProduto `gorm:"foreignKey:GrupoID" json:"opcoes,omitempty"`
+}
+
+func (GrupoOpcoes) TableName() string {
+	return "produto_grupo_opcao"
+}
+
+// Obrigatorio checks if at least one option must be chosen
+func (g *GrupoOpcoes) Obrigatorio() bool {
+	return g.MinSelecoes > 0
+}
+
+// OpcaoProduto is an option of a group, with the amount added to the product price
+type OpcaoProduto struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	GrupoID        uint64          `gorm:"column:grupo_opcao_id;not null" json:"grupoId"`
+	Nome           string          `gorm:"size:60;not null" json:"nome"`
+	PrecoAdicional decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"precoAdicional"`
+	Ativo          bool            `gorm:"not null" json:"ativo"`
+}
+
+func (OpcaoProduto) TableName() string {
+	return "produto_opcao"
+}
+
+// ItemPedidoOpcao is the snapshot of an option chosen for an order item. Nome, grupo
+// e preço são copiados da opção para que alterações no cardápio não mudem pedidos já feitos.
+type ItemPedidoOpcao struct {
+	ID             uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	ItemPedidoID   uint64          `gorm:"not null" json:"itemPedidoId"`
+	OpcaoID        uint64          `gorm:"not null" json:"opcaoId"`
+	Grupo          string          `gorm:"column:grupo_nome;size:60;not null" json:"grupo"`
+	Nome           string          `gorm:"size:60;not null" json:"nome"`
+	PrecoAdicional decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"precoAdicional"`
+}
+
+func (ItemPedidoOpcao) TableName() string {
+	return "item_pedido_opcao"
+}
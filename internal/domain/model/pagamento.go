@@ -0,0 +1,51 @@
+package model
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// StatusPagamento represents the payment status
+type StatusPagamento string
+
+const (
+	// StatusPagamentoPendente aguarda o resultado pelo webhook do provedor
+	StatusPagamentoPendente   StatusPagamento = "PENDENTE"
+	StatusPagamentoAutorizado StatusPagamento = "AUTORIZADO"
+	StatusPagamentoCapturado  StatusPagamento = "CAPTURADO"
+	StatusPagamentoRecusado   StatusPagamento = "RECUSADO"
+	StatusPagamentoEstornado  StatusPagamento = "ESTORNADO"
+)
+
+// Pagamento represents a payment attempt of an order in the payment provider.
+// Um pedido pode ter várias tentativas, mas só uma ativa (pendente, autorizada ou capturada).
+type Pagamento struct {
+	ID              uint64          `gorm:"primaryKey;autoIncrement" json:"id"`
+	PedidoID        uint64          `gorm:"not null;index" json:"pedidoId"`
+	Provedor        string          `gorm:"size:30;not null" json:"provedor"`
+	TransacaoID     string          `gorm:"size:100;not null" json:"transacaoId"`
+	Valor           decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"valor"`
+	Status          StatusPagamento `gorm:"type:varchar(20);not null" json:"status"`
+	Mensagem        string          `gorm:"size:255" json:"mensagem,omitempty"`
+	DataCriacao     time.Time       `gorm:"autoCreateTime" json:"dataCriacao"`
+	DataAtualizacao time.Time       `gorm:"autoUpdateTime" json:"dataAtualizacao"`
+	DataCaptura     *time.Time      `json:"dataCaptura,omitempty"`
+	DataEstorno     *time.Time      `json:"dataEstorno,omitempty"`
+
+	// Pedido é carregado apenas nas consultas por transação (webhooks)
+	Pedido *Pedido `gorm:"foreignKey:PedidoID" json:"-"`
+}
+
+func (Pagamento) TableName() string {
+	return "pagamento"
+}
+
+// Ativo checks if the payment still holds (or may still hold) the order amount
+func (p *Pagamento) Ativo() bool {
+	switch p.Status {
+	case StatusPagamentoPendente, StatusPagamentoAutorizado, StatusPagamentoCapturado:
+		return true
+	}
+	return false
+}
@@ -13,10 +13,17 @@ type Pedido struct {
 	Codigo           string          `gorm:"size:36;uniqueIndex;not null" json:"codigo"`
 	Subtotal         decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"subtotal"`
 	TaxaFrete        decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"taxaFrete"`
+	RegraFrete       string          `gorm:"size:20" json:"regraFrete,omitempty"`
+	DescricaoFrete   string          `gorm:"size:255" json:"descricaoFrete,omitempty"`
+	Desconto         decimal.Decimal `gorm:"type:decimal(10,2);not null;default:0" json:"desconto"`
+	CupomID          *uint64         `json:"cupomId,omitempty"`
+	CodigoCupom      string          `gorm:"size:30;not null;default:''" json:"codigoCupom,omitempty"`
 	ValorTotal       decimal.Decimal `gorm:"type:decimal(10,2);not null" json:"valorTotal"`
-	Status           StatusPedido    `gorm:"type:varchar(15);not null;default:'CRIADO'" json:"status"`
+	Status           StatusPedido    `gorm:"type:varchar(20);not null;default:'CRIADO'" json:"status"`
 	DataCriacao      time.Time       `gorm:"autoCreateTime" json:"dataCriacao"`
 	DataConfirmacao  *time.Time      `json:"dataConfirmacao,omitempty"`
+	DataPreparacao   *time.Time      `json:"dataPreparacao,omitempty"`
+	DataSaidaEntrega *time.Time      `json:"dataSaidaEntrega,omitempty"`
 	DataCancelamento *time.Time      `json:"dataCancelamento,omitempty"`
 	DataEntrega      *time.Time      `json:"dataEntrega,omitempty"`
 
@@ -30,6 +37,8 @@ type Pedido struct {
 
 	// Embedded address
 	EnderecoEntrega EnderecoEntrega `gorm:"embedded" json:"enderecoEntrega,omitempty"`
+	// EnderecoSalvoID indica o endereço salvo do cliente copiado na emissão (não persistido)
+	EnderecoSalvoID *uint64 `gorm:"-" json:"-"`
 
 	// Items
 	Itens []ItemPedido `gorm:"foreignKey:PedidoID" json:"itens,omitempty"`
@@ -48,6 +57,9 @@ type EnderecoEntrega struct {
 	Bairro      string `gorm:"column:endereco_bairro;size:60" json:"bairro"`
 	CidadeID    uint64 `gorm:"column:endereco_cidade_id" json:"cidadeId"`
 	Cidade      Cidade `gorm:"foreignKey:CidadeID" json:"cidade,omitempty"`
+	// Coordenadas opcionais, necessárias para o frete por distância
+	Latitude  *float64 `gorm:"column:endereco_latitude;type:decimal(10,7)" json:"latitude,omitempty"`
+	Longitude *float64 `gorm:"column:endereco_longitude;type:decimal(10,7)" json:"longitude,omitempty"`
 }
 
 // BeforeCreate generates a UUID for the order code
@@ -58,19 +70,28 @@ func (p *Pedido) BeforeCreate() {
 	p.Status = StatusPedidoCriado
 }
 
-// CalcularValorTotal calculates the total order value
+// CalcularValorTotal calculates the total order value (subtotal + frete - desconto)
 func (p *Pedido) CalcularValorTotal() {
 	p.Subtotal = decimal.Zero
 	for _, item := range p.Itens {
 		item.CalcularPrecoTotal()
 		p.Subtotal = p.Subtotal.Add(item.PrecoTotal)
 	}
-	p.ValorTotal = p.Subtotal.Add(p.TaxaFrete)
+	p.ValorTotal = p.Subtotal.Add(p.TaxaFrete).Sub(p.Desconto)
 }
 
-// DefinirFrete sets the freight rate
-func (p *Pedido) DefinirFrete(taxaFrete decimal.Decimal) {
-	p.TaxaFrete = taxaFrete
+// DefinirFrete sets the freight rate and records the rule that produced it
+func (p *Pedido) DefinirFrete(cotacao *CotacaoFrete) {
+	p.TaxaFrete = cotacao.Taxa
+	p.RegraFrete = cotacao.Regra
+	p.DescricaoFrete = cotacao.Descricao
+}
+
+// AplicarCupom sets the coupon discount, calculated over the current subtotal and freight
+func (p *Pedido) AplicarCupom(cupom *Cupom) {
+	p.CupomID = &cupom.ID
+	p.CodigoCupom = cupom.Codigo
+	p.Desconto = cupom.CalcularDesconto(p.Subtotal, p.TaxaFrete)
 }
 
 // AtribuirPedidoAosItens associates this order to all items
@@ -91,6 +112,28 @@ func (p *Pedido) Confirmar() error {
 	return nil
 }
 
+// IniciarPreparacao marks the order as being prepared by the kitchen
+func (p *Pedido) IniciarPreparacao() error {
+	if !p.Status.CanTransitionTo(StatusPedidoPreparando) {
+		return newStatusChangeError(p.Status, StatusPedidoPreparando)
+	}
+	p.Status = StatusPedidoPreparando
+	now := time.Now()
+	p.DataPreparacao = &now
+	return nil
+}
+
+// SairParaEntrega marks the order as out for delivery
+func (p *Pedido) SairParaEntrega() error {
+	if !p.Status.CanTransitionTo(StatusPedidoSaiuParaEntrega) {
+		return newStatusChangeError(p.Status, StatusPedidoSaiuParaEntrega)
+	}
+	p.Status = StatusPedidoSaiuParaEntrega
+	now := time.Now()
+	p.DataSaidaEntrega = &now
+	return nil
+}
+
 // Entregar marks the order as delivered
 func (p *Pedido) Entregar() error {
 	if !p.Status.CanTransitionTo(StatusPedidoEntregue) {
@@ -135,6 +178,16 @@ func (p *Pedido) PodeSerConfirmado() bool {
 	return p.Status.CanTransitionTo(StatusPedidoConfirmado)
 }
 
+// PodeSerPreparado checks if order preparation can start
+func (p *Pedido) PodeSerPreparado() bool {
+	return p.Status.CanTransitionTo(StatusPedidoPreparando)
+}
+
+// PodeSairParaEntrega checks if order can leave for delivery
+func (p *Pedido) PodeSairParaEntrega() bool {
+	return p.Status.CanTransitionTo(StatusPedidoSaiuParaEntrega)
+}
+
 // PodeSerEntregue checks if order can be delivered
 func (p *Pedido) PodeSerEntregue() bool {
 	return p.Status.CanTransitionTo(StatusPedidoEntregue)
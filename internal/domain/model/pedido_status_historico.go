@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// PedidoStatusHistorico records a status transition of an order (audit trail)
+type PedidoStatusHistorico struct {
+	ID             uint64        `gorm:"primaryKey;autoIncrement" json:"id"`
+	PedidoID       uint64        `gorm:"not null" json:"pedidoId"`
+	StatusAnterior *StatusPedido `gorm:"type:varchar(20)" json:"statusAnterior,omitempty"`
+	StatusNovo     StatusPedido  `gorm:"type:varchar(20);not null" json:"statusNovo"`
+	UsuarioID      *uint64       `json:"usuarioId,omitempty"`
+	Usuario        *Usuario      `gorm:"foreignKey:UsuarioID" json:"usuario,omitempty"`
+	Motivo         *string       `gorm:"size:255" json:"motivo,omitempty"`
+	DataAlteracao  time.Time     `gorm:"not null" json:"dataAlteracao"`
+}
+
+func (PedidoStatusHistorico) TableName() string {
+	return "pedido_status_historico"
+}
+
+// NewPedidoStatusHistorico registra a mudança do status anterior para o status atual do pedido.
+// anterior é nil na emissão; usuarioID 0 indica uma alteração feita pelo sistema.
+func NewPedidoStatusHistorico(pedido *Pedido, anterior *StatusPedido, usuarioID uint64, motivo string) *PedidoStatusHistorico {
+	historico := &PedidoStatusHistorico{
+		PedidoID:       pedido.ID,
+		StatusAnterior: anterior,
+		StatusNovo:     pedido.Status,
+		DataAlteracao:  time.Now(),
+	}
+	if usuarioID > 0 {
+		historico.UsuarioID = &usuarioID
+	}
+	if motivo != "" {
+		historico.Motivo = &motivo
+	}
+	return historico
+}
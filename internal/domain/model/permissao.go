@@ -10,3 +10,26 @@ type Permissao struct {
 func (Permissao) TableName() string {
 	return "permissao"
 }
+
+// Nomes das permissoes cadastradas na tabela permissao
+const (
+	PermissaoConsultarCozinhas                 = "CONSULTAR_COZINHAS"
+	PermissaoEditarCozinhas                    = "EDITAR_COZINHAS"
+	PermissaoConsultarFormasPagamento          = "CONSULTAR_FORMAS_PAGAMENTO"
+	PermissaoEditarFormasPagamento             = "EDITAR_FORMAS_PAGAMENTO"
+	PermissaoConsultarCidades                  = "CONSULTAR_CIDADES"
+	PermissaoEditarCidades                     = "EDITAR_CIDADES"
+	PermissaoConsultarEstados                  = "CONSULTAR_ESTADOS"
+	PermissaoEditarEstados                     = "EDITAR_ESTADOS"
+	PermissaoConsultarUsuariosGruposPermissoes = "CONSULTAR_USUARIOS_GRUPOS_PERMISSOES"
+	PermissaoEditarUsuariosGruposPermissoes    = "EDITAR_USUARIOS_GRUPOS_PERMISSOES"
+	PermissaoConsultarRestaurantes             = "CONSULTAR_RESTAURANTES"
+	PermissaoEditarRestaurantes                = "EDITAR_RESTAURANTES"
+	PermissaoConsultarProdutos                 = "CONSULTAR_PRODUTOS"
+	PermissaoEditarProdutos                    = "EDITAR_PRODUTOS"
+	PermissaoConsultarPedidos                  = "CONSULTAR_PEDIDOS"
+	PermissaoGerenciarPedidos                  = "GERENCIAR_PEDIDOS"
+	PermissaoGerarRelatorios                   = "GERAR_RELATORIOS"
+	PermissaoEditarCupons                      = "EDITAR_CUPONS"
+	PermissaoGerenciarEventosOutbox            = "GERENCIAR_EVENTOS_OUTBOX"
+)
@@ -0,0 +1,36 @@
+package model
+
+import "time"
+
+// RefreshToken is a rotating refresh token. Only the SHA-256 hash of the token is stored.
+// Tokens issued from the same login share a Familia; reusing an already rotated token
+// revokes the whole family.
+type RefreshToken struct {
+	ID            uint64     `gorm:"primaryKey;autoIncrement" json:"id"`
+	Familia       string     `gorm:"size:36;not null;index" json:"familia"`
+	UsuarioID     uint64     `gorm:"not null" json:"usuarioId"`
+	TokenHash     string     `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	DataCriacao   time.Time  `gorm:"autoCreateTime" json:"dataCriacao"`
+	DataExpiracao time.Time  `gorm:"not null" json:"dataExpiracao"`
+	DataUso       *time.Time `json:"dataUso,omitempty"`
+	DataRevogacao *time.Time `json:"dataRevogacao,omitempty"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_token"
+}
+
+// Expirado checks if the token is past its expiration
+func (t *RefreshToken) Expirado(agora time.Time) bool {
+	return !agora.Before(t.DataExpiracao)
+}
+
+// Usado checks if the token was already exchanged for a new one
+func (t *RefreshToken) Usado() bool {
+	return t.DataUso != nil
+}
+
+// Revogado checks if the token family was revoked (logout or reuse detected)
+func (t *RefreshToken) Revogado() bool {
+	return t.DataRevogacao != nil
+}
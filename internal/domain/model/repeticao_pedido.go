@@ -0,0 +1,29 @@
+package model
+
+import "github.com/shopspring/decimal"
+
+// RepeticaoPedido é o resultado de repetir um pedido: o novo pedido, emitido ou apenas
+// simulado, e o que mudou em relação ao original
+type RepeticaoPedido struct {
+	Original       *Pedido
+	Pedido         *Pedido
+	Emitido        bool
+	ItensAlterados []ItemRepeticaoAlterado
+	ItensRemovidos []ItemRepeticaoRemovido
+}
+
+// ItemRepeticaoAlterado registra um item cujo preço unitário (com as opções) mudou
+type ItemRepeticaoAlterado struct {
+	ProdutoID     uint64
+	Nome          string
+	PrecoAnterior decimal.Decimal
+	PrecoAtual    decimal.Decimal
+}
+
+// ItemRepeticaoRemovido registra um item do pedido original que não pôde ser repetido
+type ItemRepeticaoRemovido struct {
+	ProdutoID  uint64
+	Nome       string
+	Quantidade int
+	Motivo     string
+}
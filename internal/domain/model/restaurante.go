@@ -8,19 +8,28 @@ import (
 
 // Restaurante represents a restaurant
 type Restaurante struct {
-	ID              uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
-	Nome            string           `gorm:"size:80;not null" json:"nome"`
-	TaxaFrete       decimal.Decimal  `gorm:"type:decimal(10,2);not null" json:"taxaFrete"`
-	CozinhaID       uint64           `gorm:"not null" json:"cozinhaId"`
-	Cozinha         Cozinha          `gorm:"foreignKey:CozinhaID" json:"cozinha,omitempty"`
-	Endereco        Endereco         `gorm:"embedded" json:"endereco,omitempty"`
-	Ativo           bool             `gorm:"default:true" json:"ativo"`
-	Aberto          bool             `gorm:"default:false" json:"aberto"`
-	DataCadastro    time.Time        `gorm:"autoCreateTime" json:"dataCadastro"`
-	DataAtualizacao time.Time        `gorm:"autoUpdateTime" json:"dataAtualizacao"`
-	FormasPagamento []FormaPagamento `gorm:"many2many:restaurante_forma_pagamento;" json:"formasPagamento,omitempty"`
-	Responsaveis    []Usuario        `gorm:"many2many:restaurante_usuario_responsavel;" json:"responsaveis,omitempty"`
-	Produtos        []Produto        `gorm:"foreignKey:RestauranteID" json:"produtos,omitempty"`
+	ID                uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Nome              string           `gorm:"size:80;not null" json:"nome"`
+	TaxaFrete         decimal.Decimal  `gorm:"type:decimal(10,2);not null" json:"taxaFrete"`
+	ValorMinimoPedido decimal.Decimal  `gorm:"type:decimal(10,2);not null;default:0" json:"valorMinimoPedido"`
+	CozinhaID         uint64           `gorm:"not null" json:"cozinhaId"`
+	Cozinha           Cozinha          `gorm:"foreignKey:CozinhaID" json:"cozinha,omitempty"`
+	Endereco          Endereco         `gorm:"embedded" json:"endereco,omitempty"`
+	Ativo             bool             `gorm:"default:true" json:"ativo"`
+	Aberto            bool             `gorm:"default:false" json:"aberto"`
+	FusoHorario       string           `gorm:"size:60;not null;default:America/Sao_Paulo" json:"fusoHorario"`
+	DataCadastro      time.Time        `gorm:"autoCreateTime" json:"dataCadastro"`
+	DataAtualizacao   time.Time        `gorm:"autoUpdateTime" json:"dataAtualizacao"`
+	FormasPagamento   []FormaPagamento `gorm:"many2many:restaurante_forma_pagamento;" json:"formasPagamento,omitempty"`
+	Responsaveis      []Usuario        `gorm:"many2many:restaurante_usuario_responsavel;" json:"responsaveis,omitempty"`
+	Produtos          []Produto        `gorm:"foreignKey:RestauranteID" json:"produtos,omitempty"`
+
+	// Média e total das avaliações, recalculados a cada nova avaliação (somente leitura)
+	AvaliacaoMedia  decimal.Decimal `gorm:"column:avaliacao_media;type:decimal(3,2);->" json:"avaliacaoMedia"`
+	TotalAvaliacoes int64           `gorm:"column:total_avaliacoes;->" json:"totalAvaliacoes"`
+
+	// DistanciaKm é calculada apenas nas buscas por localização (somente leitura)
+	DistanciaKm *float64 `gorm:"column:distancia_km;->;-:migration" json:"distanciaKm,omitempty"`
 }
 
 func (Restaurante) TableName() string {
@@ -47,6 +56,15 @@ func (r *Restaurante) Fechar() {
 	r.Aberto = false
 }
 
+// Localizacao returns the restaurant's time zone, falling back to FusoHorarioPadrao
+func (r *Restaurante) Localizacao() (*time.Location, error) {
+	fusoHorario := r.FusoHorario
+	if fusoHorario == "" {
+		fusoHorario = FusoHorarioPadrao
+	}
+	return time.LoadLocation(fusoHorario)
+}
+
 // AdicionarFormaPagamento adds a payment method
 func (r *Restaurante) AdicionarFormaPagamento(formaPagamento FormaPagamento) {
 	r.FormasPagamento = append(r.FormasPagamento, formaPagamento)
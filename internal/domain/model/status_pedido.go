@@ -4,17 +4,45 @@ package model
 type StatusPedido string
 
 const (
-	StatusPedidoCriado     StatusPedido = "CRIADO"
-	StatusPedidoConfirmado StatusPedido = "CONFIRMADO"
-	StatusPedidoEntregue   StatusPedido = "ENTREGUE"
-	StatusPedidoCancelado  StatusPedido = "CANCELADO"
+	StatusPedidoCriado          StatusPedido = "CRIADO"
+	StatusPedidoConfirmado      StatusPedido = "CONFIRMADO"
+	StatusPedidoPreparando      StatusPedido = "PREPARANDO"
+	StatusPedidoSaiuParaEntrega StatusPedido = "SAIU_PARA_ENTREGA"
+	StatusPedidoEntregue        StatusPedido = "ENTREGUE"
+	StatusPedidoCancelado       StatusPedido = "CANCELADO"
 )
 
+// Finalizado checks if no further status changes are possible
+func (s StatusPedido) Finalizado() bool {
+	return s == StatusPedidoEntregue || s == StatusPedidoCancelado
+}
+
+// Legado retorna o status como era exposto antes das etapas PREPARANDO e SAIU_PARA_ENTREGA,
+// que continuam aparecendo como CONFIRMADO para clientes que só conhecem os status originais
+func (s StatusPedido) Legado() StatusPedido {
+	if s == StatusPedidoPreparando || s == StatusPedidoSaiuParaEntrega {
+		return StatusPedidoConfirmado
+	}
+	return s
+}
+
+// Detalhados retorna os status que aparecem como s na visão legada
+func (s StatusPedido) Detalhados() []StatusPedido {
+	if s == StatusPedidoConfirmado {
+		return []StatusPedido{StatusPedidoConfirmado, StatusPedidoPreparando, StatusPedidoSaiuParaEntrega}
+	}
+	return []StatusPedido{s}
+}
+
 // CanTransitionTo checks if the current status can transition to the target status
 func (s StatusPedido) CanTransitionTo(target StatusPedido) bool {
+	// As etapas intermediárias são opcionais: clientes que só conhecem
+	// CRIADO/CONFIRMADO/ENTREGUE/CANCELADO continuam podendo entregar e cancelar
 	transitions := map[StatusPedido][]StatusPedido{
-		StatusPedidoCriado:     {StatusPedidoConfirmado, StatusPedidoCancelado},
-		StatusPedidoConfirmado: {StatusPedidoEntregue, StatusPedidoCancelado},
+		StatusPedidoCriado:          {StatusPedidoConfirmado, StatusPedidoCancelado},
+		StatusPedidoConfirmado:      {StatusPedidoPreparando, StatusPedidoSaiuParaEntrega, StatusPedidoEntregue, StatusPedidoCancelado},
+		StatusPedidoPreparando:      {StatusPedidoSaiuParaEntrega, StatusPedidoEntregue, StatusPedidoCancelado},
+		StatusPedidoSaiuParaEntrega: {StatusPedidoEntregue},
 	}
 
 	allowedTargets, exists := transitions[s]
@@ -33,10 +61,12 @@ func (s StatusPedido) CanTransitionTo(target StatusPedido) bool {
 // GetDescription returns the Portuguese description of the status
 func (s StatusPedido) GetDescription() string {
 	descriptions := map[StatusPedido]string{
-		StatusPedidoCriado:     "Criado",
-		StatusPedidoConfirmado: "Confirmado",
-		StatusPedidoEntregue:   "Entregue",
-		StatusPedidoCancelado:  "Cancelado",
+		StatusPedidoCriado:          "Criado",
+		StatusPedidoConfirmado:      "Confirmado",
+		StatusPedidoPreparando:      "Em preparo",
+		StatusPedidoSaiuParaEntrega: "Saiu para entrega",
+		StatusPedidoEntregue:        "Entregue",
+		StatusPedidoCancelado:       "Cancelado",
 	}
 	return descriptions[s]
 }
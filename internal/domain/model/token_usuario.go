@@ -0,0 +1,37 @@
+package model
+
+import "time"
+
+// TipoTokenUsuario identifica a finalidade de um TokenUsuario
+type TipoTokenUsuario string
+
+const (
+	TipoTokenRedefinicaoSenha TipoTokenUsuario = "REDEFINICAO_SENHA"
+	TipoTokenVerificacaoEmail TipoTokenUsuario = "VERIFICACAO_EMAIL"
+)
+
+// TokenUsuario is a single-use, time-limited token sent to the user by e-mail
+// (password reset or e-mail verification). Only the SHA-256 hash of the token is stored.
+type TokenUsuario struct {
+	ID            uint64           `gorm:"primaryKey;autoIncrement" json:"id"`
+	Tipo          TipoTokenUsuario `gorm:"size:20;not null" json:"tipo"`
+	UsuarioID     uint64           `gorm:"not null" json:"usuarioId"`
+	TokenHash     string           `gorm:"size:64;not null;uniqueIndex" json:"-"`
+	DataCriacao   time.Time        `gorm:"autoCreateTime" json:"dataCriacao"`
+	DataExpiracao time.Time        `gorm:"not null" json:"dataExpiracao"`
+	DataUso       *time.Time       `json:"dataUso,omitempty"`
+}
+
+func (TokenUsuario) TableName() string {
+	return "usuario_token"
+}
+
+// Expirado checks if the token is past its expiration
+func (t *TokenUsuario) Expirado(agora time.Time) bool {
+	return !agora.Before(t.DataExpiracao)
+}
+
+// Usado checks if the token was already consumed or invalidated
+func (t *TokenUsuario) Usado() bool {
+	return t.DataUso != nil
+}
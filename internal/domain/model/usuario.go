@@ -10,6 +10,9 @@ type Usuario struct {
 	Senha        string    `gorm:"size:255;not null" json:"-"`
 	DataCadastro time.Time `gorm:"autoCreateTime" json:"dataCadastro"`
 	Grupos       []Grupo   `gorm:"many2many:usuario_grupo;" json:"grupos,omitempty"`
+
+	EmailVerificado      bool       `gorm:"not null;default:false" json:"emailVerificado"`
+	DataVerificacaoEmail *time.Time `json:"dataVerificacaoEmail,omitempty"`
 }
 
 func (Usuario) TableName() string {
@@ -31,6 +34,12 @@ func (u *Usuario) RemoverGrupo(grupo Grupo) {
 	}
 }
 
+// VerificarEmail marks the user's e-mail as confirmed
+func (u *Usuario) VerificarEmail(data time.Time) {
+	u.EmailVerificado = true
+	u.DataVerificacaoEmail = &data
+}
+
 // SenhaCoincideCom checks if the provided password matches the user's password
 // Note: In production, this should use bcrypt.CompareHashAndPassword
 func (u *Usuario) SenhaCoincideCom(senha string) bool {
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Códigos de erro do MySQL tratados pelos serviços
+const (
+	mysqlChaveDuplicada   = 1062
+	mysqlRegistroReferido = 1451
+)
+
+// ChaveDuplicada indica a violação de uma chave única. Com indice informado, só
+// considera a violação daquela chave.
+func ChaveDuplicada(err error, indice string) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) || mysqlErr.Number != mysqlChaveDuplicada {
+		return false
+	}
+	return indice == "" || strings.Contains(mysqlErr.Message, indice)
+}
+
+// RegistroEmUso indica que o registro não pode ser removido por ser referenciado
+// por uma chave estrangeira
+func RegistroEmUso(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlRegistroReferido
+}
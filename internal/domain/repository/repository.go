@@ -1,6 +1,10 @@
 package repository
 
 import (
+	"context"
+	"time"
+
+	"github.com/shopspring/decimal"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/pkg/pagination"
 )
@@ -15,7 +19,7 @@ type EstadoRepository interface {
 
 // CidadeRepository interface for cidade operations
 type CidadeRepository interface {
-	FindAll() ([]model.Cidade, error)
+	FindAll(page *pagination.Pageable) (*pagination.Page[model.Cidade], error)
 	FindByID(id uint64) (*model.Cidade, error)
 	Save(cidade *model.Cidade) error
 	Delete(id uint64) error
@@ -55,17 +59,20 @@ type GrupoRepository interface {
 
 // UsuarioRepository interface for usuario operations
 type UsuarioRepository interface {
-	FindAll() ([]model.Usuario, error)
+	FindAll(page *pagination.Pageable) (*pagination.Page[model.Usuario], error)
 	FindByID(id uint64) (*model.Usuario, error)
 	FindByEmail(email string) (*model.Usuario, error)
 	Save(usuario *model.Usuario) error
+	// SaveConsumindoToken grava o usuário e marca o token como usado na mesma transação.
+	// Retorna false, sem gravar nada, se o token já havia sido usado.
+	SaveConsumindoToken(usuario *model.Usuario, tokenID uint64, data time.Time) (bool, error)
 	AddGrupo(usuarioID, grupoID uint64) error
 	RemoveGrupo(usuarioID, grupoID uint64) error
 }
 
 // RestauranteRepository interface for restaurante operations
 type RestauranteRepository interface {
-	FindAll() ([]model.Restaurante, error)
+	FindAll(filter *RestauranteFilter, page *pagination.Pageable) (*pagination.Page[model.Restaurante], error)
 	FindByID(id uint64) (*model.Restaurante, error)
 	Save(restaurante *model.Restaurante) error
 	AddFormaPagamento(restauranteID, formaPagamentoID uint64) error
@@ -73,15 +80,60 @@ type RestauranteRepository interface {
 	AddResponsavel(restauranteID, usuarioID uint64) error
 	RemoveResponsavel(restauranteID, usuarioID uint64) error
 	ExistsResponsavel(restauranteID, usuarioID uint64) (bool, error)
+	FindCidadesEntrega(restauranteID uint64) ([]model.Cidade, error)
+	AddCidadeEntrega(restauranteID, cidadeID uint64) error
+	RemoveCidadeEntrega(restauranteID, cidadeID uint64) error
+	// AplicarAgenda abre ou fecha o restaurante somente se a situação prevista pela agenda
+	// mudou desde a última aplicada; retorna false quando não havia fronteira a aplicar
+	AplicarAgenda(restauranteID uint64, aberto bool) (bool, error)
+	// LimparAgenda esquece a situação aplicada dos restaurantes inativos ou fora da lista
+	LimparAgenda(restauranteIDs []uint64) error
+}
+
+// HorarioFuncionamentoRepository interface for restaurante_horario_funcionamento operations
+type HorarioFuncionamentoRepository interface {
+	FindAllByRestaurante(restauranteID uint64) ([]model.HorarioFuncionamento, error)
+	FindByID(restauranteID, horarioID uint64) (*model.HorarioFuncionamento, error)
+	Save(horario *model.HorarioFuncionamento) error
+	Delete(horarioID uint64) error
+	// FindRestauranteIDs retorna os restaurantes que possuem horário de funcionamento cadastrado
+	FindRestauranteIDs() ([]uint64, error)
+	// ComTravaAgenda executa fn somente se nenhuma outra instância estiver sincronizando a agenda
+	ComTravaAgenda(ctx context.Context, fn func() error) (bool, error)
+}
+
+// ExcecaoHorarioRepository interface for restaurante_excecao_horario operations
+type ExcecaoHorarioRepository interface {
+	FindAllByRestaurante(restauranteID uint64) ([]model.ExcecaoHorario, error)
+	FindByID(restauranteID, excecaoID uint64) (*model.ExcecaoHorario, error)
+	Save(excecao *model.ExcecaoHorario) error
+	Delete(excecaoID uint64) error
+}
+
+// FreteRepository interface for restaurante_frete operations
+type FreteRepository interface {
+	// FindConfiguracao retorna a configuração com localidades e faixas (gorm.ErrRecordNotFound se não houver)
+	FindConfiguracao(restauranteID uint64) (*model.ConfiguracaoFrete, error)
+	// SaveConfiguracao grava a configuração substituindo localidades e faixas
+	SaveConfiguracao(configuracao *model.ConfiguracaoFrete) error
 }
 
 // ProdutoRepository interface for produto operations
 type ProdutoRepository interface {
-	FindAllByRestaurante(restauranteID uint64, incluirInativos bool) ([]model.Produto, error)
+	FindAllByRestaurante(restauranteID uint64, incluirInativos bool, page *pagination.Pageable) (*pagination.Page[model.Produto], error)
 	FindByID(restauranteID, produtoID uint64) (*model.Produto, error)
 	Save(produto *model.Produto) error
 }
 
+// GrupoOpcoesRepository interface for the option groups of a product. Os grupos são
+// carregados e gravados com suas opções.
+type GrupoOpcoesRepository interface {
+	FindAllByProduto(produtoID uint64) ([]model.GrupoOpcoes, error)
+	FindByID(produtoID, grupoID uint64) (*model.GrupoOpcoes, error)
+	Save(grupo *model.GrupoOpcoes) error
+	Delete(grupoID uint64) error
+}
+
 // FotoProdutoRepository interface for foto_produto operations
 type FotoProdutoRepository interface {
 	FindByProdutoID(produtoID uint64) (*model.FotoProduto, error)
@@ -95,22 +147,142 @@ type PedidoFilter struct {
 	RestauranteID     *uint64
 	DataCriacaoInicio *string
 	DataCriacaoFim    *string
-	Status            *model.StatusPedido
+	// Status usa a visão legada (CONFIRMADO inclui PREPARANDO e SAIU_PARA_ENTREGA)
+	Status          *model.StatusPedido
+	StatusDetalhado *model.StatusPedido
+}
+
+// RestauranteFilter filtra a pesquisa de restaurantes. CidadeID seleciona os restaurantes
+// que entregam na cidade. Com Latitude e Longitude, apenas restaurantes com coordenadas
+// a até RaioKm são retornados, com a distância calculada.
+type RestauranteFilter struct {
+	Nome         *string
+	CozinhaID    *uint64
+	CidadeID     *uint64
+	Aberto       *bool
+	Ativo        *bool
+	TaxaFreteMin *decimal.Decimal
+	TaxaFreteMax *decimal.Decimal
+	FreteGratis  *bool
+	Latitude     *float64
+	Longitude    *float64
+	RaioKm       float64
 }
 
 // PedidoRepository interface for pedido operations
 type PedidoRepository interface {
 	FindAll(filter *PedidoFilter, page *pagination.Pageable) (*pagination.Page[model.Pedido], error)
+	FindAllByCursor(filter *PedidoFilter, page *pagination.Pageable) (*pagination.CursorPage[model.Pedido], error)
 	FindByCodigo(codigo string) (*model.Pedido, error)
 	Save(pedido *model.Pedido) error
+	// SaveComHistorico salva o pedido e registra a mudança de status na mesma transação
+	SaveComHistorico(pedido *model.Pedido, historico *model.PedidoStatusHistorico) error
+	// SaveComCupom salva o pedido como SaveComHistorico e registra o uso do cupom na mesma transação.
+	// Retorna false, sem gravar nada, se o cupom já atingiu o limite de usos (total ou do cliente).
+	SaveComCupom(pedido *model.Pedido, historico *model.PedidoStatusHistorico, cupom *model.Cupom) (bool, error)
+	// SaveComEvento grava a mudança de status do pedido, o histórico e o evento no outbox na mesma
+	// transação. Só grava se o status no banco ainda é o StatusAnterior do histórico; caso contrário
+	// retorna false sem gravar nada.
+	SaveComEvento(pedido *model.Pedido, historico *model.PedidoStatusHistorico, evento *model.EventoOutbox) (bool, error)
+	FindHistorico(pedidoID uint64) ([]model.PedidoStatusHistorico, error)
 	IsPedidoGerenciadoPor(codigoPedido string, usuarioID uint64) (bool, error)
 }
 
+// CupomFilter for filtering coupons
+type CupomFilter struct {
+	RestauranteID *uint64
+	Ativo         *bool
+}
+
+// CupomRepository interface for cupom operations. As consultas preenchem TotalUsos.
+type CupomRepository interface {
+	FindAll(filter *CupomFilter, page *pagination.Pageable) (*pagination.Page[model.Cupom], error)
+	FindByID(id uint64) (*model.Cupom, error)
+	FindByCodigo(codigo string) (*model.Cupom, error)
+	Save(cupom *model.Cupom) error
+	Delete(id uint64) error
+	// ContarUsosCliente conta os pedidos não cancelados do cliente com o cupom
+	ContarUsosCliente(cupomID, clienteID uint64) (int64, error)
+}
+
+// PagamentoRepository interface for pagamento operations
+type PagamentoRepository interface {
+	// FindUltimoByPedido retorna a tentativa de pagamento mais recente do pedido
+	FindUltimoByPedido(pedidoID uint64) (*model.Pagamento, error)
+	FindByTransacao(provedor, transacaoID string) (*model.Pagamento, error)
+	// FindCapturadosDePedidosCancelados retorna pagamentos capturados de pedidos já cancelados,
+	// ou seja, estornos que falharam ou ainda não foram feitos
+	FindCapturadosDePedidosCancelados(limite int) ([]model.Pagamento, error)
+	Save(pagamento *model.Pagamento) error
+}
+
+// AvaliacaoRepository interface for avaliacao operations
+type AvaliacaoRepository interface {
+	FindAllByRestaurante(restauranteID uint64, page *pagination.Pageable) (*pagination.Page[model.Avaliacao], error)
+	FindByID(restauranteID, avaliacaoID uint64) (*model.Avaliacao, error)
+	FindByPedido(pedidoID uint64) (*model.Avaliacao, error)
+	// Adicionar grava a avaliação e recalcula a média e o total do restaurante na mesma transação
+	Adicionar(avaliacao *model.Avaliacao) error
+	Save(avaliacao *model.Avaliacao) error
+}
+
+// EnderecoUsuarioRepository interface for the addresses saved by the customers
+type EnderecoUsuarioRepository interface {
+	FindAllByUsuario(usuarioID uint64) ([]model.EnderecoUsuario, error)
+	FindByID(usuarioID, enderecoID uint64) (*model.EnderecoUsuario, error)
+	FindPadrao(usuarioID uint64) (*model.EnderecoUsuario, error)
+	// Save grava o endereço; se for o padrão, desmarca os demais do usuário na mesma transação
+	Save(endereco *model.EnderecoUsuario) error
+	// Delete remove o endereço e, se era o padrão, promove o mais antigo restante
+	Delete(usuarioID, enderecoID uint64) error
+}
+
+// FavoritoRepository interface for the customers' favorite restaurants and products
+type FavoritoRepository interface {
+	FindRestaurantes(usuarioID uint64) ([]model.Restaurante, error)
+	FindProdutos(usuarioID uint64) ([]model.Produto, error)
+	AdicionarRestaurante(usuarioID, restauranteID uint64) error
+	RemoverRestaurante(usuarioID, restauranteID uint64) error
+	AdicionarProduto(usuarioID, produtoID uint64) error
+	RemoverProduto(usuarioID, produtoID uint64) error
+}
+
+// EventoOutboxRepository interface for evento_outbox operations
+type EventoOutboxRepository interface {
+	FindAll(status *model.StatusEventoOutbox, page *pagination.Pageable) (*pagination.Page[model.EventoOutbox], error)
+	FindByID(id uint64) (*model.EventoOutbox, error)
+	// ReservarPendentes reserva um lote para publicação, sem disputar com outras instâncias
+	ReservarPendentes(limite int, reserva time.Duration) ([]model.EventoOutbox, error)
+	Save(evento *model.EventoOutbox) error
+}
+
+// RefreshTokenRepository interface for refresh_token operations
+type RefreshTokenRepository interface {
+	FindByHash(tokenHash string) (*model.RefreshToken, error)
+	Save(token *model.RefreshToken) error
+	// MarcarUsado retorna false se o token já havia sido usado ou revogado
+	MarcarUsado(id uint64, data time.Time) (bool, error)
+	RevogarFamilia(familia string, data time.Time) error
+	RevogarPorUsuario(usuarioID uint64, data time.Time) error
+}
+
+// TokenUsuarioRepository interface for usuario_token operations
+type TokenUsuarioRepository interface {
+	FindByHash(tokenHash string) (*model.TokenUsuario, error)
+	Save(token *model.TokenUsuario) error
+	// MarcarUsado retorna false se o token já havia sido usado
+	MarcarUsado(id uint64, data time.Time) (bool, error)
+	// InvalidarPendentes marca como usados os tokens ainda não usados do usuário
+	InvalidarPendentes(usuarioID uint64, tipo model.TipoTokenUsuario, data time.Time) error
+}
+
 // VendaDiaria represents daily sales statistics
 type VendaDiaria struct {
 	Data          string  `json:"data"`
 	TotalVendas   int64   `json:"totalVendas"`
 	TotalFaturado float64 `json:"totalFaturado"`
+	// TotalDescontos soma os descontos de cupons concedidos no dia
+	TotalDescontos float64 `json:"totalDescontos"`
 }
 
 // VendaDiariaFilter for filtering sales report
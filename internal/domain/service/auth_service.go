@@ -1,27 +1,189 @@
 package service
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"log"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"gorm.io/gorm"
 )
 
+const (
+	defaultAccessTokenTTL  = 15 * time.Minute
+	defaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+	// claimSessao identifica a família de refresh tokens que originou o access token
+	claimSessao = "sid"
+)
+
+// AssinadorToken assina e valida os access tokens emitidos pela API
+type AssinadorToken interface {
+	Assinar(claims jwt.Claims) (string, error)
+	Parse(tokenString string) (*jwt.Token, error)
+}
+
+// TokensAutenticacao é o par de tokens devolvido no login e na renovação
+type TokensAutenticacao struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresIn    time.Duration
+}
+
+// AuthService emite access tokens de curta duração e refresh tokens rotativos.
+// Cada login abre uma família de refresh tokens; cada renovação troca o token
+// usado por um novo da mesma família. Se um token já trocado for reapresentado,
+// a família inteira é revogada, pois o token provavelmente vazou.
 type AuthService struct {
-	cfg *config.JWTConfig
+	cfg              *config.JWTConfig
+	assinador        AssinadorToken
+	refreshTokenRepo repository.RefreshTokenRepository
+	usuarioSvc       *UsuarioService
+	accessTokenTTL   time.Duration
+	refreshTokenTTL  time.Duration
+}
+
+func NewAuthService(cfg *config.JWTConfig, assinador AssinadorToken, refreshTokenRepo repository.RefreshTokenRepository, usuarioSvc *UsuarioService) *AuthService {
+	accessTokenTTL := time.Duration(cfg.AccessTokenTTLMinutes) * time.Minute
+	if accessTokenTTL <= 0 {
+		accessTokenTTL = defaultAccessTokenTTL
+	}
+	refreshTokenTTL := time.Duration(cfg.RefreshTokenTTLHours) * time.Hour
+	if refreshTokenTTL <= 0 {
+		refreshTokenTTL = defaultRefreshTokenTTL
+	}
+
+	return &AuthService{
+		cfg:              cfg,
+		assinador:        assinador,
+		refreshTokenRepo: refreshTokenRepo,
+		usuarioSvc:       usuarioSvc,
+		accessTokenTTL:   accessTokenTTL,
+		refreshTokenTTL:  refreshTokenTTL,
+	}
+}
+
+// Login abre uma nova sessão (família de refresh tokens) para o usuário autenticado
+func (s *AuthService) Login(user *model.Usuario) (*TokensAutenticacao, error) {
+	return s.emitirTokens(user, uuid.New().String())
+}
+
+// Refresh troca um refresh token válido por um novo par de tokens
+func (s *AuthService) Refresh(refreshToken string) (*TokensAutenticacao, error) {
+	token, err := s.refreshTokenRepo.FindByHash(hashTokenOpaco(refreshToken))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewAuthenticationException("Refresh token invalido")
+		}
+		return nil, err
+	}
+
+	agora := time.Now()
+	if token.Revogado() {
+		return nil, exception.NewAuthenticationException("Refresh token revogado")
+	}
+	if token.Usado() {
+		s.revogarPorReuso(token)
+		return nil, exception.NewAuthenticationException("Refresh token revogado")
+	}
+	if token.Expirado(agora) {
+		return nil, exception.NewAuthenticationException("Refresh token expirado")
+	}
+
+	marcado, err := s.refreshTokenRepo.MarcarUsado(token.ID, agora)
+	if err != nil {
+		return nil, err
+	}
+	if !marcado {
+		// Outra requisição usou o mesmo token ao mesmo tempo
+		s.revogarPorReuso(token)
+		return nil, exception.NewAuthenticationException("Refresh token revogado")
+	}
+
+	user, err := s.usuarioSvc.FindByID(token.UsuarioID)
+	if err != nil {
+		var naoEncontrado *exception.UsuarioNaoEncontradoException
+		if errors.As(err, &naoEncontrado) {
+			return nil, exception.NewAuthenticationException("Refresh token invalido")
+		}
+		return nil, err
+	}
+
+	return s.emitirTokens(user, token.Familia)
+}
+
+// Logout revoga a família de refresh tokens da sessão do access token informado
+func (s *AuthService) Logout(accessToken string) error {
+	token, err := s.assinador.Parse(accessToken)
+	if err != nil {
+		return err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil
+	}
+	familia, ok := claims[claimSessao].(string)
+	if !ok || familia == "" {
+		// Tokens emitidos antes dos refresh tokens não têm sessão
+		return nil
+	}
+
+	return s.refreshTokenRepo.RevogarFamilia(familia, time.Now())
+}
+
+func (s *AuthService) revogarPorReuso(token *model.RefreshToken) {
+	log.Printf("Aviso: Reuso de refresh token detectado (usuario %d, familia %s). Revogando sessao.", token.UsuarioID, token.Familia)
+	if err := s.refreshTokenRepo.RevogarFamilia(token.Familia, time.Now()); err != nil {
+		log.Printf("Erro ao revogar familia de refresh tokens %s: %v", token.Familia, err)
+	}
 }
 
-func NewAuthService(cfg *config.JWTConfig) *AuthService {
-	return &AuthService{cfg: cfg}
+func (s *AuthService) emitirTokens(user *model.Usuario, familia string) (*TokensAutenticacao, error) {
+	accessToken, err := s.GenerateToken(user, familia)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := novoTokenOpaco()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.refreshTokenRepo.Save(&model.RefreshToken{
+		Familia:       familia,
+		UsuarioID:     user.ID,
+		TokenHash:     hashTokenOpaco(refreshToken),
+		DataExpiracao: time.Now().Add(s.refreshTokenTTL),
+	}); err != nil {
+		return nil, err
+	}
+
+	return &TokensAutenticacao{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    s.accessTokenTTL,
+	}, nil
 }
 
-func (s *AuthService) GenerateToken(user *model.Usuario) (string, error) {
+// GenerateToken emite um access token de curta duração vinculado à sessão
+func (s *AuthService) GenerateToken(user *model.Usuario, sessao string) (string, error) {
+	now := time.Now()
 	claims := jwt.MapClaims{
 		"usuario_id": user.ID,
-		"exp":        time.Now().Add(time.Hour * 24).Unix(),
-		"iat":        time.Now().Unix(),
+		"exp":        now.Add(s.accessTokenTTL).Unix(),
+		"iat":        now.Unix(),
 		"iss":        s.cfg.Issuer,
+		"jti":        uuid.New().String(),
+		claimSessao:  sessao,
 	}
 
 	// Add authorities to claims
@@ -33,6 +195,24 @@ func (s *AuthService) GenerateToken(user *model.Usuario) (string, error) {
 	}
 	claims["authorities"] = authorities
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(s.cfg.SecretKey))
+	if s.cfg.Audience != "" {
+		claims["aud"] = s.cfg.Audience
+	}
+
+	return s.assinador.Assinar(claims)
+}
+
+// novoTokenOpaco gera um token opaco com 256 bits aleatórios (refresh tokens e links por e-mail)
+func novoTokenOpaco() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashTokenOpaco: o token tem entropia alta, então SHA-256 basta (sem bcrypt)
+func hashTokenOpaco(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
 }
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// AvaliacaoService registra as avaliações dos pedidos entregues e as respostas dos
+// restaurantes. A média e o total ficam gravados no restaurante.
+type AvaliacaoService struct {
+	repo           repository.AvaliacaoRepository
+	pedidoSvc      *PedidoService
+	restauranteSvc *RestauranteService
+	cacheSvc       *BusinessCacheService
+}
+
+func NewAvaliacaoService(
+	repo repository.AvaliacaoRepository,
+	pedidoSvc *PedidoService,
+	restauranteSvc *RestauranteService,
+	cacheSvc *BusinessCacheService,
+) *AvaliacaoService {
+	return &AvaliacaoService{
+		repo:           repo,
+		pedidoSvc:      pedidoSvc,
+		restauranteSvc: restauranteSvc,
+		cacheSvc:       cacheSvc,
+	}
+}
+
+func (s *AvaliacaoService) FindAllByRestaurante(restauranteID uint64, page *pagination.Pageable) (*pagination.Page[model.Avaliacao], error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindAllByRestaurante(restauranteID, page)
+}
+
+func (s *AvaliacaoService) FindByID(restauranteID, avaliacaoID uint64) (*model.Avaliacao, error) {
+	avaliacao, err := s.repo.FindByID(restauranteID, avaliacaoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewAvaliacaoNaoEncontradaException(restauranteID, avaliacaoID)
+		}
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+func (s *AvaliacaoService) FindByPedido(codigoPedido string) (*model.Avaliacao, error) {
+	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
+		return nil, err
+	}
+
+	avaliacao, err := s.repo.FindByPedido(pedido.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewAvaliacaoPedidoNaoEncontradaException(codigoPedido)
+		}
+		return nil, err
+	}
+	return avaliacao, nil
+}
+
+// Avaliar registra a avaliação do cliente. Só pedidos entregues podem ser avaliados, uma única vez.
+func (s *AvaliacaoService) Avaliar(codigoPedido string, avaliacao *model.Avaliacao) error {
+	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
+		return err
+	}
+
+	if pedido.Status != model.StatusPedidoEntregue {
+		return exception.NewNegocioException(fmt.Sprintf("O pedido %s nao pode ser avaliado com status %s",
+			pedido.Codigo, pedido.Status.GetDescription()))
+	}
+	if avaliacao.Nota < model.NotaMinimaAvaliacao || avaliacao.Nota > model.NotaMaximaAvaliacao {
+		msg := fmt.Sprintf("A nota deve estar entre %d e %d", model.NotaMinimaAvaliacao, model.NotaMaximaAvaliacao)
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "nota", Mensagem: msg})
+	}
+
+	jaAvaliado := exception.NewNegocioException(fmt.Sprintf("O pedido %s ja foi avaliado", pedido.Codigo))
+	if _, err := s.repo.FindByPedido(pedido.ID); err == nil {
+		return jaAvaliado
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	avaliacao.PedidoID = pedido.ID
+	avaliacao.RestauranteID = pedido.RestauranteID
+	avaliacao.ClienteID = pedido.ClienteID
+	avaliacao.Cliente = pedido.Cliente
+	avaliacao.Comentario = strings.TrimSpace(avaliacao.Comentario)
+	if err := s.repo.Adicionar(avaliacao); err != nil {
+		// Outra requisição avaliou o pedido entre a verificação e a gravação
+		if repository.ChaveDuplicada(err, "uk_avaliacao_pedido") {
+			return jaAvaliado
+		}
+		return err
+	}
+
+	// A média do restaurante mudou
+	if s.cacheSvc != nil {
+		s.cacheSvc.InvalidateRestaurante(avaliacao.RestauranteID)
+	}
+	return nil
+}
+
+// Responder grava a resposta do restaurante, substituindo a anterior
+func (s *AvaliacaoService) Responder(restauranteID, avaliacaoID uint64, resposta string) (*model.Avaliacao, error) {
+	avaliacao, err := s.FindByID(restauranteID, avaliacaoID)
+	if err != nil {
+		return nil, err
+	}
+
+	resposta = strings.TrimSpace(resposta)
+	if resposta == "" {
+		msg := "Informe a resposta"
+		return nil, exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "resposta", Mensagem: msg})
+	}
+
+	avaliacao.Responder(resposta)
+	if err := s.repo.Save(avaliacao); err != nil {
+		return nil, err
+	}
+	return avaliacao, nil
+}
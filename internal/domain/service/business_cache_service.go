@@ -47,13 +47,19 @@ type CachedRestaurante struct {
 	ID                 uint64          `json:"id"`
 	Nome               string          `json:"nome"`
 	TaxaFrete          decimal.Decimal `json:"taxaFrete"`
+	ValorMinimoPedido  decimal.Decimal `json:"valorMinimoPedido"`
 	Ativo              bool            `json:"ativo"`
 	Aberto             bool            `json:"aberto"`
+	FusoHorario        string          `json:"fusoHorario,omitempty"`
 	CozinhaID          uint64          `json:"cozinhaId"`
 	Cozinha            *CachedCozinha  `json:"cozinha,omitempty"`
 	EnderecoCidadeID   uint64          `json:"enderecoCidadeId,omitempty"`
+	EnderecoLatitude   *float64        `json:"enderecoLatitude,omitempty"`
+	EnderecoLongitude  *float64        `json:"enderecoLongitude,omitempty"`
 	FormasPagamentoIDs []uint64        `json:"formasPagamentoIds,omitempty"`
 	ResponsaveisIDs    []uint64        `json:"responsaveisIds,omitempty"`
+	AvaliacaoMedia     decimal.Decimal `json:"avaliacaoMedia"`
+	TotalAvaliacoes    int64           `json:"totalAvaliacoes"`
 }
 
 // BusinessCacheService gerencia o cache de entidades de negócio
@@ -317,12 +323,16 @@ func (s *BusinessCacheService) InvalidateRestaurante(id uint64) error {
 // toCachedRestaurante converte um restaurante para versão em cache
 func (s *BusinessCacheService) toCachedRestaurante(r *model.Restaurante) *CachedRestaurante {
 	cached := &CachedRestaurante{
-		ID:        r.ID,
-		Nome:      r.Nome,
-		TaxaFrete: r.TaxaFrete,
-		Ativo:     r.Ativo,
-		Aberto:    r.Aberto,
-		CozinhaID: r.CozinhaID,
+		ID:                r.ID,
+		Nome:              r.Nome,
+		TaxaFrete:         r.TaxaFrete,
+		ValorMinimoPedido: r.ValorMinimoPedido,
+		Ativo:             r.Ativo,
+		Aberto:            r.Aberto,
+		FusoHorario:       r.FusoHorario,
+		CozinhaID:         r.CozinhaID,
+		AvaliacaoMedia:    r.AvaliacaoMedia,
+		TotalAvaliacoes:   r.TotalAvaliacoes,
 	}
 
 	if r.Cozinha.ID > 0 {
@@ -335,6 +345,8 @@ func (s *BusinessCacheService) toCachedRestaurante(r *model.Restaurante) *Cached
 	if r.Endereco.CidadeID > 0 {
 		cached.EnderecoCidadeID = r.Endereco.CidadeID
 	}
+	cached.EnderecoLatitude = r.Endereco.Latitude
+	cached.EnderecoLongitude = r.Endereco.Longitude
 
 	for _, fp := range r.FormasPagamento {
 		cached.FormasPagamentoIDs = append(cached.FormasPagamentoIDs, fp.ID)
@@ -350,13 +362,19 @@ func (s *BusinessCacheService) toCachedRestaurante(r *model.Restaurante) *Cached
 // ToModel converte CachedRestaurante para model.Restaurante
 func (c *CachedRestaurante) ToModel() *model.Restaurante {
 	r := &model.Restaurante{
-		ID:        c.ID,
-		Nome:      c.Nome,
-		TaxaFrete: c.TaxaFrete,
-		Ativo:     c.Ativo,
-		Aberto:    c.Aberto,
-		CozinhaID: c.CozinhaID,
+		ID:                c.ID,
+		Nome:              c.Nome,
+		TaxaFrete:         c.TaxaFrete,
+		ValorMinimoPedido: c.ValorMinimoPedido,
+		Ativo:             c.Ativo,
+		Aberto:            c.Aberto,
+		FusoHorario:       c.FusoHorario,
+		CozinhaID:         c.CozinhaID,
+		AvaliacaoMedia:    c.AvaliacaoMedia,
+		TotalAvaliacoes:   c.TotalAvaliacoes,
 	}
+	r.Endereco.Latitude = c.EnderecoLatitude
+	r.Endereco.Longitude = c.EnderecoLongitude
 
 	if c.Cozinha != nil {
 		r.Cozinha = model.Cozinha{
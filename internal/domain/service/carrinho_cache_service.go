@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+)
+
+const (
+	// Prefixos para chaves no Redis
+	carrinhoPrefix        = "carrinho:"
+	carrinhoProdutoPrefix = "carrinho:produto:"
+
+	// DefaultCarrinhoTTL é usado quando pedido.carrinho_ttl_hours não está configurado
+	DefaultCarrinhoTTL = 72 * time.Hour
+
+	// Timeout para operações com um carrinho
+	carrinhoCacheTimeout = 200 * time.Millisecond
+	// Timeout para repreçar todos os carrinhos de um produto
+	carrinhoRepricingTimeout = 5 * time.Second
+	// Tentativas de gravar um carrinho alterado ao mesmo tempo por outra requisição
+	carrinhoMaxTentativas = 5
+)
+
+// CarrinhoCacheService guarda os carrinhos no Redis, um por usuário e restaurante. Um índice
+// por produto aponta os carrinhos que o contêm, para repreçá-los quando o produto muda.
+type CarrinhoCacheService struct {
+	redisClient *redis.Client
+	ttl         time.Duration
+}
+
+// NewCarrinhoCacheService cria um novo serviço de cache de carrinhos
+func NewCarrinhoCacheService(redisCfg *config.RedisConfig, ttl time.Duration) *CarrinhoCacheService {
+	client := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
+		Password:     redisCfg.Password,
+		DB:           redisCfg.DB,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  carrinhoCacheTimeout,
+		WriteTimeout: carrinhoCacheTimeout,
+	})
+
+	if ttl <= 0 {
+		ttl = DefaultCarrinhoTTL
+	}
+	return &CarrinhoCacheService{redisClient: client, ttl: ttl}
+}
+
+// Get obtém o carrinho do usuário no restaurante; retorna nil quando não existe ou expirou
+func (s *CarrinhoCacheService) Get(usuarioID, restauranteID uint64) (*model.Carrinho, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), carrinhoCacheTimeout)
+	defer cancel()
+
+	return s.get(ctx, s.key(usuarioID, restauranteID))
+}
+
+// Atualizar lê o carrinho (vazio quando não existe), aplica a alteração e grava renovando o TTL
+// e o índice de produtos. O WATCH descarta a escrita quando outra requisição altera o mesmo
+// carrinho no meio do caminho, e a alteração é reaplicada sobre o carrinho novo; por isso
+// alterar não deve ter efeitos além do próprio carrinho. Um erro de alterar não grava nada.
+func (s *CarrinhoCacheService) Atualizar(usuarioID, restauranteID uint64, alterar func(*model.Carrinho) error) (*model.Carrinho, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), carrinhoCacheTimeout)
+	defer cancel()
+
+	key := s.key(usuarioID, restauranteID)
+	var carrinho *model.Carrinho
+	atualizar := func(tx *redis.Tx) error {
+		atual, err := s.getTx(ctx, tx, key)
+		if err != nil {
+			return err
+		}
+		if atual == nil {
+			atual = model.NewCarrinho(usuarioID, restauranteID)
+		}
+		if err := alterar(atual); err != nil {
+			return err
+		}
+
+		atual.DataAtualizacao = time.Now()
+		data, err := json.Marshal(atual)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, s.ttl)
+			for _, item := range atual.Itens {
+				indice := s.produtoKey(item.ProdutoID)
+				pipe.SAdd(ctx, indice, key)
+				pipe.Expire(ctx, indice, s.ttl)
+			}
+			return nil
+		})
+		if err == nil {
+			carrinho = atual
+		}
+		return err
+	}
+
+	for tentativa := 0; tentativa < carrinhoMaxTentativas; tentativa++ {
+		err := s.redisClient.Watch(ctx, atualizar, key)
+		if err != redis.TxFailedErr {
+			return carrinho, err
+		}
+	}
+	return nil, fmt.Errorf("carrinho %s alterado simultaneamente por outra requisicao", key)
+}
+
+// Delete remove o carrinho. As entradas do índice são limpas no próximo repreço do produto.
+func (s *CarrinhoCacheService) Delete(usuarioID, restauranteID uint64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), carrinhoCacheTimeout)
+	defer cancel()
+
+	return s.redisClient.Del(ctx, s.key(usuarioID, restauranteID)).Err()
+}
+
+// ProdutoAlterado repreça os carrinhos que contêm o produto, ou remove o produto deles
+// quando foi inativado. Falhas são apenas registradas: a prévia e o checkout usam os
+// preços atuais de qualquer forma.
+func (s *CarrinhoCacheService) ProdutoAlterado(produto *model.Produto) {
+	ctx, cancel := context.WithTimeout(context.Background(), carrinhoRepricingTimeout)
+	defer cancel()
+
+	indice := s.produtoKey(produto.ID)
+	keys, err := s.redisClient.SMembers(ctx, indice).Result()
+	if err != nil {
+		log.Printf("Aviso: Falha ao listar carrinhos do produto %d: %v", produto.ID, err)
+		return
+	}
+
+	for _, key := range keys {
+		if err := s.aplicarAlteracao(ctx, indice, key, produto); err != nil {
+			log.Printf("Aviso: Falha ao atualizar carrinho %s com o produto %d: %v", key, produto.ID, err)
+		}
+	}
+}
+
+// aplicarAlteracao aplica a alteração do produto em um carrinho, mantendo o TTL restante. O WATCH
+// descarta a escrita quando o cliente altera o carrinho ao mesmo tempo.
+func (s *CarrinhoCacheService) aplicarAlteracao(ctx context.Context, indice, key string, produto *model.Produto) error {
+	return s.redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Bytes()
+		if err == redis.Nil {
+			return tx.SRem(ctx, indice, key).Err()
+		}
+		if err != nil {
+			return err
+		}
+
+		var carrinho model.Carrinho
+		if err := json.Unmarshal(data, &carrinho); err != nil {
+			return err
+		}
+		if !carrinho.ContemProduto(produto.ID) {
+			return tx.SRem(ctx, indice, key).Err()
+		}
+		if !carrinho.AplicarAlteracaoProduto(produto) {
+			return nil
+		}
+
+		data, err = json.Marshal(&carrinho)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, data, redis.KeepTTL)
+			if !carrinho.ContemProduto(produto.ID) {
+				pipe.SRem(ctx, indice, key)
+			}
+			return nil
+		})
+		return err
+	}, key)
+}
+
+func (s *CarrinhoCacheService) get(ctx context.Context, key string) (*model.Carrinho, error) {
+	return s.getTx(ctx, s.redisClient, key)
+}
+
+func (s *CarrinhoCacheService) getTx(ctx context.Context, cmd redis.Cmdable, key string) (*model.Carrinho, error) {
+	data, err := cmd.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var carrinho model.Carrinho
+	if err := json.Unmarshal(data, &carrinho); err != nil {
+		return nil, err
+	}
+	return &carrinho, nil
+}
+
+func (s *CarrinhoCacheService) key(usuarioID, restauranteID uint64) string {
+	return fmt.Sprintf("%s%d:%d", carrinhoPrefix, usuarioID, restauranteID)
+}
+
+func (s *CarrinhoCacheService) produtoKey(produtoID uint64) string {
+	return fmt.Sprintf("%s%d", carrinhoProdutoPrefix, produtoID)
+}
+
+// Close fecha a conexão com o Redis
+func (s *CarrinhoCacheService) Close() error {
+	return s.redisClient.Close()
+}
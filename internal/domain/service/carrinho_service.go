@@ -0,0 +1,234 @@
+package service
+
+import (
+	"errors"
+	"log"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+)
+
+// PreviaCarrinho é o carrinho com o pedido simulado a partir dele, com os preços atuais,
+// o frete e o desconto. Pendencias reúne as regras que ainda impedem o checkout; quando
+// elas impedem o próprio cálculo, Pedido é nil.
+type PreviaCarrinho struct {
+	Carrinho   *model.Carrinho
+	Pedido     *model.Pedido
+	Pendencias *exception.NegocioException
+}
+
+// CarrinhoService gerencia o carrinho do cliente em cada restaurante e o checkout para pedido
+type CarrinhoService struct {
+	cache          *CarrinhoCacheService
+	pedidoSvc      *PedidoService
+	restauranteSvc *RestauranteService
+	produtoSvc     *ProdutoService
+	grupoOpcoesSvc *GrupoOpcoesService
+}
+
+func NewCarrinhoService(
+	cache *CarrinhoCacheService,
+	pedidoSvc *PedidoService,
+	restauranteSvc *RestauranteService,
+	produtoSvc *ProdutoService,
+	grupoOpcoesSvc *GrupoOpcoesService,
+) *CarrinhoService {
+	return &CarrinhoService{
+		cache:          cache,
+		pedidoSvc:      pedidoSvc,
+		restauranteSvc: restauranteSvc,
+		produtoSvc:     produtoSvc,
+		grupoOpcoesSvc: grupoOpcoesSvc,
+	}
+}
+
+// Buscar retorna o carrinho do usuário no restaurante, vazio quando não existe
+func (s *CarrinhoService) Buscar(usuarioID, restauranteID uint64) (*model.Carrinho, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+
+	carrinho, err := s.cache.Get(usuarioID, restauranteID)
+	if err != nil {
+		return nil, err
+	}
+	if carrinho == nil {
+		carrinho = model.NewCarrinho(usuarioID, restauranteID)
+	}
+	return carrinho, nil
+}
+
+// Previa simula o pedido do carrinho com os dados de entrega informados (forma de pagamento,
+// endereço salvo e cupom, todos opcionais). Os avisos do carrinho são exibidos uma única vez.
+func (s *CarrinhoService) Previa(usuarioID, restauranteID uint64, dados *model.Pedido) (*PreviaCarrinho, error) {
+	carrinho, err := s.Buscar(usuarioID, restauranteID)
+	if err != nil {
+		return nil, err
+	}
+	previa := &PreviaCarrinho{Carrinho: carrinho}
+	if carrinho.Vazio() {
+		return previa, nil
+	}
+
+	// Regras que impedem o cálculo (sem endereço, opção indisponível) viram pendências da prévia
+	pedido := s.paraPedido(carrinho, dados)
+	pendencias, err := s.pedidoSvc.Simular(pedido)
+	var negocio *exception.NegocioException
+	switch {
+	case errors.As(err, &negocio):
+		previa.Pendencias = negocio
+	case err != nil:
+		return nil, err
+	default:
+		s.pedidoSvc.populateRelacionamentos(pedido)
+		previa.Pedido = pedido
+		previa.Pendencias = pendencias
+	}
+
+	// Remove só os avisos exibidos: outros podem ter chegado durante o cálculo
+	if len(carrinho.Avisos) > 0 {
+		exibidos := make(map[string]bool, len(carrinho.Avisos))
+		for _, aviso := range carrinho.Avisos {
+			exibidos[aviso] = true
+		}
+		_, err := s.cache.Atualizar(usuarioID, restauranteID, func(atual *model.Carrinho) error {
+			pendentes := atual.Avisos[:0]
+			for _, aviso := range atual.Avisos {
+				if !exibidos[aviso] {
+					pendentes = append(pendentes, aviso)
+				}
+			}
+			atual.Avisos = pendentes
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return previa, nil
+}
+
+// AdicionarItem inclui o produto no carrinho, conferindo se ele está ativo e as opções escolhidas
+func (s *CarrinhoService) AdicionarItem(usuarioID, restauranteID uint64, item *model.ItemCarrinho) (*model.Carrinho, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	if err := s.conferirItem(restauranteID, item); err != nil {
+		return nil, err
+	}
+
+	novo := *item
+	return s.cache.Atualizar(usuarioID, restauranteID, func(carrinho *model.Carrinho) error {
+		*item = *carrinho.AdicionarItem(novo)
+		return nil
+	})
+}
+
+// AtualizarItem substitui o produto, a quantidade, a observação e as opções do item
+func (s *CarrinhoService) AtualizarItem(usuarioID, restauranteID, itemID uint64, item *model.ItemCarrinho) (*model.Carrinho, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	if err := s.conferirItem(restauranteID, item); err != nil {
+		return nil, err
+	}
+
+	return s.cache.Atualizar(usuarioID, restauranteID, func(carrinho *model.Carrinho) error {
+		existente := carrinho.BuscarItem(itemID)
+		if existente == nil {
+			return exception.NewItemCarrinhoNaoEncontradoException(itemID)
+		}
+		item.ID = existente.ID
+		*existente = *item
+		return nil
+	})
+}
+
+// RemoverItem retira o item do carrinho
+func (s *CarrinhoService) RemoverItem(usuarioID, restauranteID, itemID uint64) (*model.Carrinho, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+
+	return s.cache.Atualizar(usuarioID, restauranteID, func(carrinho *model.Carrinho) error {
+		if !carrinho.RemoverItem(itemID) {
+			return exception.NewItemCarrinhoNaoEncontradoException(itemID)
+		}
+		return nil
+	})
+}
+
+// Limpar remove o carrinho do usuário no restaurante
+func (s *CarrinhoService) Limpar(usuarioID, restauranteID uint64) error {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return err
+	}
+	return s.cache.Delete(usuarioID, restauranteID)
+}
+
+// Checkout emite o pedido do carrinho com os preços atuais e remove o carrinho. Depois da
+// emissão não retorna erro, para que o handler registre a resposta da chave de idempotência.
+func (s *CarrinhoService) Checkout(usuarioID, restauranteID uint64, dados *model.Pedido) (*model.Pedido, error) {
+	carrinho, err := s.Buscar(usuarioID, restauranteID)
+	if err != nil {
+		return nil, err
+	}
+	if carrinho.Vazio() {
+		return nil, exception.NewNegocioExceptionComCampos("O carrinho esta vazio",
+			exception.CampoInvalido{Nome: "itens", Mensagem: "Adicione ao menos um item ao carrinho"})
+	}
+
+	pedido := s.paraPedido(carrinho, dados)
+	if err := s.pedidoSvc.Emitir(pedido); err != nil {
+		return nil, err
+	}
+
+	// O pedido já foi emitido; uma falha aqui apenas deixa o carrinho para expirar
+	if err := s.cache.Delete(usuarioID, restauranteID); err != nil {
+		log.Printf("Aviso: Falha ao remover carrinho do usuario %d no restaurante %d: %v", usuarioID, restauranteID, err)
+	}
+
+	// O pedido já existe, então uma falha ao recarregar não pode virar erro
+	completo, err := s.pedidoSvc.FindByCodigo(pedido.Codigo)
+	if err != nil {
+		log.Printf("Aviso: Falha ao recarregar o pedido %s: %v", pedido.Codigo, err)
+		return pedido, nil
+	}
+	return completo, nil
+}
+
+// conferirItem valida o produto e as opções do item e grava o nome e o preço atuais
+func (s *CarrinhoService) conferirItem(restauranteID uint64, item *model.ItemCarrinho) error {
+	produto, err := s.produtoSvc.FindByID(restauranteID, item.ProdutoID)
+	if err != nil {
+		return err
+	}
+	if !produto.Ativo {
+		return exception.NewNegocioExceptionComCampos("Produto indisponivel",
+			exception.CampoInvalido{Nome: "produtoId", Mensagem: "O produto " + produto.Nome + " nao esta disponivel"})
+	}
+
+	escolhidas := make([]model.ItemPedidoOpcao, len(item.Opcoes))
+	for i, opcaoID := range item.Opcoes {
+		escolhidas[i] = model.ItemPedidoOpcao{OpcaoID: opcaoID}
+	}
+	if _, err := s.grupoOpcoesSvc.SelecionarOpcoes(produto, escolhidas); err != nil {
+		return err
+	}
+
+	item.ProdutoNome = produto.Nome
+	item.PrecoUnitario = produto.Preco
+	return nil
+}
+
+// paraPedido monta o pedido do carrinho com os dados de entrega informados
+func (s *CarrinhoService) paraPedido(carrinho *model.Carrinho, dados *model.Pedido) *model.Pedido {
+	pedido := carrinho.ParaPedido()
+	if dados != nil {
+		pedido.FormaPagamentoID = dados.FormaPagamentoID
+		pedido.EnderecoEntrega = dados.EnderecoEntrega
+		pedido.EnderecoSalvoID = dados.EnderecoSalvoID
+		pedido.CodigoCupom = dados.CodigoCupom
+	}
+	return pedido
+}
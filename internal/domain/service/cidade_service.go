@@ -7,6 +7,7 @@ import (
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -24,27 +25,9 @@ func NewCidadeService(repo repository.CidadeRepository, estadoSvc *EstadoService
 	}
 }
 
-func (s *CidadeService) FindAll() ([]model.Cidade, error) {
-	// Tenta obter do cache primeiro
-	if s.cacheSvc != nil {
-		if cached, err := s.cacheSvc.GetAllCidades(); err == nil && cached != nil {
-			return cached, nil
-		}
-	}
-
-	cidades, err := s.repo.FindAll()
-	if err != nil {
-		return nil, err
-	}
-
-	// Armazena no cache
-	if s.cacheSvc != nil {
-		if err := s.cacheSvc.SetAllCidades(cidades); err != nil {
-			log.Printf("Aviso: Falha ao armazenar cidades no cache: %v", err)
-		}
-	}
-
-	return cidades, nil
+// FindAll lista as cidades paginadas direto do banco; o cache é usado nas buscas por ID
+func (s *CidadeService) FindAll(page *pagination.Pageable) (*pagination.Page[model.Cidade], error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *CidadeService) FindByID(id uint64) (*model.Cidade, error) {
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/email"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultRedefinicaoSenhaTTL = 30 * time.Minute
+	defaultVerificacaoEmailTTL = 48 * time.Hour
+)
+
+// ContaService cuida dos fluxos de conta feitos por links enviados por e-mail:
+// redefinição de senha e verificação do e-mail. Os tokens são de uso único, expiram
+// e apenas o hash SHA-256 é armazenado.
+type ContaService struct {
+	cfg              *config.ContaConfig
+	tokenRepo        repository.TokenUsuarioRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	usuarioSvc       *UsuarioService
+	emailSvc         email.EmailService
+	redefinicaoTTL   time.Duration
+	verificacaoTTL   time.Duration
+}
+
+func NewContaService(
+	cfg *config.ContaConfig,
+	tokenRepo repository.TokenUsuarioRepository,
+	refreshTokenRepo repository.RefreshTokenRepository,
+	usuarioSvc *UsuarioService,
+	emailSvc email.EmailService,
+) *ContaService {
+	redefinicaoTTL := time.Duration(cfg.RedefinicaoSenhaTTLMinutes) * time.Minute
+	if redefinicaoTTL <= 0 {
+		redefinicaoTTL = defaultRedefinicaoSenhaTTL
+	}
+	verificacaoTTL := time.Duration(cfg.VerificacaoEmailTTLHours) * time.Hour
+	if verificacaoTTL <= 0 {
+		verificacaoTTL = defaultVerificacaoEmailTTL
+	}
+
+	return &ContaService{
+		cfg:              cfg,
+		tokenRepo:        tokenRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		usuarioSvc:       usuarioSvc,
+		emailSvc:         emailSvc,
+		redefinicaoTTL:   redefinicaoTTL,
+		verificacaoTTL:   verificacaoTTL,
+	}
+}
+
+// SolicitarRedefinicaoSenha envia o link de redefinição se houver usuário com o e-mail.
+// O processamento é feito em segundo plano para que a resposta seja a mesma, inclusive
+// no tempo, exista ou não uma conta com o e-mail informado.
+func (s *ContaService) SolicitarRedefinicaoSenha(emailUsuario string) {
+	go func() {
+		if err := s.enviarRedefinicaoSenha(emailUsuario); err != nil {
+			log.Printf("Erro ao enviar redefinicao de senha: %v", err)
+		}
+	}()
+}
+
+func (s *ContaService) enviarRedefinicaoSenha(emailUsuario string) error {
+	usuario, err := s.usuarioSvc.FindByEmail(emailUsuario)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	// Apenas o link mais recente vale
+	token, err := s.emitirToken(usuario.ID, model.TipoTokenRedefinicaoSenha, s.redefinicaoTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h1>Redefinição de senha</h1>
+			<p>Olá, <strong>%s</strong>!</p>
+			<p>Recebemos um pedido para redefinir a senha da sua conta no AlgaFood.</p>
+			%s
+			<p>O link expira em %s e só pode ser usado uma vez.</p>
+			<hr>
+			<p>Se você não pediu a redefinição, ignore este e-mail. Sua senha continua a mesma.</p>
+		</body>
+		</html>
+	`, html.EscapeString(usuario.Nome), linkToken(s.cfg.URLRedefinicaoSenha, token, "Redefinir senha"),
+		formatDuracao(s.redefinicaoTTL))
+
+	return s.emailSvc.Send(email.EmailMessage{
+		To:      []string{usuario.Email},
+		Subject: "Redefinição de senha - AlgaFood",
+		Body:    body,
+	})
+}
+
+// RedefinirSenha consome o token de redefinição e troca a senha do usuário.
+// As sessões abertas (refresh tokens) são revogadas.
+func (s *ContaService) RedefinirSenha(token, novaSenha string) error {
+	// O token só é consumido junto com a troca da senha: se ela falhar, o link continua valendo
+	tokenUsuario, err := s.buscarTokenValido(token, model.TipoTokenRedefinicaoSenha)
+	if err != nil {
+		return err
+	}
+
+	redefinida, err := s.usuarioSvc.RedefinirSenha(tokenUsuario, novaSenha)
+	if err != nil {
+		return err
+	}
+	if !redefinida {
+		// Outra requisição usou o mesmo token ao mesmo tempo
+		return tokenInvalidoException()
+	}
+
+	if err := s.refreshTokenRepo.RevogarPorUsuario(tokenUsuario.UsuarioID, time.Now()); err != nil {
+		log.Printf("Erro ao revogar sessoes do usuario %d apos redefinicao de senha: %v", tokenUsuario.UsuarioID, err)
+	}
+	return nil
+}
+
+// EnviarVerificacaoEmail envia em segundo plano o link de verificação do e-mail do usuário
+func (s *ContaService) EnviarVerificacaoEmail(usuario *model.Usuario) {
+	go func() {
+		if err := s.enviarVerificacaoEmail(usuario); err != nil {
+			log.Printf("Erro ao enviar verificacao de e-mail do usuario %d: %v", usuario.ID, err)
+		}
+	}()
+}
+
+// ReenviarVerificacaoEmail envia um novo link de verificação; os anteriores deixam de valer
+func (s *ContaService) ReenviarVerificacaoEmail(usuarioID uint64) error {
+	usuario, err := s.usuarioSvc.FindByID(usuarioID)
+	if err != nil {
+		return err
+	}
+	if usuario.EmailVerificado {
+		return exception.NewNegocioException("O e-mail do usuario ja foi verificado")
+	}
+
+	s.EnviarVerificacaoEmail(usuario)
+	return nil
+}
+
+func (s *ContaService) enviarVerificacaoEmail(usuario *model.Usuario) error {
+	token, err := s.emitirToken(usuario.ID, model.TipoTokenVerificacaoEmail, s.verificacaoTTL)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h1>Confirme seu e-mail</h1>
+			<p>Olá, <strong>%s</strong>!</p>
+			<p>Confirme o e-mail da sua conta no AlgaFood para poder fazer pedidos.</p>
+			%s
+			<p>O link expira em %s.</p>
+		</body>
+		</html>
+	`, html.EscapeString(usuario.Nome), linkToken(s.cfg.URLVerificacaoEmail, token, "Confirmar e-mail"),
+		formatDuracao(s.verificacaoTTL))
+
+	return s.emailSvc.Send(email.EmailMessage{
+		To:      []string{usuario.Email},
+		Subject: "Confirme seu e-mail - AlgaFood",
+		Body:    body,
+	})
+}
+
+// VerificarEmail consome o token de verificação e marca o e-mail como verificado
+func (s *ContaService) VerificarEmail(token string) error {
+	tokenUsuario, err := s.consumirToken(token, model.TipoTokenVerificacaoEmail)
+	if err != nil {
+		return err
+	}
+	return s.usuarioSvc.ConfirmarEmail(tokenUsuario.UsuarioID)
+}
+
+// emitirToken invalida os tokens pendentes do mesmo tipo e gera um novo
+func (s *ContaService) emitirToken(usuarioID uint64, tipo model.TipoTokenUsuario, ttl time.Duration) (string, error) {
+	agora := time.Now()
+	if err := s.tokenRepo.InvalidarPendentes(usuarioID, tipo, agora); err != nil {
+		return "", err
+	}
+
+	token, err := novoTokenOpaco()
+	if err != nil {
+		return "", err
+	}
+	if err := s.tokenRepo.Save(&model.TokenUsuario{
+		Tipo:          tipo,
+		UsuarioID:     usuarioID,
+		TokenHash:     hashTokenOpaco(token),
+		DataExpiracao: agora.Add(ttl),
+	}); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// consumirToken valida o token e o marca como usado. Todos os motivos de rejeição
+// resultam na mesma mensagem para não revelar se o token existiu.
+func (s *ContaService) consumirToken(token string, tipo model.TipoTokenUsuario) (*model.TokenUsuario, error) {
+	tokenUsuario, err := s.buscarTokenValido(token, tipo)
+	if err != nil {
+		return nil, err
+	}
+
+	marcado, err := s.tokenRepo.MarcarUsado(tokenUsuario.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if !marcado {
+		// Outra requisição usou o mesmo token ao mesmo tempo
+		return nil, tokenInvalidoException()
+	}
+
+	return tokenUsuario, nil
+}
+
+// buscarTokenValido localiza o token do tipo informado, ainda não usado nem expirado
+func (s *ContaService) buscarTokenValido(token string, tipo model.TipoTokenUsuario) (*model.TokenUsuario, error) {
+	tokenUsuario, err := s.tokenRepo.FindByHash(hashTokenOpaco(token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, tokenInvalidoException()
+		}
+		return nil, err
+	}
+
+	if tokenUsuario.Tipo != tipo || tokenUsuario.Usado() || tokenUsuario.Expirado(time.Now()) {
+		return nil, tokenInvalidoException()
+	}
+	return tokenUsuario, nil
+}
+
+func tokenInvalidoException() error {
+	return exception.NewNegocioException("Token invalido ou expirado")
+}
+
+// linkToken monta o link com o token; sem URL configurada, envia apenas o token
+func linkToken(base, token, texto string) string {
+	if base == "" {
+		return fmt.Sprintf(`<p>Use o código abaixo:</p><p><strong>%s</strong></p>`, token)
+	}
+
+	link := base
+	if u, err := url.Parse(base); err == nil {
+		q := u.Query()
+		q.Set("token", token)
+		u.RawQuery = q.Encode()
+		link = u.String()
+	}
+	return fmt.Sprintf(`<p><a href="%s">%s</a></p>`, html.EscapeString(link), texto)
+}
+
+func formatDuracao(d time.Duration) string {
+	if d >= time.Hour && d%time.Hour == 0 {
+		return fmt.Sprintf("%d hora(s)", int(d/time.Hour))
+	}
+	return fmt.Sprintf("%d minuto(s)", int(d/time.Minute))
+}
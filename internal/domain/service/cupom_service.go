@@ -0,0 +1,176 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// codigoCupomRegex aceita letras maiúsculas, números, hífen e sublinhado
+var codigoCupomRegex = regexp.MustCompile(`^[A-Z0-9_-]{3,30}$`)
+
+// CupomService gerencia os cupons de desconto e valida seu uso nos pedidos
+type CupomService struct {
+	repo           repository.CupomRepository
+	restauranteSvc *RestauranteService
+}
+
+func NewCupomService(repo repository.CupomRepository, restauranteSvc *RestauranteService) *CupomService {
+	return &CupomService{
+		repo:           repo,
+		restauranteSvc: restauranteSvc,
+	}
+}
+
+// NormalizarCodigoCupom remove espaços e converte para maiúsculas: os códigos não diferenciam caixa
+func NormalizarCodigoCupom(codigo string) string {
+	return strings.ToUpper(strings.TrimSpace(codigo))
+}
+
+func (s *CupomService) FindAll(filter *repository.CupomFilter, page *pagination.Pageable) (*pagination.Page[model.Cupom], error) {
+	return s.repo.FindAll(filter, page)
+}
+
+func (s *CupomService) FindByID(id uint64) (*model.Cupom, error) {
+	cupom, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewCupomNaoEncontradoException(id)
+		}
+		return nil, err
+	}
+	return cupom, nil
+}
+
+func (s *CupomService) Save(cupom *model.Cupom) error {
+	cupom.Codigo = NormalizarCodigoCupom(cupom.Codigo)
+	if !codigoCupomRegex.MatchString(cupom.Codigo) {
+		return cupomInvalido("codigo", "O codigo do cupom deve ter de 3 a 30 letras, numeros, hifens ou sublinhados")
+	}
+
+	switch cupom.Tipo {
+	case model.TipoDescontoPercentual:
+		if !cupom.Valor.IsPositive() || cupom.Valor.GreaterThan(decimal.NewFromInt(100)) {
+			return cupomInvalido("valor", "O percentual de desconto deve ser maior que 0 e no maximo 100")
+		}
+	case model.TipoDescontoValorFixo:
+		if !cupom.Valor.IsPositive() {
+			return cupomInvalido("valor", "O valor do desconto deve ser maior que 0")
+		}
+	case model.TipoDescontoFreteGratis:
+		cupom.Valor = decimal.Zero
+	default:
+		return cupomInvalido("tipo", fmt.Sprintf("Tipo de desconto %s invalido", cupom.Tipo))
+	}
+
+	if cupom.ValidoDe != nil && cupom.ValidoAte != nil && !cupom.ValidoAte.After(*cupom.ValidoDe) {
+		return cupomInvalido("validoAte", "O fim da validade deve ser posterior ao inicio")
+	}
+
+	cupom.Restaurante = nil
+	if cupom.RestauranteID != nil {
+		restaurante, err := s.restauranteSvc.FindByID(*cupom.RestauranteID)
+		if err != nil {
+			return err
+		}
+		cupom.Restaurante = restaurante
+	}
+
+	existente, err := s.repo.FindByCodigo(cupom.Codigo)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if existente != nil && existente.ID != cupom.ID {
+		return cupomInvalido("codigo", fmt.Sprintf("Ja existe um cupom com o codigo %s", cupom.Codigo))
+	}
+
+	return s.repo.Save(cupom)
+}
+
+func (s *CupomService) Ativar(id uint64) error {
+	return s.alterarAtivo(id, true)
+}
+
+func (s *CupomService) Inativar(id uint64) error {
+	return s.alterarAtivo(id, false)
+}
+
+func (s *CupomService) alterarAtivo(id uint64, ativo bool) error {
+	cupom, err := s.FindByID(id)
+	if err != nil {
+		return err
+	}
+	cupom.Ativo = ativo
+	return s.repo.Save(cupom)
+}
+
+// Excluir remove cupons nunca usados; cupons já usados devem ser inativados
+func (s *CupomService) Excluir(id uint64) error {
+	if _, err := s.FindByID(id); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id); err != nil {
+		if repository.RegistroEmUso(err) {
+			return exception.NewEntidadeEmUsoException("Cupom nao pode ser removido, pois ja foi usado em pedidos. Inative-o")
+		}
+		return err
+	}
+	return nil
+}
+
+// ValidarParaPedido busca o cupom pelo código e verifica se pode ser usado no pedido,
+// cujo subtotal já deve estar calculado. Os limites de uso são verificados novamente
+// ao gravar o pedido, para evitar usos concorrentes além do limite.
+func (s *CupomService) ValidarParaPedido(codigo string, pedido *model.Pedido) (*model.Cupom, error) {
+	codigo = NormalizarCodigoCupom(codigo)
+
+	cupom, err := s.repo.FindByCodigo(codigo)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cupomInvalido("cupom", fmt.Sprintf("Cupom %s invalido", codigo))
+		}
+		return nil, err
+	}
+
+	if !cupom.Vigente(time.Now()) {
+		return nil, cupomInvalido("cupom", fmt.Sprintf("O cupom %s nao esta vigente", codigo))
+	}
+	if !cupom.ValidoPara(pedido.RestauranteID) {
+		return nil, cupomInvalido("cupom", fmt.Sprintf("O cupom %s nao e valido para este restaurante", codigo))
+	}
+	if minimo := cupom.ValorMinimoPedido; minimo != nil && pedido.Subtotal.LessThan(*minimo) {
+		return nil, cupomInvalido("cupom", fmt.Sprintf("O cupom %s exige subtotal minimo de %s", codigo, minimo.StringFixed(2)))
+	}
+	if cupom.LimiteUsos != nil && cupom.TotalUsos >= int64(*cupom.LimiteUsos) {
+		return nil, CupomEsgotadoException(codigo)
+	}
+	if cupom.LimiteUsosPorCliente != nil {
+		usos, err := s.repo.ContarUsosCliente(cupom.ID, pedido.ClienteID)
+		if err != nil {
+			return nil, err
+		}
+		if usos >= int64(*cupom.LimiteUsosPorCliente) {
+			return nil, cupomInvalido("cupom", fmt.Sprintf("O cupom %s ja foi usado o numero maximo de vezes por este cliente", codigo))
+		}
+	}
+
+	return cupom, nil
+}
+
+// CupomEsgotadoException é retornada quando o cupom atingiu o limite de usos
+func CupomEsgotadoException(codigo string) error {
+	return cupomInvalido("cupom", fmt.Sprintf("O cupom %s atingiu o limite de usos", codigo))
+}
+
+func cupomInvalido(campo, msg string) error {
+	return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: campo, Mensagem: msg})
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// EnderecoUsuarioService gerencia os endereços salvos dos clientes. O cliente com endereços
+// sempre tem um padrão, usado nos pedidos emitidos sem endereço.
+type EnderecoUsuarioService struct {
+	repo      repository.EnderecoUsuarioRepository
+	cidadeSvc *CidadeService
+}
+
+func NewEnderecoUsuarioService(repo repository.EnderecoUsuarioRepository, cidadeSvc *CidadeService) *EnderecoUsuarioService {
+	return &EnderecoUsuarioService{
+		repo:      repo,
+		cidadeSvc: cidadeSvc,
+	}
+}
+
+func (s *EnderecoUsuarioService) FindAllByUsuario(usuarioID uint64) ([]model.EnderecoUsuario, error) {
+	return s.repo.FindAllByUsuario(usuarioID)
+}
+
+func (s *EnderecoUsuarioService) FindByID(usuarioID, enderecoID uint64) (*model.EnderecoUsuario, error) {
+	endereco, err := s.repo.FindByID(usuarioID, enderecoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewEnderecoUsuarioNaoEncontradoException(enderecoID)
+		}
+		return nil, err
+	}
+	return endereco, nil
+}
+
+// Save valida e grava o endereço do usuário. O primeiro endereço vira o padrão, e o padrão
+// atual só deixa de ser padrão quando outro endereço é marcado.
+func (s *EnderecoUsuarioService) Save(usuarioID uint64, endereco *model.EnderecoUsuario) error {
+	cidade, err := s.cidadeSvc.FindByID(endereco.Endereco.CidadeID)
+	if err != nil {
+		return err
+	}
+	endereco.Endereco.Cidade = *cidade
+	endereco.UsuarioID = usuarioID
+	endereco.Apelido = strings.TrimSpace(endereco.Apelido)
+
+	padraoAtual, err := s.repo.FindPadrao(usuarioID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if padraoAtual == nil || padraoAtual.ID == endereco.ID {
+		endereco.Padrao = true
+	}
+
+	return s.repo.Save(endereco)
+}
+
+// DefinirPadrao marca o endereço como padrão do usuário
+func (s *EnderecoUsuarioService) DefinirPadrao(usuarioID, enderecoID uint64) error {
+	endereco, err := s.FindByID(usuarioID, enderecoID)
+	if err != nil {
+		return err
+	}
+	endereco.Padrao = true
+	return s.repo.Save(endereco)
+}
+
+func (s *EnderecoUsuarioService) Excluir(usuarioID, enderecoID uint64) error {
+	if _, err := s.FindByID(usuarioID, enderecoID); err != nil {
+		return err
+	}
+	return s.repo.Delete(usuarioID, enderecoID)
+}
+
+// ParaPedido retorna o endereço salvo informado no pedido ou, sem enderecoID, o padrão do cliente
+func (s *EnderecoUsuarioService) ParaPedido(usuarioID uint64, enderecoID *uint64) (*model.EnderecoUsuario, error) {
+	if enderecoID != nil {
+		return s.FindByID(usuarioID, *enderecoID)
+	}
+
+	endereco, err := s.repo.FindPadrao(usuarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			msg := "Informe o endereco de entrega ou cadastre um endereco padrao"
+			return nil, exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "enderecoEntrega", Mensagem: msg})
+		}
+		return nil, err
+	}
+	return endereco, nil
+}
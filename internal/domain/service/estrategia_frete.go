@@ -0,0 +1,206 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+)
+
+// raioTerraKm é o mesmo raio usado pelo ST_Distance_Sphere do MySQL na busca por localização
+const raioTerraKm = 6370.986
+
+// SolicitacaoFrete descreve a entrega a ser cotada
+type SolicitacaoFrete struct {
+	CidadeID  uint64
+	Bairro    string
+	Latitude  *float64
+	Longitude *float64
+	Subtotal  decimal.Decimal
+}
+
+// EstrategiaFrete calcula o frete de um tipo de configuração. Entregas que a estratégia
+// não consegue atender devem ser rejeitadas com NegocioException.
+type EstrategiaFrete interface {
+	Tipo() model.TipoFrete
+	// Validar verifica a configuração antes de gravá-la
+	Validar(restaurante *model.Restaurante, configuracao *model.ConfiguracaoFrete) error
+	Calcular(restaurante *model.Restaurante, configuracao *model.ConfiguracaoFrete, solicitacao *SolicitacaoFrete) (*model.CotacaoFrete, error)
+}
+
+// FreteFixoEstrategia cobra a TaxaFrete do restaurante em qualquer entrega
+type FreteFixoEstrategia struct{}
+
+func NewFreteFixoEstrategia() *FreteFixoEstrategia {
+	return &FreteFixoEstrategia{}
+}
+
+func (FreteFixoEstrategia) Tipo() model.TipoFrete {
+	return model.TipoFreteFixo
+}
+
+func (FreteFixoEstrategia) Validar(*model.Restaurante, *model.ConfiguracaoFrete) error {
+	return nil
+}
+
+func (FreteFixoEstrategia) Calcular(restaurante *model.Restaurante, _ *model.ConfiguracaoFrete, _ *SolicitacaoFrete) (*model.CotacaoFrete, error) {
+	return freteFixo(restaurante, "Taxa de entrega fixa do restaurante"), nil
+}
+
+func freteFixo(restaurante *model.Restaurante, descricao string) *model.CotacaoFrete {
+	return &model.CotacaoFrete{
+		Taxa:      restaurante.TaxaFrete,
+		Regra:     string(model.TipoFreteFixo),
+		Descricao: descricao,
+	}
+}
+
+// FreteLocalidadeEstrategia usa a tabela por bairro ou cidade. O bairro tem precedência
+// sobre a cidade inteira; localidades fora da tabela pagam a TaxaFrete do restaurante.
+type FreteLocalidadeEstrategia struct{}
+
+func NewFreteLocalidadeEstrategia() *FreteLocalidadeEstrategia {
+	return &FreteLocalidadeEstrategia{}
+}
+
+func (FreteLocalidadeEstrategia) Tipo() model.TipoFrete {
+	return model.TipoFreteLocalidade
+}
+
+func (FreteLocalidadeEstrategia) Validar(_ *model.Restaurante, configuracao *model.ConfiguracaoFrete) error {
+	if len(configuracao.Localidades) == 0 {
+		return exception.NewNegocioExceptionComCampos("Informe ao menos uma localidade para o frete por localidade",
+			exception.CampoInvalido{Nome: "localidades", Mensagem: "Informe ao menos uma localidade"})
+	}
+
+	vistas := make(map[string]bool, len(configuracao.Localidades))
+	for _, localidade := range configuracao.Localidades {
+		chave := fmt.Sprintf("%d:%s", localidade.CidadeID, strings.ToLower(localidade.Bairro))
+		if vistas[chave] {
+			msg := fmt.Sprintf("A localidade %s esta duplicada na tabela de frete", descreverLocalidade(&localidade))
+			return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "localidades", Mensagem: msg})
+		}
+		vistas[chave] = true
+	}
+	return nil
+}
+
+func (FreteLocalidadeEstrategia) Calcular(restaurante *model.Restaurante, configuracao *model.ConfiguracaoFrete, solicitacao *SolicitacaoFrete) (*model.CotacaoFrete, error) {
+	bairro := strings.TrimSpace(solicitacao.Bairro)
+
+	var cidadeInteira *model.FreteLocalidade
+	for i := range configuracao.Localidades {
+		localidade := &configuracao.Localidades[i]
+		if localidade.CidadeID != solicitacao.CidadeID {
+			continue
+		}
+		if localidade.Bairro == "" {
+			cidadeInteira = localidade
+		} else if bairro != "" && strings.EqualFold(localidade.Bairro, bairro) {
+			return cotacaoLocalidade(localidade), nil
+		}
+	}
+
+	if cidadeInteira != nil {
+		return cotacaoLocalidade(cidadeInteira), nil
+	}
+	return freteFixo(restaurante, "Taxa de entrega fixa do restaurante (localidade fora da tabela de frete)"), nil
+}
+
+func cotacaoLocalidade(localidade *model.FreteLocalidade) *model.CotacaoFrete {
+	return &model.CotacaoFrete{
+		Taxa:      localidade.Taxa,
+		Regra:     string(model.TipoFreteLocalidade),
+		Descricao: "Tabela de frete: " + descreverLocalidade(localidade),
+	}
+}
+
+func descreverLocalidade(localidade *model.FreteLocalidade) string {
+	cidade := localidade.Cidade.Nome
+	if cidade == "" {
+		cidade = fmt.Sprintf("cidade %d", localidade.CidadeID)
+	}
+	if localidade.Bairro == "" {
+		return cidade
+	}
+	return localidade.Bairro + ", " + cidade
+}
+
+// FreteDistanciaEstrategia cobra pela faixa de distância (em linha reta) entre o
+// restaurante e a entrega. Exige coordenadas nos dois endereços.
+type FreteDistanciaEstrategia struct{}
+
+func NewFreteDistanciaEstrategia() *FreteDistanciaEstrategia {
+	return &FreteDistanciaEstrategia{}
+}
+
+func (FreteDistanciaEstrategia) Tipo() model.TipoFrete {
+	return model.TipoFreteDistancia
+}
+
+func (FreteDistanciaEstrategia) Validar(restaurante *model.Restaurante, configuracao *model.ConfiguracaoFrete) error {
+	if !restaurante.Endereco.PossuiCoordenadas() {
+		return exception.NewNegocioException("Informe latitude e longitude no endereco do restaurante para usar o frete por distancia")
+	}
+	if len(configuracao.Faixas) == 0 {
+		return exception.NewNegocioExceptionComCampos("Informe ao menos uma faixa para o frete por distancia",
+			exception.CampoInvalido{Nome: "faixas", Mensagem: "Informe ao menos uma faixa"})
+	}
+
+	// A distância é gravada como DECIMAL(6,2): faixas iguais após o arredondamento violariam a chave única
+	vistas := make(map[string]bool, len(configuracao.Faixas))
+	for _, faixa := range configuracao.Faixas {
+		chave := decimal.NewFromFloat(faixa.DistanciaMaxKm).Round(2).StringFixed(2)
+		if vistas[chave] {
+			msg := fmt.Sprintf("A faixa de %s km esta duplicada", chave)
+			return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "faixas", Mensagem: msg})
+		}
+		vistas[chave] = true
+	}
+	return nil
+}
+
+func (FreteDistanciaEstrategia) Calcular(restaurante *model.Restaurante, configuracao *model.ConfiguracaoFrete, solicitacao *SolicitacaoFrete) (*model.CotacaoFrete, error) {
+	if solicitacao.Latitude == nil || solicitacao.Longitude == nil {
+		msg := "Informe latitude e longitude do endereco de entrega para calcular o frete deste restaurante"
+		return nil, exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "enderecoEntrega", Mensagem: msg})
+	}
+	if !restaurante.Endereco.PossuiCoordenadas() {
+		return nil, exception.NewNegocioException("O restaurante nao possui coordenadas para calcular o frete por distancia")
+	}
+
+	distancia := distanciaKm(*restaurante.Endereco.Latitude, *restaurante.Endereco.Longitude,
+		*solicitacao.Latitude, *solicitacao.Longitude)
+
+	faixas := append([]model.FaixaFreteDistancia(nil), configuracao.Faixas...)
+	sort.Slice(faixas, func(i, j int) bool { return faixas[i].DistanciaMaxKm < faixas[j].DistanciaMaxKm })
+
+	for _, faixa := range faixas {
+		if distancia <= faixa.DistanciaMaxKm {
+			return &model.CotacaoFrete{
+				Taxa:        faixa.Taxa,
+				Regra:       string(model.TipoFreteDistancia),
+				Descricao:   fmt.Sprintf("Faixa de distancia ate %.2f km (entrega a %.2f km)", faixa.DistanciaMaxKm, distancia),
+				DistanciaKm: &distancia,
+			}, nil
+		}
+	}
+
+	msg := fmt.Sprintf("O endereco de entrega esta a %.2f km, alem do alcance de entrega do restaurante (%.2f km)",
+		distancia, faixas[len(faixas)-1].DistanciaMaxKm)
+	return nil, exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "enderecoEntrega", Mensagem: msg})
+}
+
+// distanciaKm calcula a distância em linha reta (haversine) entre dois pontos
+func distanciaKm(lat1, lng1, lat2, lng2 float64) float64 {
+	const grau = math.Pi / 180
+	dLat := (lat2 - lat1) * grau
+	dLng := (lng2 - lng1) * grau
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1*grau)*math.Cos(lat2*grau)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * raioTerraKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/event"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/eventbridge"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultOutboxBatchSize     = 50
+	defaultOutboxMaxTentativas = 10
+	defaultOutboxBackoffBase   = 5 * time.Second
+	defaultOutboxBackoffMax    = time.Hour
+
+	// Tempo em que um lote reservado fica fora da fila enquanto é publicado
+	outboxReserva = 2 * time.Minute
+)
+
+// EventoOutboxService publica os eventos registrados no outbox e permite reprocessá-los
+type EventoOutboxService struct {
+	repo           repository.EventoOutboxRepository
+	eventPublisher eventbridge.EventPublisher
+	batchSize      int
+	maxTentativas  int
+	backoffBase    time.Duration
+	backoffMax     time.Duration
+}
+
+func NewEventoOutboxService(repo repository.EventoOutboxRepository, eventPublisher eventbridge.EventPublisher, cfg *config.OutboxConfig) *EventoOutboxService {
+	s := &EventoOutboxService{
+		repo:           repo,
+		eventPublisher: eventPublisher,
+		batchSize:      cfg.BatchSize,
+		maxTentativas:  cfg.MaxTentativas,
+		backoffBase:    time.Duration(cfg.BackoffBaseSeconds) * time.Second,
+		backoffMax:     time.Duration(cfg.BackoffMaxSeconds) * time.Second,
+	}
+	if s.batchSize <= 0 {
+		s.batchSize = defaultOutboxBatchSize
+	}
+	if s.maxTentativas <= 0 {
+		s.maxTentativas = defaultOutboxMaxTentativas
+	}
+	if s.backoffBase <= 0 {
+		s.backoffBase = defaultOutboxBackoffBase
+	}
+	if s.backoffMax <= 0 {
+		s.backoffMax = defaultOutboxBackoffMax
+	}
+	return s
+}
+
+// NovoEventoOutbox serializa um evento de domínio para ser gravado no outbox
+func NovoEventoOutbox(evt event.DomainEvent) (*model.EventoOutbox, error) {
+	payload, err := json.Marshal(evt)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao serializar evento %s: %w", evt.EventType(), err)
+	}
+
+	return &model.EventoOutbox{
+		Tipo:             evt.EventType(),
+		Payload:          string(payload),
+		Status:           model.StatusEventoOutboxPendente,
+		DataOcorrencia:   evt.OccurredAt(),
+		ProximaTentativa: time.Now(),
+	}, nil
+}
+
+// PublicarPendentes publica um lote de eventos pendentes e retorna quantos foram publicados.
+// O lote é reservado antes, então várias instâncias da API podem rodar o relay ao mesmo tempo.
+func (s *EventoOutboxService) PublicarPendentes(ctx context.Context) (int, error) {
+	eventos, err := s.repo.ReservarPendentes(s.batchSize, outboxReserva)
+	if err != nil {
+		return 0, err
+	}
+
+	publicados := 0
+	for i := range eventos {
+		evento := &eventos[i]
+
+		evt := event.RawEvent{
+			Type:      evento.Tipo,
+			Payload:   json.RawMessage(evento.Payload),
+			Timestamp: evento.DataOcorrencia,
+		}
+
+		if err := s.eventPublisher.Publish(ctx, evt); err != nil {
+			evento.RegistrarFalha(err, s.maxTentativas, s.backoffBase, s.backoffMax)
+			if evento.Status == model.StatusEventoOutboxFalhou {
+				log.Printf("Evento de outbox %d (%s) movido para FALHOU apos %d tentativas: %v",
+					evento.ID, evento.Tipo, evento.Tentativas, err)
+			} else {
+				log.Printf("Aviso: Falha ao publicar evento de outbox %d (%s), tentativa %d: %v",
+					evento.ID, evento.Tipo, evento.Tentativas, err)
+			}
+		} else {
+			evento.MarcarPublicado()
+			publicados++
+		}
+
+		if err := s.repo.Save(evento); err != nil {
+			return publicados, err
+		}
+	}
+
+	return publicados, nil
+}
+
+func (s *EventoOutboxService) Pesquisar(status *model.StatusEventoOutbox, page *pagination.Pageable) (*pagination.Page[model.EventoOutbox], error) {
+	return s.repo.FindAll(status, page)
+}
+
+func (s *EventoOutboxService) FindByID(id uint64) (*model.EventoOutbox, error) {
+	evento, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewEventoOutboxNaoEncontradoException(id)
+		}
+		return nil, err
+	}
+	return evento, nil
+}
+
+// Reprocessar devolve o evento para a fila de publicação
+func (s *EventoOutboxService) Reprocessar(id uint64) error {
+	evento, err := s.FindByID(id)
+	if err != nil {
+		return err
+	}
+
+	if evento.Status == model.StatusEventoOutboxPublicado {
+		return exception.NewNegocioException(fmt.Sprintf("Evento de outbox %d ja foi publicado", id))
+	}
+
+	evento.Reprocessar()
+	return s.repo.Save(evento)
+}
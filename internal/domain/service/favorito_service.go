@@ -0,0 +1,54 @@
+package service
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+)
+
+// FavoritoService gerencia os restaurantes e produtos favoritos dos clientes
+type FavoritoService struct {
+	repo           repository.FavoritoRepository
+	restauranteSvc *RestauranteService
+	produtoSvc     *ProdutoService
+}
+
+func NewFavoritoService(repo repository.FavoritoRepository, restauranteSvc *RestauranteService, produtoSvc *ProdutoService) *FavoritoService {
+	return &FavoritoService{
+		repo:           repo,
+		restauranteSvc: restauranteSvc,
+		produtoSvc:     produtoSvc,
+	}
+}
+
+func (s *FavoritoService) FindRestaurantes(usuarioID uint64) ([]model.Restaurante, error) {
+	return s.repo.FindRestaurantes(usuarioID)
+}
+
+func (s *FavoritoService) FindProdutos(usuarioID uint64) ([]model.Produto, error) {
+	return s.repo.FindProdutos(usuarioID)
+}
+
+func (s *FavoritoService) FavoritarRestaurante(usuarioID, restauranteID uint64) error {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return err
+	}
+	return s.repo.AdicionarRestaurante(usuarioID, restauranteID)
+}
+
+func (s *FavoritoService) DesfavoritarRestaurante(usuarioID, restauranteID uint64) error {
+	return s.repo.RemoverRestaurante(usuarioID, restauranteID)
+}
+
+func (s *FavoritoService) FavoritarProduto(usuarioID, restauranteID, produtoID uint64) error {
+	if _, err := s.produtoSvc.FindByID(restauranteID, produtoID); err != nil {
+		return err
+	}
+	return s.repo.AdicionarProduto(usuarioID, produtoID)
+}
+
+func (s *FavoritoService) DesfavoritarProduto(usuarioID, restauranteID, produtoID uint64) error {
+	if _, err := s.produtoSvc.FindByID(restauranteID, produtoID); err != nil {
+		return err
+	}
+	return s.repo.RemoverProduto(usuarioID, produtoID)
+}
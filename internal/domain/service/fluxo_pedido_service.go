@@ -2,46 +2,112 @@ package service
 
 import (
 	"context"
+	"log"
+	"net/http"
 
 	"github.com/yurisasc/algafood-go/internal/domain/event"
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/repository"
-	"github.com/yurisasc/algafood-go/internal/infrastructure/eventbridge"
 )
 
+// FluxoPedidoService altera o status dos pedidos. Os eventos de domínio são gravados
+// no outbox na mesma transação do pedido e publicados depois pelo relay; a mudança
+// também é enviada na hora aos streams de acompanhamento. Os pagamentos confirmam ou
+// cancelam os pedidos criados, e o cancelamento estorna o valor já capturado (o estorno
+// que falhar é refeito pelo scheduler de estornos).
 type FluxoPedidoService struct {
-	pedidoRepo     repository.PedidoRepository
-	pedidoSvc      *PedidoService
-	eventPublisher eventbridge.EventPublisher
+	pedidoRepo   repository.PedidoRepository
+	pedidoSvc    *PedidoService
+	streamSvc    *PedidoStreamService
+	pagamentoSvc *PagamentoService
 }
 
 func NewFluxoPedidoService(
 	pedidoRepo repository.PedidoRepository,
 	pedidoSvc *PedidoService,
-	eventPublisher eventbridge.EventPublisher,
+	streamSvc *PedidoStreamService,
+	pagamentoSvc *PagamentoService,
 ) *FluxoPedidoService {
 	return &FluxoPedidoService{
-		pedidoRepo:     pedidoRepo,
-		pedidoSvc:      pedidoSvc,
-		eventPublisher: eventPublisher,
+		pedidoRepo:   pedidoRepo,
+		pedidoSvc:    pedidoSvc,
+		streamSvc:    streamSvc,
+		pagamentoSvc: pagamentoSvc,
 	}
 }
 
-func (s *FluxoPedidoService) Confirmar(codigoPedido string) error {
+// Pagar cobra o pedido e, com o valor capturado, confirma o pedido em nome do sistema.
+// Se a confirmação falhar (o pedido pode ter sido cancelado durante a cobrança), o valor
+// é estornado para não ficar com um pedido não confirmado e pago.
+func (s *FluxoPedidoService) Pagar(ctx context.Context, codigoPedido, token string) (*model.Pagamento, error) {
 	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
 	if err != nil {
+		return nil, err
+	}
+
+	pagamento, err := s.pagamentoSvc.Pagar(ctx, pedido, token)
+	if err != nil {
+		return nil, err
+	}
+	if pagamento.Status == model.StatusPagamentoCapturado {
+		if err := s.Confirmar(codigoPedido, 0); err != nil {
+			if errEstorno := s.pagamentoSvc.Estornar(ctx, pagamento); errEstorno != nil {
+				log.Printf("Erro ao estornar o pagamento do pedido %s apos falha na confirmacao: %v", codigoPedido, errEstorno)
+			}
+			return nil, err
+		}
+	}
+	return pagamento, nil
+}
+
+// ProcessarWebhookPagamento aplica a notificação do provedor e reconcilia o pedido com o
+// pagamento gravado, mesmo quando a notificação não muda o pagamento: se a alteração do
+// pedido falhou numa entrega anterior, o reenvio do provedor a completa. A captura confirma
+// e a recusa cancela o pedido ainda não confirmado; a captura de um pedido cancelado é estornada.
+func (s *FluxoPedidoService) ProcessarWebhookPagamento(ctx context.Context, header http.Header, body []byte) error {
+	pagamento, _, err := s.pagamentoSvc.AtualizarPorWebhook(ctx, header, body)
+	if err != nil || pagamento == nil || pagamento.Pedido == nil {
 		return err
 	}
 
-	if err := pedido.Confirmar(); err != nil {
-		return exception.NewNegocioException(err.Error())
+	pedido := pagamento.Pedido
+	// Só a tentativa mais recente decide o pedido; uma recusa antiga não cancela a nova tentativa
+	ultimo, err := s.pagamentoSvc.FindByPedido(pedido)
+	if err != nil {
+		return err
+	}
+	if ultimo.ID != pagamento.ID {
+		return nil
+	}
+
+	if pedido.Status == model.StatusPedidoCancelado {
+		return s.pagamentoSvc.Estornar(ctx, pagamento)
 	}
+	if pedido.Status != model.StatusPedidoCriado {
+		return nil
+	}
+	switch pagamento.Status {
+	case model.StatusPagamentoCapturado:
+		return s.Confirmar(pedido.Codigo, 0)
+	case model.StatusPagamentoRecusado:
+		return s.Cancelar(pedido.Codigo, 0, "Pagamento recusado")
+	}
+	return nil
+}
 
-	if err := s.pedidoRepo.Save(pedido); err != nil {
+func (s *FluxoPedidoService) Confirmar(codigoPedido string, usuarioID uint64) error {
+	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
 		return err
 	}
 
-	// Publish domain event
+	anterior := pedido.Status
+	if err := pedido.Confirmar(); err != nil {
+		return exception.NewNegocioException(err.Error())
+	}
+
+	// Registra o evento de domínio no outbox
 	evt := event.NewPedidoConfirmadoEvent(
 		pedido.Codigo,
 		pedido.Cliente.ID,
@@ -53,30 +119,76 @@ func (s *FluxoPedidoService) Confirmar(codigoPedido string) error {
 		*pedido.DataConfirmacao,
 	)
 
-	if err := s.eventPublisher.Publish(context.Background(), evt); err != nil {
-		// Log error but don't fail the operation
-		// In production, consider using a retry mechanism or dead letter queue
-		return nil
+	return s.salvarComEvento(pedido, model.NewPedidoStatusHistorico(pedido, &anterior, usuarioID, ""), evt)
+}
+
+func (s *FluxoPedidoService) IniciarPreparacao(codigoPedido string, usuarioID uint64) error {
+	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	anterior := pedido.Status
+	if err := pedido.IniciarPreparacao(); err != nil {
+		return exception.NewNegocioException(err.Error())
+	}
+
+	// Registra o evento de domínio no outbox
+	evt := event.NewPedidoEmPreparacaoEvent(
+		pedido.Codigo,
+		pedido.Cliente.ID,
+		pedido.Cliente.Nome,
+		pedido.Cliente.Email,
+		pedido.Restaurante.ID,
+		pedido.Restaurante.Nome,
+		pedido.ValorTotal,
+		*pedido.DataPreparacao,
+	)
+
+	return s.salvarComEvento(pedido, model.NewPedidoStatusHistorico(pedido, &anterior, usuarioID, ""), evt)
 }
 
-func (s *FluxoPedidoService) Cancelar(codigoPedido string) error {
+func (s *FluxoPedidoService) SairParaEntrega(codigoPedido string, usuarioID uint64) error {
 	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
 	if err != nil {
 		return err
 	}
 
-	if err := pedido.Cancelar(); err != nil {
+	anterior := pedido.Status
+	if err := pedido.SairParaEntrega(); err != nil {
 		return exception.NewNegocioException(err.Error())
 	}
 
-	if err := s.pedidoRepo.Save(pedido); err != nil {
+	// Registra o evento de domínio no outbox
+	evt := event.NewPedidoSaiuParaEntregaEvent(
+		pedido.Codigo,
+		pedido.Cliente.ID,
+		pedido.Cliente.Nome,
+		pedido.Cliente.Email,
+		pedido.Restaurante.ID,
+		pedido.Restaurante.Nome,
+		pedido.ValorTotal,
+		*pedido.DataSaidaEntrega,
+	)
+
+	return s.salvarComEvento(pedido, model.NewPedidoStatusHistorico(pedido, &anterior, usuarioID, ""), evt)
+}
+
+// Cancelar cancela o pedido e estorna o valor capturado. O motivo é opcional e fica
+// registrado no histórico. O cancelamento é gravado antes do estorno: se o estorno falhar,
+// o pedido continua cancelado e o scheduler de estornos tenta de novo.
+func (s *FluxoPedidoService) Cancelar(codigoPedido string, usuarioID uint64, motivo string) error {
+	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
+	if err != nil {
 		return err
 	}
 
-	// Publish domain event
+	anterior := pedido.Status
+	if err := pedido.Cancelar(); err != nil {
+		return exception.NewNegocioException(err.Error())
+	}
+
+	// Registra o evento de domínio no outbox
 	evt := event.NewPedidoCanceladoEvent(
 		pedido.Codigo,
 		pedido.Cliente.ID,
@@ -86,31 +198,31 @@ func (s *FluxoPedidoService) Cancelar(codigoPedido string) error {
 		pedido.Restaurante.Nome,
 		pedido.ValorTotal,
 		*pedido.DataCancelamento,
+		motivo,
 	)
 
-	if err := s.eventPublisher.Publish(context.Background(), evt); err != nil {
-		// Log error but don't fail the operation
-		return nil
+	if err := s.salvarComEvento(pedido, model.NewPedidoStatusHistorico(pedido, &anterior, usuarioID, motivo), evt); err != nil {
+		return err
 	}
 
+	if err := s.pagamentoSvc.EstornarPedido(context.Background(), pedido); err != nil {
+		log.Printf("Erro ao estornar o pagamento do pedido cancelado %s; o estorno sera refeito: %v", pedido.Codigo, err)
+	}
 	return nil
 }
 
-func (s *FluxoPedidoService) Entregar(codigoPedido string) error {
+func (s *FluxoPedidoService) Entregar(codigoPedido string, usuarioID uint64) error {
 	pedido, err := s.pedidoSvc.FindByCodigo(codigoPedido)
 	if err != nil {
 		return err
 	}
 
+	anterior := pedido.Status
 	if err := pedido.Entregar(); err != nil {
 		return exception.NewNegocioException(err.Error())
 	}
 
-	if err := s.pedidoRepo.Save(pedido); err != nil {
-		return err
-	}
-
-	// Publish domain event
+	// Registra o evento de domínio no outbox
 	evt := event.NewPedidoEntregueEvent(
 		pedido.Codigo,
 		pedido.Cliente.ID,
@@ -122,10 +234,26 @@ func (s *FluxoPedidoService) Entregar(codigoPedido string) error {
 		*pedido.DataEntrega,
 	)
 
-	if err := s.eventPublisher.Publish(context.Background(), evt); err != nil {
-		// Log error but don't fail the operation
-		return nil
+	return s.salvarComEvento(pedido, model.NewPedidoStatusHistorico(pedido, &anterior, usuarioID, ""), evt)
+}
+
+// salvarComEvento grava o pedido, o histórico de status e o evento no outbox atomicamente.
+// Se outra requisição mudou o status depois da leitura, nada é gravado e o conflito é retornado.
+func (s *FluxoPedidoService) salvarComEvento(pedido *model.Pedido, historico *model.PedidoStatusHistorico, evt event.DomainEvent) error {
+	evento, err := NovoEventoOutbox(evt)
+	if err != nil {
+		return err
+	}
+	gravado, err := s.pedidoRepo.SaveComEvento(pedido, historico, evento)
+	if err != nil {
+		return err
+	}
+	if !gravado {
+		return exception.NewStatusPedidoAlteradoException(pedido.Codigo)
 	}
 
+	if s.streamSvc != nil {
+		s.streamSvc.Publicar(pedido)
+	}
 	return nil
 }
@@ -0,0 +1,113 @@
+package service
+
+import (
+	"errors"
+	"io"
+	"log"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/storage"
+	"gorm.io/gorm"
+)
+
+type FotoProdutoService struct {
+	repo       repository.FotoProdutoRepository
+	produtoSvc *ProdutoService
+	storage    storage.StorageService
+}
+
+func NewFotoProdutoService(repo repository.FotoProdutoRepository, produtoSvc *ProdutoService, storage storage.StorageService) *FotoProdutoService {
+	return &FotoProdutoService{
+		repo:       repo,
+		produtoSvc: produtoSvc,
+		storage:    storage,
+	}
+}
+
+func (s *FotoProdutoService) FindByID(restauranteID, produtoID uint64) (*model.FotoProduto, error) {
+	produto, err := s.produtoSvc.FindByID(restauranteID, produtoID)
+	if err != nil {
+		return nil, err
+	}
+
+	foto, err := s.repo.FindByProdutoID(produto.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewFotoProdutoNaoEncontradaException(restauranteID, produtoID)
+		}
+		return nil, err
+	}
+	foto.Produto = *produto
+	return foto, nil
+}
+
+// Salvar armazena o novo arquivo e só então substitui o registro da foto.
+// O arquivo anterior é removido apenas depois que o novo registro foi salvo,
+// e o novo arquivo é descartado se o registro não puder ser salvo.
+func (s *FotoProdutoService) Salvar(restauranteID, produtoID uint64, foto *model.FotoProduto, conteudo io.Reader) error {
+	produto, err := s.produtoSvc.FindByID(restauranteID, produtoID)
+	if err != nil {
+		return err
+	}
+
+	var nomeArquivoAntigo string
+	existente, err := s.repo.FindByProdutoID(produto.ID)
+	if err == nil {
+		nomeArquivoAntigo = existente.NomeArquivo
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	nomeArquivo, err := s.storage.Store(foto.NomeArquivo, foto.ContentType, conteudo)
+	if err != nil {
+		return err
+	}
+
+	foto.ID = produto.ID
+	foto.ProdutoID = produto.ID
+	foto.Produto = *produto
+	foto.NomeArquivo = nomeArquivo
+
+	if err := s.repo.Save(foto); err != nil {
+		if delErr := s.storage.Delete(nomeArquivo); delErr != nil {
+			log.Printf("Aviso: Falha ao remover arquivo %s apos erro ao salvar foto: %v", nomeArquivo, delErr)
+		}
+		return err
+	}
+
+	if nomeArquivoAntigo != "" && nomeArquivoAntigo != nomeArquivo {
+		if err := s.storage.Delete(nomeArquivoAntigo); err != nil {
+			log.Printf("Aviso: Falha ao remover arquivo antigo %s: %v", nomeArquivoAntigo, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *FotoProdutoService) Excluir(restauranteID, produtoID uint64) error {
+	foto, err := s.FindByID(restauranteID, produtoID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(foto.ProdutoID); err != nil {
+		return err
+	}
+
+	return s.storage.Delete(foto.NomeArquivo)
+}
+
+// Recuperar abre o conteúdo do arquivo da foto
+func (s *FotoProdutoService) Recuperar(foto *model.FotoProduto) (io.ReadCloser, error) {
+	return s.storage.Retrieve(foto.NomeArquivo)
+}
+
+// URL retorna a URL pública da foto quando o armazenamento é remoto
+func (s *FotoProdutoService) URL(foto *model.FotoProduto) (string, bool) {
+	if !s.storage.IsRemote() {
+		return "", false
+	}
+	return s.storage.GetURL(foto.NomeArquivo), true
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// FreteService calcula o frete das entregas com a estratégia configurada em cada
+// restaurante. As estratégias disponíveis são registradas na criação do serviço.
+type FreteService struct {
+	repo           repository.FreteRepository
+	restauranteSvc *RestauranteService
+	cidadeSvc      *CidadeService
+	estrategias    map[model.TipoFrete]EstrategiaFrete
+}
+
+func NewFreteService(
+	repo repository.FreteRepository,
+	restauranteSvc *RestauranteService,
+	cidadeSvc *CidadeService,
+	estrategias ...EstrategiaFrete,
+) *FreteService {
+	s := &FreteService{
+		repo:           repo,
+		restauranteSvc: restauranteSvc,
+		cidadeSvc:      cidadeSvc,
+		estrategias:    make(map[model.TipoFrete]EstrategiaFrete, len(estrategias)),
+	}
+	for _, estrategia := range estrategias {
+		s.estrategias[estrategia.Tipo()] = estrategia
+	}
+	return s
+}
+
+// BuscarConfiguracao retorna a configuração de frete do restaurante
+func (s *FreteService) BuscarConfiguracao(restauranteID uint64) (*model.ConfiguracaoFrete, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	return s.configuracao(restauranteID)
+}
+
+// SalvarConfiguracao valida e grava a configuração de frete, substituindo a anterior
+func (s *FreteService) SalvarConfiguracao(restauranteID uint64, configuracao *model.ConfiguracaoFrete) error {
+	restaurante, err := s.restauranteSvc.FindByID(restauranteID)
+	if err != nil {
+		return err
+	}
+
+	estrategia, ok := s.estrategias[configuracao.Tipo]
+	if !ok {
+		return exception.NewNegocioException(fmt.Sprintf("Tipo de frete %s nao suportado", configuracao.Tipo))
+	}
+
+	for i := range configuracao.Localidades {
+		localidade := &configuracao.Localidades[i]
+		cidade, err := s.cidadeSvc.FindByID(localidade.CidadeID)
+		if err != nil {
+			return err
+		}
+		localidade.Cidade = *cidade
+		localidade.Bairro = strings.TrimSpace(localidade.Bairro)
+	}
+
+	if err := estrategia.Validar(restaurante, configuracao); err != nil {
+		return err
+	}
+
+	configuracao.RestauranteID = restauranteID
+	return s.repo.SaveConfiguracao(configuracao)
+}
+
+// Simular cota o frete de uma entrega antes da emissão do pedido
+func (s *FreteService) Simular(restauranteID uint64, solicitacao *SolicitacaoFrete) (*model.CotacaoFrete, error) {
+	restaurante, err := s.restauranteSvc.FindByID(restauranteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.cidadeSvc.FindByID(solicitacao.CidadeID); err != nil {
+		return nil, err
+	}
+	atende, err := s.restauranteSvc.AtendeCidade(restaurante, solicitacao.CidadeID)
+	if err != nil {
+		return nil, err
+	}
+	if !atende {
+		return nil, exception.NewNegocioException("O restaurante nao entrega na cidade informada")
+	}
+
+	return s.Cotar(restaurante, solicitacao)
+}
+
+// Cotar calcula o frete pela estratégia do restaurante e aplica o frete grátis
+// quando o subtotal atinge o mínimo configurado
+func (s *FreteService) Cotar(restaurante *model.Restaurante, solicitacao *SolicitacaoFrete) (*model.CotacaoFrete, error) {
+	configuracao, err := s.configuracao(restaurante.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	estrategia, ok := s.estrategias[configuracao.Tipo]
+	if !ok {
+		return nil, fmt.Errorf("estrategia de frete %s nao registrada", configuracao.Tipo)
+	}
+
+	cotacao, err := estrategia.Calcular(restaurante, configuracao, solicitacao)
+	if err != nil {
+		return nil, err
+	}
+
+	if minimo := configuracao.FreteGratisAcima; minimo != nil && cotacao.Taxa.IsPositive() &&
+		solicitacao.Subtotal.GreaterThanOrEqual(*minimo) {
+		cotacao.Descricao = fmt.Sprintf("Frete gratis para pedidos a partir de %s (%s)", minimo.StringFixed(2), cotacao.Descricao)
+		cotacao.Taxa = decimal.Zero
+		cotacao.Regra = model.RegraFreteGratis
+	}
+
+	return cotacao, nil
+}
+
+// configuracao carrega a configuração do restaurante; sem configuração o frete é fixo
+func (s *FreteService) configuracao(restauranteID uint64) (*model.ConfiguracaoFrete, error) {
+	configuracao, err := s.repo.FindConfiguracao(restauranteID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &model.ConfiguracaoFrete{RestauranteID: restauranteID, Tipo: model.TipoFreteFixo}, nil
+		}
+		return nil, err
+	}
+	return configuracao, nil
+}
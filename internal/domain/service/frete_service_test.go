@@ -0,0 +1,194 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+// freteRepositoryFake devolve a configuração informada, ou nenhuma quando nil
+type freteRepositoryFake struct {
+	configuracao *model.ConfiguracaoFrete
+}
+
+func (r *freteRepositoryFake) FindConfiguracao(restauranteID uint64) (*model.ConfiguracaoFrete, error) {
+	if r.configuracao == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.configuracao, nil
+}
+
+func (r *freteRepositoryFake) SaveConfiguracao(configuracao *model.ConfiguracaoFrete) error {
+	return nil
+}
+
+func restauranteFrete(taxa string) *model.Restaurante {
+	latitude, longitude := -23.55, -46.63
+	return &model.Restaurante{
+		ID:        1,
+		TaxaFrete: decimal.RequireFromString(taxa),
+		Endereco:  model.Endereco{Latitude: &latitude, Longitude: &longitude},
+	}
+}
+
+// entregaAoNorte cria uma entrega deslocada em latitude (1 grau ≈ 111 km)
+func entregaAoNorte(graus float64) *SolicitacaoFrete {
+	latitude, longitude := -23.55+graus, -46.63
+	return &SolicitacaoFrete{CidadeID: 1, Latitude: &latitude, Longitude: &longitude}
+}
+
+func TestFreteLocalidadeEstrategiaCalcular(t *testing.T) {
+	configuracao := &model.ConfiguracaoFrete{Tipo: model.TipoFreteLocalidade, Localidades: []model.FreteLocalidade{
+		{CidadeID: 1, Taxa: decimal.RequireFromString("6.00")},
+		{CidadeID: 1, Bairro: "Centro", Taxa: decimal.RequireFromString("3.00")},
+		{CidadeID: 2, Bairro: "Vila Nova", Taxa: decimal.RequireFromString("9.00")},
+	}}
+	restaurante := restauranteFrete("12.00")
+
+	tests := []struct {
+		name      string
+		cidadeID  uint64
+		bairro    string
+		wantTaxa  string
+		wantRegra model.TipoFrete
+	}{
+		{name: "bairro tem precedencia", cidadeID: 1, bairro: "Centro", wantTaxa: "3.00", wantRegra: model.TipoFreteLocalidade},
+		{name: "bairro sem diferenciar maiusculas", cidadeID: 1, bairro: " centro ", wantTaxa: "3.00", wantRegra: model.TipoFreteLocalidade},
+		{name: "outro bairro usa a cidade inteira", cidadeID: 1, bairro: "Jardins", wantTaxa: "6.00", wantRegra: model.TipoFreteLocalidade},
+		{name: "cidade so com bairros usa a taxa fixa", cidadeID: 2, bairro: "Centro", wantTaxa: "12.00", wantRegra: model.TipoFreteFixo},
+		{name: "cidade fora da tabela usa a taxa fixa", cidadeID: 3, wantTaxa: "12.00", wantRegra: model.TipoFreteFixo},
+	}
+
+	estrategia := NewFreteLocalidadeEstrategia()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cotacao, err := estrategia.Calcular(restaurante, configuracao, &SolicitacaoFrete{CidadeID: tt.cidadeID, Bairro: tt.bairro})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cotacao.Taxa.Equal(decimal.RequireFromString(tt.wantTaxa)) || cotacao.Regra != string(tt.wantRegra) {
+				t.Errorf("cotacao = %s (%s), esperava %s (%s)", cotacao.Taxa, cotacao.Regra, tt.wantTaxa, tt.wantRegra)
+			}
+		})
+	}
+}
+
+func TestFreteDistanciaEstrategiaCalcular(t *testing.T) {
+	configuracao := &model.ConfiguracaoFrete{Tipo: model.TipoFreteDistancia, Faixas: []model.FaixaFreteDistancia{
+		{DistanciaMaxKm: 10, Taxa: decimal.RequireFromString("9.00")},
+		{DistanciaMaxKm: 3, Taxa: decimal.RequireFromString("4.00")},
+	}}
+	restaurante := restauranteFrete("12.00")
+
+	tests := []struct {
+		name        string
+		solicitacao *SolicitacaoFrete
+		wantTaxa    string
+		wantErr     bool
+	}{
+		{name: "primeira faixa", solicitacao: entregaAoNorte(0.02), wantTaxa: "4.00"},
+		{name: "faixa seguinte", solicitacao: entregaAoNorte(0.05), wantTaxa: "9.00"},
+		{name: "alem da ultima faixa", solicitacao: entregaAoNorte(0.2), wantErr: true},
+		{name: "entrega sem coordenadas", solicitacao: &SolicitacaoFrete{CidadeID: 1}, wantErr: true},
+	}
+
+	estrategia := NewFreteDistanciaEstrategia()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cotacao, err := estrategia.Calcular(restaurante, configuracao, tt.solicitacao)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %+v", cotacao)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cotacao.Taxa.Equal(decimal.RequireFromString(tt.wantTaxa)) {
+				t.Errorf("Taxa = %s, esperava %s", cotacao.Taxa, tt.wantTaxa)
+			}
+			if cotacao.DistanciaKm == nil {
+				t.Error("cotacao sem a distancia calculada")
+			}
+		})
+	}
+}
+
+func TestFreteDistanciaEstrategiaValidar(t *testing.T) {
+	faixa := func(km float64) model.FaixaFreteDistancia {
+		return model.FaixaFreteDistancia{DistanciaMaxKm: km, Taxa: decimal.RequireFromString("5.00")}
+	}
+
+	tests := []struct {
+		name        string
+		restaurante *model.Restaurante
+		faixas      []model.FaixaFreteDistancia
+		wantErr     bool
+	}{
+		{name: "faixas distintas", restaurante: restauranteFrete("0"), faixas: []model.FaixaFreteDistancia{faixa(3), faixa(5.5)}},
+		{name: "sem faixas", restaurante: restauranteFrete("0"), wantErr: true},
+		{name: "faixa repetida", restaurante: restauranteFrete("0"), faixas: []model.FaixaFreteDistancia{faixa(3), faixa(3)}, wantErr: true},
+		{name: "iguais em centavos de km", restaurante: restauranteFrete("0"), faixas: []model.FaixaFreteDistancia{faixa(5), faixa(5.001)}, wantErr: true},
+		{name: "restaurante sem coordenadas", restaurante: &model.Restaurante{}, faixas: []model.FaixaFreteDistancia{faixa(3)}, wantErr: true},
+	}
+
+	estrategia := NewFreteDistanciaEstrategia()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := estrategia.Validar(tt.restaurante, &model.ConfiguracaoFrete{Tipo: model.TipoFreteDistancia, Faixas: tt.faixas})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validar() erro = %v, esperava erro: %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFreteServiceCotar(t *testing.T) {
+	gratisAcima := decimal.RequireFromString("50.00")
+	localidades := []model.FreteLocalidade{{CidadeID: 1, Taxa: decimal.RequireFromString("6.00")}}
+
+	tests := []struct {
+		name         string
+		configuracao *model.ConfiguracaoFrete
+		taxaFixa     string
+		subtotal     string
+		wantTaxa     string
+		wantRegra    string
+	}{
+		{name: "sem configuracao usa a taxa fixa", taxaFixa: "8.00", subtotal: "30.00", wantTaxa: "8.00", wantRegra: string(model.TipoFreteFixo)},
+		{
+			name:         "abaixo do minimo para frete gratis",
+			configuracao: &model.ConfiguracaoFrete{Tipo: model.TipoFreteLocalidade, FreteGratisAcima: &gratisAcima, Localidades: localidades},
+			taxaFixa:     "8.00", subtotal: "49.99", wantTaxa: "6.00", wantRegra: string(model.TipoFreteLocalidade),
+		},
+		{
+			name:         "a partir do minimo o frete e gratis",
+			configuracao: &model.ConfiguracaoFrete{Tipo: model.TipoFreteLocalidade, FreteGratisAcima: &gratisAcima, Localidades: localidades},
+			taxaFixa:     "8.00", subtotal: "50.00", wantTaxa: "0", wantRegra: model.RegraFreteGratis,
+		},
+		{
+			name:         "frete ja zerado mantem a regra da estrategia",
+			configuracao: &model.ConfiguracaoFrete{Tipo: model.TipoFreteFixo, FreteGratisAcima: &gratisAcima},
+			taxaFixa:     "0", subtotal: "80.00", wantTaxa: "0", wantRegra: string(model.TipoFreteFixo),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewFreteService(&freteRepositoryFake{configuracao: tt.configuracao}, nil, nil,
+				NewFreteFixoEstrategia(), NewFreteLocalidadeEstrategia(), NewFreteDistanciaEstrategia())
+
+			solicitacao := &SolicitacaoFrete{CidadeID: 1, Subtotal: decimal.RequireFromString(tt.subtotal)}
+			cotacao, err := svc.Cotar(restauranteFrete(tt.taxaFixa), solicitacao)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !cotacao.Taxa.Equal(decimal.RequireFromString(tt.wantTaxa)) || cotacao.Regra != tt.wantRegra {
+				t.Errorf("cotacao = %s (%s), esperava %s (%s)", cotacao.Taxa, cotacao.Regra, tt.wantTaxa, tt.wantRegra)
+			}
+		})
+	}
+}
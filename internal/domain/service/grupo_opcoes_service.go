@@ -0,0 +1,179 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// GrupoOpcoesService gerencia os grupos de opções dos produtos (tamanhos, adicionais)
+// e valida as opções escolhidas nos itens dos pedidos
+type GrupoOpcoesService struct {
+	repo       repository.GrupoOpcoesRepository
+	produtoSvc *ProdutoService
+}
+
+func NewGrupoOpcoesService(repo repository.GrupoOpcoesRepository, produtoSvc *ProdutoService) *GrupoOpcoesService {
+	return &GrupoOpcoesService{
+		repo:       repo,
+		produtoSvc: produtoSvc,
+	}
+}
+
+func (s *GrupoOpcoesService) FindAllByProduto(restauranteID, produtoID uint64) ([]model.GrupoOpcoes, error) {
+	if _, err := s.produtoSvc.FindByID(restauranteID, produtoID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindAllByProduto(produtoID)
+}
+
+func (s *GrupoOpcoesService) FindByID(restauranteID, produtoID, grupoID uint64) (*model.GrupoOpcoes, error) {
+	if _, err := s.produtoSvc.FindByID(restauranteID, produtoID); err != nil {
+		return nil, err
+	}
+
+	grupo, err := s.repo.FindByID(produtoID, grupoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewGrupoOpcoesNaoEncontradoException(produtoID, grupoID)
+		}
+		return nil, err
+	}
+	return grupo, nil
+}
+
+// Save valida e grava o grupo com suas opções. Opções existentes são identificadas pelo
+// ID; as que não forem informadas são removidas do grupo.
+func (s *GrupoOpcoesService) Save(restauranteID, produtoID uint64, grupo *model.GrupoOpcoes) error {
+	if _, err := s.produtoSvc.FindByID(restauranteID, produtoID); err != nil {
+		return err
+	}
+
+	grupo.Nome = strings.TrimSpace(grupo.Nome)
+	if len(grupo.Opcoes) == 0 {
+		return opcaoInvalida("opcoes", "Informe ao menos uma opcao para o grupo")
+	}
+	if grupo.MaxSelecoes < grupo.MinSelecoes {
+		return opcaoInvalida("maxSelecoes", "O maximo de selecoes deve ser maior ou igual ao minimo")
+	}
+	// Só as opções ativas podem ser escolhidas nos pedidos
+	ativas := 0
+	for _, opcao := range grupo.Opcoes {
+		if opcao.Ativo {
+			ativas++
+		}
+	}
+	if grupo.MinSelecoes > ativas {
+		return opcaoInvalida("minSelecoes", fmt.Sprintf("O grupo exige %d selecoes, mas possui apenas %d opcoes ativas",
+			grupo.MinSelecoes, ativas))
+	}
+
+	// Opções com ID precisam pertencer ao grupo que está sendo alterado
+	existentes := make(map[uint64]bool)
+	if grupo.ID != 0 {
+		atual, err := s.repo.FindByID(produtoID, grupo.ID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return exception.NewGrupoOpcoesNaoEncontradoException(produtoID, grupo.ID)
+			}
+			return err
+		}
+		for _, opcao := range atual.Opcoes {
+			existentes[opcao.ID] = true
+		}
+	}
+
+	nomes := make(map[string]bool, len(grupo.Opcoes))
+	for i := range grupo.Opcoes {
+		opcao := &grupo.Opcoes[i]
+		opcao.Nome = strings.TrimSpace(opcao.Nome)
+		if opcao.ID != 0 && !existentes[opcao.ID] {
+			return opcaoInvalida("opcoes", fmt.Sprintf("A opcao de codigo %d nao pertence a este grupo", opcao.ID))
+		}
+		chave := strings.ToLower(opcao.Nome)
+		if nomes[chave] {
+			return opcaoInvalida("opcoes", fmt.Sprintf("A opcao %s esta duplicada no grupo", opcao.Nome))
+		}
+		nomes[chave] = true
+	}
+
+	grupo.ProdutoID = produtoID
+	return s.repo.Save(grupo)
+}
+
+func (s *GrupoOpcoesService) Excluir(restauranteID, produtoID, grupoID uint64) error {
+	grupo, err := s.FindByID(restauranteID, produtoID, grupoID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(grupo.ID)
+}
+
+// SelecionarOpcoes valida as opções escolhidas para um item do produto (informadas pelo
+// OpcaoID) contra os limites de cada grupo e devolve a cópia de nome, grupo e preço
+// que fica gravada no pedido
+func (s *GrupoOpcoesService) SelecionarOpcoes(produto *model.Produto, escolhidas []model.ItemPedidoOpcao) ([]model.ItemPedidoOpcao, error) {
+	grupos, err := s.repo.FindAllByProduto(produto.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	type opcaoDoGrupo struct {
+		grupo *model.GrupoOpcoes
+		opcao *model.OpcaoProduto
+	}
+	opcoes := make(map[uint64]opcaoDoGrupo)
+	for i := range grupos {
+		grupo := &grupos[i]
+		for j := range grupo.Opcoes {
+			opcoes[grupo.Opcoes[j].ID] = opcaoDoGrupo{grupo: grupo, opcao: &grupo.Opcoes[j]}
+		}
+	}
+
+	selecoes := make(map[uint64]int, len(grupos))
+	vistas := make(map[uint64]bool, len(escolhidas))
+	resultado := make([]model.ItemPedidoOpcao, 0, len(escolhidas))
+	for _, escolhida := range escolhidas {
+		item, ok := opcoes[escolhida.OpcaoID]
+		if !ok || !item.opcao.Ativo {
+			return nil, opcaoInvalida("itens", fmt.Sprintf("A opcao de codigo %d nao esta disponivel para o produto %s",
+				escolhida.OpcaoID, produto.Nome))
+		}
+		if vistas[escolhida.OpcaoID] {
+			return nil, opcaoInvalida("itens", fmt.Sprintf("A opcao %s foi informada mais de uma vez para o produto %s",
+				item.opcao.Nome, produto.Nome))
+		}
+		vistas[escolhida.OpcaoID] = true
+		selecoes[item.grupo.ID]++
+
+		resultado = append(resultado, model.ItemPedidoOpcao{
+			OpcaoID:        item.opcao.ID,
+			Grupo:          item.grupo.Nome,
+			Nome:           item.opcao.Nome,
+			PrecoAdicional: item.opcao.PrecoAdicional,
+		})
+	}
+
+	for _, grupo := range grupos {
+		quantidade := selecoes[grupo.ID]
+		if quantidade < grupo.MinSelecoes {
+			return nil, opcaoInvalida("itens", fmt.Sprintf("Escolha ao menos %d opcao(oes) de %s para o produto %s",
+				grupo.MinSelecoes, grupo.Nome, produto.Nome))
+		}
+		if quantidade > grupo.MaxSelecoes {
+			return nil, opcaoInvalida("itens", fmt.Sprintf("Escolha no maximo %d opcao(oes) de %s para o produto %s",
+				grupo.MaxSelecoes, grupo.Nome, produto.Nome))
+		}
+	}
+
+	return resultado, nil
+}
+
+func opcaoInvalida(campo, msg string) error {
+	return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: campo, Mensagem: msg})
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+// grupoOpcoesRepositoryFake devolve os grupos de um único produto
+type grupoOpcoesRepositoryFake struct {
+	grupos []model.GrupoOpcoes
+}
+
+func (r *grupoOpcoesRepositoryFake) FindAllByProduto(produtoID uint64) ([]model.GrupoOpcoes, error) {
+	return r.grupos, nil
+}
+
+func (r *grupoOpcoesRepositoryFake) FindByID(produtoID, grupoID uint64) (*model.GrupoOpcoes, error) {
+	for i := range r.grupos {
+		if r.grupos[i].ID == grupoID {
+			return &r.grupos[i], nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (r *grupoOpcoesRepositoryFake) Save(grupo *model.GrupoOpcoes) error { return nil }
+
+func (r *grupoOpcoesRepositoryFake) Delete(grupoID uint64) error { return nil }
+
+func TestGrupoOpcoesServiceSelecionarOpcoes(t *testing.T) {
+	repo := &grupoOpcoesRepositoryFake{grupos: []model.GrupoOpcoes{
+		{ID: 1, Nome: "Tamanho", MinSelecoes: 1, MaxSelecoes: 1, Opcoes: []model.OpcaoProduto{
+			{ID: 10, GrupoID: 1, Nome: "Media", PrecoAdicional: decimal.Zero, Ativo: true},
+			{ID: 11, GrupoID: 1, Nome: "Grande", PrecoAdicional: decimal.RequireFromString("8.50"), Ativo: true},
+		}},
+		{ID: 2, Nome: "Adicionais", MinSelecoes: 0, MaxSelecoes: 2, Opcoes: []model.OpcaoProduto{
+			{ID: 20, GrupoID: 2, Nome: "Bacon", PrecoAdicional: decimal.RequireFromString("4.00"), Ativo: true},
+			{ID: 21, GrupoID: 2, Nome: "Cheddar", PrecoAdicional: decimal.RequireFromString("3.00"), Ativo: true},
+			{ID: 22, GrupoID: 2, Nome: "Ovo", PrecoAdicional: decimal.RequireFromString("2.00"), Ativo: true},
+			{ID: 23, GrupoID: 2, Nome: "Catupiry", PrecoAdicional: decimal.RequireFromString("5.00"), Ativo: false},
+		}},
+	}}
+	svc := NewGrupoOpcoesService(repo, nil)
+	produto := &model.Produto{ID: 1, Nome: "Pizza"}
+
+	escolher := func(ids ...uint64) []model.ItemPedidoOpcao {
+		escolhidas := make([]model.ItemPedidoOpcao, len(ids))
+		for i, id := range ids {
+			escolhidas[i] = model.ItemPedidoOpcao{OpcaoID: id}
+		}
+		return escolhidas
+	}
+
+	tests := []struct {
+		name      string
+		escolhas  []model.ItemPedidoOpcao
+		wantErr   bool
+		wantNomes []string
+	}{
+		{name: "minimo obrigatorio", escolhas: escolher(10), wantNomes: []string{"Media"}},
+		{name: "com adicionais", escolhas: escolher(11, 20, 21), wantNomes: []string{"Grande", "Bacon", "Cheddar"}},
+		{name: "falta grupo obrigatorio", escolhas: escolher(20), wantErr: true},
+		{name: "acima do maximo do grupo", escolhas: escolher(10, 20, 21, 22), wantErr: true},
+		{name: "duas opcoes em grupo de escolha unica", escolhas: escolher(10, 11), wantErr: true},
+		{name: "opcao inativa", escolhas: escolher(10, 23), wantErr: true},
+		{name: "opcao de outro produto", escolhas: escolher(10, 99), wantErr: true},
+		{name: "opcao repetida", escolhas: escolher(10, 20, 20), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opcoes, err := svc.SelecionarOpcoes(produto, tt.escolhas)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %+v", opcoes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(opcoes) != len(tt.wantNomes) {
+				t.Fatalf("obteve %d opcoes, esperava %d", len(opcoes), len(tt.wantNomes))
+			}
+			for i, opcao := range opcoes {
+				if opcao.Nome != tt.wantNomes[i] {
+					t.Errorf("opcao %d = %q, esperava %q", i, opcao.Nome, tt.wantNomes[i])
+				}
+				if opcao.Grupo == "" {
+					t.Errorf("opcao %q sem o nome do grupo", opcao.Nome)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"gorm.io/gorm"
+)
+
+// HorarioFuncionamentoService gerencia a agenda semanal dos restaurantes e
+// abre/fecha os restaurantes automaticamente nas fronteiras dos horários
+type HorarioFuncionamentoService struct {
+	repo           repository.HorarioFuncionamentoRepository
+	excecaoRepo    repository.ExcecaoHorarioRepository
+	restauranteSvc *RestauranteService
+}
+
+func NewHorarioFuncionamentoService(
+	repo repository.HorarioFuncionamentoRepository,
+	excecaoRepo repository.ExcecaoHorarioRepository,
+	restauranteSvc *RestauranteService,
+) *HorarioFuncionamentoService {
+	return &HorarioFuncionamentoService{
+		repo:           repo,
+		excecaoRepo:    excecaoRepo,
+		restauranteSvc: restauranteSvc,
+	}
+}
+
+// ==================== HORÁRIOS ====================
+
+func (s *HorarioFuncionamentoService) FindAllByRestaurante(restauranteID uint64) ([]model.HorarioFuncionamento, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindAllByRestaurante(restauranteID)
+}
+
+func (s *HorarioFuncionamentoService) FindByID(restauranteID, horarioID uint64) (*model.HorarioFuncionamento, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+
+	horario, err := s.repo.FindByID(restauranteID, horarioID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewHorarioFuncionamentoNaoEncontradoException(restauranteID, horarioID)
+		}
+		return nil, err
+	}
+	return horario, nil
+}
+
+func (s *HorarioFuncionamentoService) Save(restauranteID uint64, horario *model.HorarioFuncionamento) error {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return err
+	}
+
+	if err := validarIntervalo(horario.HoraAbertura, horario.HoraFechamento); err != nil {
+		return err
+	}
+
+	horario.RestauranteID = restauranteID
+	return s.repo.Save(horario)
+}
+
+func (s *HorarioFuncionamentoService) Excluir(restauranteID, horarioID uint64) error {
+	horario, err := s.FindByID(restauranteID, horarioID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(horario.ID)
+}
+
+// ==================== EXCEÇÕES ====================
+
+func (s *HorarioFuncionamentoService) FindAllExcecoes(restauranteID uint64) ([]model.ExcecaoHorario, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	return s.excecaoRepo.FindAllByRestaurante(restauranteID)
+}
+
+func (s *HorarioFuncionamentoService) FindExcecaoByID(restauranteID, excecaoID uint64) (*model.ExcecaoHorario, error) {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+
+	excecao, err := s.excecaoRepo.FindByID(restauranteID, excecaoID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewExcecaoHorarioNaoEncontradaException(restauranteID, excecaoID)
+		}
+		return nil, err
+	}
+	return excecao, nil
+}
+
+func (s *HorarioFuncionamentoService) SaveExcecao(restauranteID uint64, excecao *model.ExcecaoHorario) error {
+	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
+		return err
+	}
+
+	if _, err := time.Parse(model.FormatoData, excecao.Data); err != nil {
+		return exception.NewNegocioException(fmt.Sprintf("Data %s deve estar no formato AAAA-MM-DD", excecao.Data))
+	}
+
+	if excecao.Fechado {
+		excecao.HoraAbertura = nil
+		excecao.HoraFechamento = nil
+	} else {
+		if excecao.HoraAbertura == nil || excecao.HoraFechamento == nil {
+			return exception.NewNegocioException("Informe os horarios de abertura e fechamento ou marque a data como fechada")
+		}
+		if err := validarIntervalo(*excecao.HoraAbertura, *excecao.HoraFechamento); err != nil {
+			return err
+		}
+	}
+
+	// Apenas uma exceção por data
+	existentes, err := s.excecaoRepo.FindAllByRestaurante(restauranteID)
+	if err != nil {
+		return err
+	}
+	for _, existente := range existentes {
+		if existente.Data == excecao.Data && existente.ID != excecao.ID {
+			return exception.NewNegocioException(fmt.Sprintf("Ja existe uma excecao de horario para a data %s", excecao.Data))
+		}
+	}
+
+	excecao.RestauranteID = restauranteID
+	return s.excecaoRepo.Save(excecao)
+}
+
+func (s *HorarioFuncionamentoService) ExcluirExcecao(restauranteID, excecaoID uint64) error {
+	excecao, err := s.FindExcecaoByID(restauranteID, excecaoID)
+	if err != nil {
+		return err
+	}
+	return s.excecaoRepo.Delete(excecao.ID)
+}
+
+// ==================== AGENDA ====================
+
+// DeveEstarAberto verifica se a agenda do restaurante prevê funcionamento no instante informado
+func (s *HorarioFuncionamentoService) DeveEstarAberto(restaurante *model.Restaurante, instante time.Time) (bool, error) {
+	loc, err := restaurante.Localizacao()
+	if err != nil {
+		return false, err
+	}
+
+	horarios, err := s.repo.FindAllByRestaurante(restaurante.ID)
+	if err != nil {
+		return false, err
+	}
+	excecoes, err := s.excecaoRepo.FindAllByRestaurante(restaurante.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return model.EstaAbertoEm(horarios, excecoes, instante.In(loc)), nil
+}
+
+// SincronizarAberturas abre ou fecha os restaurantes com agenda cadastrada quando
+// a situação prevista muda. A última situação aplicada fica gravada no restaurante,
+// então uma abertura ou fechamento manual continua valendo até a próxima mudança
+// prevista pela agenda, mesmo após reinícios ou com várias réplicas rodando.
+func (s *HorarioFuncionamentoService) SincronizarAberturas(ctx context.Context) error {
+	executou, err := s.repo.ComTravaAgenda(ctx, func() error {
+		return s.sincronizarAberturas(ctx)
+	})
+	if err == nil && !executou {
+		log.Println("Sincronizacao da agenda em andamento em outra instancia; ciclo ignorado")
+	}
+	return err
+}
+
+func (s *HorarioFuncionamentoService) sincronizarAberturas(ctx context.Context) error {
+	ids, err := s.repo.FindRestauranteIDs()
+	if err != nil {
+		return err
+	}
+
+	agora := time.Now()
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		restaurante, err := s.restauranteSvc.FindByID(id)
+		if err != nil {
+			log.Printf("Aviso: Falha ao carregar restaurante %d para agenda: %v", id, err)
+			continue
+		}
+		if !restaurante.Ativo {
+			continue
+		}
+
+		deveEstarAberto, err := s.DeveEstarAberto(restaurante, agora)
+		if err != nil {
+			log.Printf("Aviso: Falha ao calcular agenda do restaurante %d: %v", id, err)
+			continue
+		}
+
+		// Só altera o restaurante quando a agenda cruzou uma fronteira desde a última aplicação
+		aplicada, err := s.restauranteSvc.AplicarAgenda(id, deveEstarAberto)
+		if err != nil {
+			log.Printf("Aviso: Falha ao atualizar abertura do restaurante %d pela agenda: %v", id, err)
+			continue
+		}
+		if !aplicada {
+			continue
+		}
+
+		situacao := "fechado"
+		if deveEstarAberto {
+			situacao = "aberto"
+		}
+		log.Printf("Restaurante %d %s pela agenda de funcionamento", id, situacao)
+	}
+
+	// Restaurantes inativos ou que deixaram de ter agenda voltam ao controle manual
+	return s.restauranteSvc.LimparAgenda(ids)
+}
+
+func validarIntervalo(horaAbertura, horaFechamento string) error {
+	abertura, err := model.ParseHora(horaAbertura)
+	if err != nil {
+		return exception.NewNegocioException(err.Error())
+	}
+	fechamento, err := model.ParseHora(horaFechamento)
+	if err != nil {
+		return exception.NewNegocioException(err.Error())
+	}
+	if abertura == 24*60 {
+		return exception.NewNegocioException("A hora de abertura deve ser anterior a 24:00")
+	}
+	if abertura == fechamento {
+		return exception.NewNegocioException("As horas de abertura e fechamento devem ser diferentes")
+	}
+	return nil
+}
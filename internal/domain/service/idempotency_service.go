@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+)
+
+const (
+	// Prefixo para chaves de idempotência no Redis
+	idempotencyPrefix = "idempotency:"
+
+	// Tempo que a resposta original fica disponível para replay
+	idempotencyTTL = 24 * time.Hour
+
+	// Tempo máximo que uma requisição pode ficar "em processamento"
+	idempotencyLockTTL = 30 * time.Second
+
+	// Timeout para operações no Redis
+	idempotencyTimeout = 200 * time.Millisecond
+)
+
+// RespostaIdempotente representa uma requisição registrada com uma Idempotency-Key
+type RespostaIdempotente struct {
+	Fingerprint     string          `json:"fingerprint"`
+	EmProcessamento bool            `json:"emProcessamento"`
+	Status          int             `json:"status,omitempty"`
+	Body            json.RawMessage `json:"body,omitempty"`
+}
+
+// IdempotencyService armazena as respostas de requisições com Idempotency-Key no Redis
+type IdempotencyService struct {
+	redisClient *redis.Client
+}
+
+// NewIdempotencyService cria um novo serviço de idempotência
+func NewIdempotencyService(redisCfg *config.RedisConfig) *IdempotencyService {
+	client := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
+		Password:     redisCfg.Password,
+		DB:           redisCfg.DB,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  idempotencyTimeout,
+		WriteTimeout: idempotencyTimeout,
+	})
+
+	return &IdempotencyService{redisClient: client}
+}
+
+// Iniciar reserva a chave para uma nova requisição. Retorna a resposta original quando
+// a chave já foi concluída com o mesmo fingerprint, ou nil quando a requisição deve
+// ser processada. Em caso de falha do Redis a requisição é processada normalmente.
+func (s *IdempotencyService) Iniciar(escopo, chave, fingerprint string) (*RespostaIdempotente, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(RespostaIdempotente{Fingerprint: fingerprint, EmProcessamento: true})
+	if err != nil {
+		return nil, err
+	}
+
+	key := s.key(escopo, chave)
+	reservado, err := s.redisClient.SetNX(ctx, key, data, idempotencyLockTTL).Result()
+	if err != nil {
+		log.Printf("Aviso: Falha ao reservar chave de idempotencia %s: %v", key, err)
+		return nil, nil
+	}
+	if reservado {
+		return nil, nil
+	}
+
+	existente, err := s.redisClient.Get(ctx, key).Bytes()
+	if err != nil {
+		if err == redis.Nil {
+			// Expirou entre o SETNX e o GET; trata como nova requisição
+			return nil, nil
+		}
+		log.Printf("Aviso: Falha ao consultar chave de idempotencia %s: %v", key, err)
+		return nil, nil
+	}
+
+	var resposta RespostaIdempotente
+	if err := json.Unmarshal(existente, &resposta); err != nil {
+		return nil, err
+	}
+
+	if resposta.Fingerprint != fingerprint {
+		return nil, exception.NewChaveIdempotenciaReutilizadaException(chave)
+	}
+	if resposta.EmProcessamento {
+		return nil, exception.NewRequisicaoEmProcessamentoException(chave)
+	}
+
+	return &resposta, nil
+}
+
+// Concluir armazena a resposta original para replays futuros
+func (s *IdempotencyService) Concluir(escopo, chave, fingerprint string, status int, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyTimeout)
+	defer cancel()
+
+	data, err := json.Marshal(RespostaIdempotente{
+		Fingerprint: fingerprint,
+		Status:      status,
+		Body:        body,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.redisClient.Set(ctx, s.key(escopo, chave), data, idempotencyTTL).Err()
+}
+
+// Liberar remove a reserva da chave, permitindo que o cliente tente novamente
+func (s *IdempotencyService) Liberar(escopo, chave string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), idempotencyTimeout)
+	defer cancel()
+
+	return s.redisClient.Del(ctx, s.key(escopo, chave)).Err()
+}
+
+func (s *IdempotencyService) key(escopo, chave string) string {
+	return idempotencyPrefix + escopo + ":" + chave
+}
+
+// Close fecha a conexão com o Redis
+func (s *IdempotencyService) Close() error {
+	return s.redisClient.Close()
+}
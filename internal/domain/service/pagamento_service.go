@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/internal/infrastructure/payment"
+	"gorm.io/gorm"
+)
+
+const (
+	moedaPadrao = "BRL"
+
+	// indicePagamentoAtivo garante um único pagamento ativo por pedido
+	indicePagamentoAtivo = "uk_pagamento_pedido_ativo"
+
+	// estornosPorLote limita quantos estornos pendentes são refeitos a cada execução
+	estornosPorLote = 50
+)
+
+// statusTransacao traduz o status da transação no provedor para o status do pagamento
+var statusTransacao = map[payment.TransactionStatus]model.StatusPagamento{
+	payment.StatusPending:    model.StatusPagamentoPendente,
+	payment.StatusAuthorized: model.StatusPagamentoAutorizado,
+	payment.StatusCaptured:   model.StatusPagamentoCapturado,
+	payment.StatusDeclined:   model.StatusPagamentoRecusado,
+	payment.StatusRefunded:   model.StatusPagamentoEstornado,
+}
+
+// PagamentoService cobra os pedidos no provedor de pagamento configurado e mantém o
+// status dos pagamentos. As mudanças no pedido ficam a cargo do FluxoPedidoService.
+type PagamentoService struct {
+	repo     repository.PagamentoRepository
+	provider payment.PaymentProvider
+	moeda    string
+}
+
+func NewPagamentoService(repo repository.PagamentoRepository, provider payment.PaymentProvider, moeda string) *PagamentoService {
+	if moeda == "" {
+		moeda = moedaPadrao
+	}
+	return &PagamentoService{
+		repo:     repo,
+		provider: provider,
+		moeda:    moeda,
+	}
+}
+
+// FindByPedido retorna a tentativa de pagamento mais recente do pedido
+func (s *PagamentoService) FindByPedido(pedido *model.Pedido) (*model.Pagamento, error) {
+	pagamento, err := s.repo.FindUltimoByPedido(pedido.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewPagamentoNaoEncontradoException(pedido.Codigo)
+		}
+		return nil, err
+	}
+	return pagamento, nil
+}
+
+// Pagar autoriza e captura o valor total do pedido. A tentativa é gravada mesmo quando
+// recusada; com o provedor assíncrono o pagamento fica pendente até o webhook. O índice
+// único de pagamento ativo impede duas tentativas simultâneas do mesmo pedido.
+func (s *PagamentoService) Pagar(ctx context.Context, pedido *model.Pedido, token string) (*model.Pagamento, error) {
+	if pedido.Status != model.StatusPedidoCriado {
+		return nil, exception.NewNegocioException(fmt.Sprintf("O pedido %s nao pode ser pago com status %s",
+			pedido.Codigo, pedido.Status.GetDescription()))
+	}
+
+	ultimo, err := s.repo.FindUltimoByPedido(pedido.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if ultimo != nil && ultimo.Status == model.StatusPagamentoAutorizado {
+		// A captura anterior falhou e a autorização não pôde ser cancelada: tenta capturar de novo
+		return s.concluirCaptura(ctx, ultimo)
+	}
+	if ultimo != nil && ultimo.Ativo() {
+		return nil, pagamentoAtivoException(pedido, ultimo.Status)
+	}
+
+	// Cada tentativa tem a própria chave: a seguinte a uma recusa não pode reaproveitar a resposta
+	transacao, err := s.provider.Authorize(ctx, payment.AuthorizeRequest{
+		Reference:      pedido.Codigo,
+		IdempotencyKey: pedido.Codigo + "-" + uuid.New().String(),
+		Amount:         pedido.ValorTotal,
+		Currency:       s.moeda,
+		Token:          token,
+		Description:    fmt.Sprintf("Pedido %s - %s", pedido.Codigo, pedido.Restaurante.Nome),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("falha ao autorizar o pagamento do pedido %s: %w", pedido.Codigo, err)
+	}
+
+	pagamento := &model.Pagamento{
+		PedidoID:    pedido.ID,
+		Provedor:    s.provider.Name(),
+		TransacaoID: transacao.ID,
+		Valor:       pedido.ValorTotal,
+	}
+	aplicarStatusTransacao(pagamento, transacao.Status, transacao.Message)
+
+	if err := s.repo.Save(pagamento); err != nil {
+		if repository.ChaveDuplicada(err, indicePagamentoAtivo) {
+			// Outra tentativa do mesmo pedido foi gravada antes: desfaz a autorização desta
+			s.cancelarAutorizacao(ctx, pagamento)
+			return nil, pagamentoAtivoException(pedido, model.StatusPagamentoPendente)
+		}
+		return nil, err
+	}
+
+	// Autorizado: captura na hora, o pedido só é confirmado com o valor capturado
+	if pagamento.Status == model.StatusPagamentoAutorizado {
+		return s.concluirCaptura(ctx, pagamento)
+	}
+
+	if pagamento.Status == model.StatusPagamentoRecusado {
+		msg := "Pagamento recusado"
+		if pagamento.Mensagem != "" {
+			msg += ": " + pagamento.Mensagem
+		}
+		return nil, exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "token", Mensagem: msg})
+	}
+	return pagamento, nil
+}
+
+// concluirCaptura captura o pagamento autorizado. Se a captura falhar, cancela a autorização
+// para que o cliente possa tentar de novo; se o cancelamento também falhar, o pagamento segue
+// autorizado e a próxima chamada a Pagar repete a captura.
+func (s *PagamentoService) concluirCaptura(ctx context.Context, pagamento *model.Pagamento) (*model.Pagamento, error) {
+	errCaptura := s.capturar(ctx, pagamento)
+	if errCaptura != nil {
+		s.cancelarAutorizacao(ctx, pagamento)
+	}
+
+	if err := s.repo.Save(pagamento); err != nil {
+		return nil, err
+	}
+	if errCaptura != nil {
+		return nil, errCaptura
+	}
+	return pagamento, nil
+}
+
+// cancelarAutorizacao libera a autorização não capturada; falhas são apenas registradas
+func (s *PagamentoService) cancelarAutorizacao(ctx context.Context, pagamento *model.Pagamento) {
+	if pagamento.Status != model.StatusPagamentoAutorizado && pagamento.Status != model.StatusPagamentoPendente {
+		return
+	}
+	transacao, err := s.provider.Void(ctx, pagamento.TransacaoID)
+	if err != nil {
+		log.Printf("Aviso: Falha ao cancelar a autorizacao %s: %v", pagamento.TransacaoID, err)
+		return
+	}
+	aplicarStatusTransacao(pagamento, transacao.Status, "Autorizacao cancelada")
+}
+
+func pagamentoAtivoException(pedido *model.Pedido, status model.StatusPagamento) error {
+	return exception.NewNegocioException(fmt.Sprintf("O pedido %s ja possui um pagamento com status %s",
+		pedido.Codigo, status))
+}
+
+// AtualizarPorWebhook valida a notificação do provedor e atualiza o pagamento. Retorna
+// false quando não há mudança: transação desconhecida, status repetido ou já encerrado.
+func (s *PagamentoService) AtualizarPorWebhook(ctx context.Context, header http.Header, body []byte) (*model.Pagamento, bool, error) {
+	evento, err := s.provider.VerifyWebhook(header, body)
+	if err != nil {
+		if errors.Is(err, payment.ErrInvalidSignature) {
+			return nil, false, exception.NewAuthenticationException("Assinatura do webhook de pagamento invalida")
+		}
+		return nil, false, exception.NewNegocioException(fmt.Sprintf("Notificacao de pagamento invalida: %v", err))
+	}
+
+	pagamento, err := s.repo.FindByTransacao(s.provider.Name(), evento.TransactionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("Aviso: Webhook de pagamento %s para transacao desconhecida %s ignorado", evento.ID, evento.TransactionID)
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	anterior := pagamento.Status
+	// Recusa e estorno encerram o pagamento; notificações atrasadas são ignoradas
+	if anterior == model.StatusPagamentoRecusado || anterior == model.StatusPagamentoEstornado {
+		return pagamento, false, nil
+	}
+
+	aplicarStatusTransacao(pagamento, evento.Status, evento.Message)
+	if pagamento.Status == model.StatusPagamentoAutorizado {
+		if err := s.capturar(ctx, pagamento); err != nil {
+			return nil, false, err
+		}
+	}
+	if pagamento.Status == anterior {
+		return pagamento, false, nil
+	}
+
+	if err := s.repo.Save(pagamento); err != nil {
+		return nil, false, err
+	}
+	return pagamento, true, nil
+}
+
+// EstornarPedido devolve o valor capturado do pedido. Sem pagamento capturado não há o que estornar.
+func (s *PagamentoService) EstornarPedido(ctx context.Context, pedido *model.Pedido) error {
+	pagamento, err := s.repo.FindUltimoByPedido(pedido.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return err
+	}
+	return s.Estornar(ctx, pagamento)
+}
+
+// Estornar devolve o valor de um pagamento capturado; nos demais status não faz nada
+func (s *PagamentoService) Estornar(ctx context.Context, pagamento *model.Pagamento) error {
+	if pagamento.Status != model.StatusPagamentoCapturado {
+		return nil
+	}
+
+	transacao, err := s.provider.Refund(ctx, pagamento.TransacaoID, pagamento.Valor)
+	if err != nil {
+		return fmt.Errorf("falha ao estornar a transacao %s: %w", pagamento.TransacaoID, err)
+	}
+	aplicarStatusTransacao(pagamento, transacao.Status, transacao.Message)
+	return s.repo.Save(pagamento)
+}
+
+// EstornarCancelados refaz os estornos pendentes: pagamentos ainda capturados de pedidos
+// cancelados. Uma falha não interrompe os demais; o pagamento fica para a próxima execução.
+func (s *PagamentoService) EstornarCancelados(ctx context.Context) error {
+	pagamentos, err := s.repo.FindCapturadosDePedidosCancelados(estornosPorLote)
+	if err != nil {
+		return err
+	}
+
+	var falhas []error
+	for i := range pagamentos {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := s.Estornar(ctx, &pagamentos[i]); err != nil {
+			falhas = append(falhas, err)
+		}
+	}
+	return errors.Join(falhas...)
+}
+
+func (s *PagamentoService) capturar(ctx context.Context, pagamento *model.Pagamento) error {
+	transacao, err := s.provider.Capture(ctx, pagamento.TransacaoID, pagamento.Valor)
+	if err != nil {
+		return fmt.Errorf("falha ao capturar a transacao %s: %w", pagamento.TransacaoID, err)
+	}
+	aplicarStatusTransacao(pagamento, transacao.Status, transacao.Message)
+	return nil
+}
+
+func aplicarStatusTransacao(pagamento *model.Pagamento, status payment.TransactionStatus, mensagem string) {
+	novo, ok := statusTransacao[status]
+	if !ok || novo == pagamento.Status {
+		return
+	}
+
+	agora := time.Now()
+	pagamento.Status = novo
+	pagamento.Mensagem = mensagem
+	switch novo {
+	case model.StatusPagamentoCapturado:
+		pagamento.DataCaptura = &agora
+	case model.StatusPagamentoEstornado:
+		pagamento.DataEstorno = &agora
+	}
+}
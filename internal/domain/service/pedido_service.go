@@ -2,6 +2,7 @@ package service
 
 import (
 	"errors"
+	"log"
 
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
@@ -17,6 +18,11 @@ type PedidoService struct {
 	usuarioSvc        *UsuarioService
 	produtoSvc        *ProdutoService
 	formaPagamentoSvc *FormaPagamentoService
+	freteSvc          *FreteService
+	cupomSvc          *CupomService
+	grupoOpcoesSvc    *GrupoOpcoesService
+	enderecoSvc       *EnderecoUsuarioService
+	validadores       []ValidadorPedido
 }
 
 func NewPedidoService(
@@ -26,6 +32,11 @@ func NewPedidoService(
 	usuarioSvc *UsuarioService,
 	produtoSvc *ProdutoService,
 	formaPagamentoSvc *FormaPagamentoService,
+	freteSvc *FreteService,
+	cupomSvc *CupomService,
+	grupoOpcoesSvc *GrupoOpcoesService,
+	enderecoSvc *EnderecoUsuarioService,
+	validadores ...ValidadorPedido,
 ) *PedidoService {
 	return &PedidoService{
 		repo:              repo,
@@ -34,6 +45,11 @@ func NewPedidoService(
 		usuarioSvc:        usuarioSvc,
 		produtoSvc:        produtoSvc,
 		formaPagamentoSvc: formaPagamentoSvc,
+		freteSvc:          freteSvc,
+		cupomSvc:          cupomSvc,
+		grupoOpcoesSvc:    grupoOpcoesSvc,
+		enderecoSvc:       enderecoSvc,
+		validadores:       validadores,
 	}
 }
 
@@ -51,6 +67,21 @@ func (s *PedidoService) Pesquisar(filter *repository.PedidoFilter, page *paginat
 	return result, nil
 }
 
+// PesquisarPorCursor pesquisa com paginação por keyset (cursor opaco)
+func (s *PedidoService) PesquisarPorCursor(filter *repository.PedidoFilter, page *pagination.Pageable) (*pagination.CursorPage[model.Pedido], error) {
+	result, err := s.repo.FindAllByCursor(filter, page)
+	if err != nil {
+		return nil, err
+	}
+
+	// Popula relacionamentos usando cache
+	for i := range result.Content {
+		s.populateRelacionamentos(&result.Content[i])
+	}
+
+	return result, nil
+}
+
 func (s *PedidoService) FindByCodigo(codigo string) (*model.Pedido, error) {
 	pedido, err := s.repo.FindByCodigo(codigo)
 	if err != nil {
@@ -108,52 +139,301 @@ func (s *PedidoService) populateRelacionamentos(pedido *model.Pedido) {
 }
 
 func (s *PedidoService) Emitir(pedido *model.Pedido) error {
-	// Validate restaurante
-	restaurante, err := s.restauranteSvc.FindByID(pedido.RestauranteID)
+	cupom, validacao, err := s.preparar(pedido, false)
 	if err != nil {
 		return err
 	}
+	if err := validarPedido(s.validadores, validacao); err != nil {
+		return err
+	}
 
-	// Validate forma pagamento
-	formaPagamento, err := s.formaPagamentoSvc.FindByID(pedido.FormaPagamentoID)
+	pedido.BeforeCreate()
+	historico := model.NewPedidoStatusHistorico(pedido, nil, pedido.ClienteID, "")
+
+	if cupom == nil {
+		return s.repo.SaveComHistorico(pedido, historico)
+	}
+
+	// Os limites do cupom são conferidos de novo dentro da transação
+	salvo, err := s.repo.SaveComCupom(pedido, historico, cupom)
 	if err != nil {
 		return err
 	}
+	if !salvo {
+		return CupomEsgotadoException(cupom.Codigo)
+	}
+	return nil
+}
 
-	// Check if restaurante accepts this forma pagamento
-	if restaurante.NaoAceitaFormaPagamento(*formaPagamento) {
-		return exception.NewNegocioException("Forma de pagamento nao aceita por esse restaurante")
+// Simular calcula preços, frete e desconto do pedido sem gravá-lo. As violações dos
+// validadores não interrompem a simulação e são retornadas como pendências; na simulação
+// a forma de pagamento é opcional.
+func (s *PedidoService) Simular(pedido *model.Pedido) (*exception.NegocioException, error) {
+	_, validacao, err := s.preparar(pedido, true)
+	if err != nil {
+		return nil, err
+	}
+
+	err = validarPedido(s.validadores, validacao)
+	var pendencias *exception.NegocioException
+	if err != nil && !errors.As(err, &pendencias) {
+		return nil, err
+	}
+	return pendencias, nil
+}
+
+// preparar confere as referências do pedido e calcula preços, frete e desconto sem
+// gravá-lo. Retorna o cupom aplicado, quando houver, e os dados para os validadores.
+func (s *PedidoService) preparar(pedido *model.Pedido, simulacao bool) (*model.Cupom, *ValidacaoPedido, error) {
+	// Validate restaurante
+	restaurante, err := s.restauranteSvc.FindByID(pedido.RestauranteID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Validate forma pagamento
+	if pedido.FormaPagamentoID != 0 || !simulacao {
+		formaPagamento, err := s.formaPagamentoSvc.FindByID(pedido.FormaPagamentoID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		// Check if restaurante accepts this forma pagamento
+		if restaurante.NaoAceitaFormaPagamento(*formaPagamento) {
+			return nil, nil, exception.NewNegocioException("Forma de pagamento nao aceita por esse restaurante")
+		}
 	}
 
 	// Validate cliente
-	_, err = s.usuarioSvc.FindByID(pedido.ClienteID)
+	cliente, err := s.usuarioSvc.FindByID(pedido.ClienteID)
 	if err != nil {
-		return err
+		return nil, nil, err
+	}
+
+	// Sem endereço no pedido, copia o endereço salvo informado ou o padrão do cliente
+	if pedido.EnderecoSalvoID != nil || pedido.EnderecoEntrega.CidadeID == 0 {
+		endereco, err := s.enderecoSvc.ParaPedido(pedido.ClienteID, pedido.EnderecoSalvoID)
+		if err != nil {
+			return nil, nil, err
+		}
+		pedido.EnderecoEntrega = endereco.ParaEntrega()
 	}
 
 	// Validate cidade
 	if pedido.EnderecoEntrega.CidadeID != 0 {
 		_, err = s.cidadeSvc.FindByID(pedido.EnderecoEntrega.CidadeID)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
 	}
 
-	// Validate and set items - apenas preço, não o objeto completo
+	// Validate and set items - apenas preço e opções, não o objeto completo
+	produtos := make(map[uint64]*model.Produto, len(pedido.Itens))
 	for i := range pedido.Itens {
 		item := &pedido.Itens[i]
 		produto, err := s.produtoSvc.FindByID(restaurante.ID, item.ProdutoID)
 		if err != nil {
-			return err
+			return nil, nil, err
 		}
+		produtos[produto.ID] = produto
 		item.PrecoUnitario = produto.Preco
+		// Valida as opções escolhidas e grava a cópia com o preço atual de cada uma
+		item.Opcoes, err = s.grupoOpcoesSvc.SelecionarOpcoes(produto, item.Opcoes)
+		if err != nil {
+			return nil, nil, err
+		}
 		item.CalcularPrecoTotal()
 	}
 
-	// Set freight and calculate total
-	pedido.TaxaFrete = restaurante.TaxaFrete
+	// O frete depende do subtotal (frete grátis), calculado antes da cotação
 	pedido.CalcularValorTotal()
-	pedido.BeforeCreate()
+	cotacao, err := s.freteSvc.Cotar(restaurante, &SolicitacaoFrete{
+		CidadeID:  pedido.EnderecoEntrega.CidadeID,
+		Bairro:    pedido.EnderecoEntrega.Bairro,
+		Latitude:  pedido.EnderecoEntrega.Latitude,
+		Longitude: pedido.EnderecoEntrega.Longitude,
+		Subtotal:  pedido.Subtotal,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	pedido.DefinirFrete(cotacao)
+
+	// O desconto do cupom incide sobre o subtotal ou sobre o frete já cotado
+	var cupom *model.Cupom
+	if pedido.CodigoCupom != "" {
+		cupom, err = s.cupomSvc.ValidarParaPedido(pedido.CodigoCupom, pedido)
+		if err != nil {
+			return nil, nil, err
+		}
+		pedido.AplicarCupom(cupom)
+	}
+	pedido.CalcularValorTotal()
+
+	return cupom, &ValidacaoPedido{
+		Pedido:      pedido,
+		Cliente:     cliente,
+		Restaurante: restaurante,
+		Produtos:    produtos,
+	}, nil
+}
+
+// Repetir monta um novo pedido do cliente a partir de um pedido anterior, com os preços
+// atuais dos produtos. Itens de produtos inativos ou com opções indisponíveis são removidos.
+// Com simular, o pedido é apenas calculado e não é gravado. Depois da emissão não retorna erro.
+func (s *PedidoService) Repetir(codigo string, clienteID uint64, simular bool) (*model.RepeticaoPedido, error) {
+	original, err := s.FindByCodigo(codigo)
+	if err != nil {
+		return nil, err
+	}
+	// Pedidos de outros clientes são tratados como inexistentes
+	if original.ClienteID != clienteID {
+		return nil, exception.NewPedidoNaoEncontradoException(codigo)
+	}
+
+	// O endereço é copiado do pedido original; o cupom não é repetido
+	endereco := original.EnderecoEntrega
+	endereco.Cidade = model.Cidade{}
+	pedido := &model.Pedido{
+		RestauranteID:    original.RestauranteID,
+		ClienteID:        clienteID,
+		FormaPagamentoID: original.FormaPagamentoID,
+		EnderecoEntrega:  endereco,
+	}
+	repeticao := &model.RepeticaoPedido{Original: original, Pedido: pedido}
+
+	// Itens mantidos, na mesma ordem dos itens do novo pedido
+	mantidos := make([]*model.ItemPedido, 0, len(original.Itens))
+	for i := range original.Itens {
+		anterior := &original.Itens[i]
+		motivo, err := s.motivoItemNaoRepetido(original.RestauranteID, anterior)
+		if err != nil {
+			return nil, err
+		}
+		if motivo != "" {
+			repeticao.ItensRemovidos = append(repeticao.ItensRemovidos, model.ItemRepeticaoRemovido{
+				ProdutoID:  anterior.ProdutoID,
+				Nome:       anterior.Produto.Nome,
+				Quantidade: anterior.Quantidade,
+				Motivo:     motivo,
+			})
+			continue
+		}
+
+		opcoes := make([]model.ItemPedidoOpcao, 0, len(anterior.Opcoes))
+		for _, opcao := range anterior.Opcoes {
+			opcoes = append(opcoes, model.ItemPedidoOpcao{OpcaoID: opcao.OpcaoID})
+		}
+		pedido.Itens = append(pedido.Itens, model.ItemPedido{
+			ProdutoID:  anterior.ProdutoID,
+			Quantidade: anterior.Quantidade,
+			Observacao: anterior.Observacao,
+			Opcoes:     opcoes,
+		})
+		mantidos = append(mantidos, anterior)
+	}
+
+	if len(pedido.Itens) == 0 {
+		return nil, exception.NewNegocioExceptionComCampos("Nenhum item do pedido esta disponivel para ser repetido",
+			exception.CampoInvalido{Nome: "itens", Mensagem: "Nenhum item do pedido esta disponivel"})
+	}
+
+	if simular {
+		pendencias, err := s.Simular(pedido)
+		if err != nil {
+			return nil, err
+		}
+		if pendencias != nil {
+			return nil, pendencias
+		}
+	} else {
+		if err := s.Emitir(pedido); err != nil {
+			return nil, err
+		}
+		repeticao.Emitido = true
+	}
+
+	for i := range pedido.Itens {
+		anterior, atual := mantidos[i], &pedido.Itens[i]
+		precoAnterior, precoAtual := anterior.PrecoUnitarioComOpcoes(), atual.PrecoUnitarioComOpcoes()
+		if !precoAnterior.Equal(precoAtual) {
+			repeticao.ItensAlterados = append(repeticao.ItensAlterados, model.ItemRepeticaoAlterado{
+				ProdutoID:     anterior.ProdutoID,
+				Nome:          anterior.Produto.Nome,
+				PrecoAnterior: precoAnterior,
+				PrecoAtual:    precoAtual,
+			})
+		}
+	}
+
+	if repeticao.Emitido {
+		// O pedido já foi emitido: uma falha ao recarregar não pode virar erro
+		completo, err := s.FindByCodigo(pedido.Codigo)
+		if err != nil {
+			log.Printf("Aviso: Falha ao recarregar o pedido %s: %v", pedido.Codigo, err)
+		} else {
+			repeticao.Pedido = completo
+		}
+	} else {
+		s.populateRelacionamentos(pedido)
+	}
+	return repeticao, nil
+}
+
+// motivoItemNaoRepetido retorna por que o item não pode ser repetido, ou vazio se ele pode
+func (s *PedidoService) motivoItemNaoRepetido(restauranteID uint64, item *model.ItemPedido) (string, error) {
+	produto, err := s.produtoSvc.FindByID(restauranteID, item.ProdutoID)
+	if err != nil {
+		var naoEncontrado *exception.ProdutoNaoEncontradoException
+		if errors.As(err, &naoEncontrado) {
+			return "Produto nao encontrado", nil
+		}
+		return "", err
+	}
+	if !produto.Ativo {
+		return "Produto inativo", nil
+	}
+
+	escolhidas := make([]model.ItemPedidoOpcao, 0, len(item.Opcoes))
+	for _, opcao := range item.Opcoes {
+		escolhidas = append(escolhidas, model.ItemPedidoOpcao{OpcaoID: opcao.OpcaoID})
+	}
+	if _, err := s.grupoOpcoesSvc.SelecionarOpcoes(produto, escolhidas); err != nil {
+		var negocio *exception.NegocioException
+		if errors.As(err, &negocio) {
+			return negocio.Message, nil
+		}
+		return "", err
+	}
+	return "", nil
+}
+
+// FindHistorico retorna as mudanças de status do pedido em ordem cronológica
+func (s *PedidoService) FindHistorico(codigo string) ([]model.PedidoStatusHistorico, error) {
+	pedido, err := s.FindByCodigo(codigo)
+	if err != nil {
+		return nil, err
+	}
+
+	historico, err := s.repo.FindHistorico(pedido.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Popula os usuários responsáveis (usa cache)
+	for i := range historico {
+		h := &historico[i]
+		if h.UsuarioID != nil {
+			if usuario, err := s.usuarioSvc.FindByID(*h.UsuarioID); err == nil {
+				h.Usuario = usuario
+			}
+		}
+	}
+
+	return historico, nil
+}
 
-	return s.repo.Save(pedido)
+// IsPedidoGerenciadoPor verifica se o usuário é responsável pelo restaurante do pedido
+func (s *PedidoService) IsPedidoGerenciadoPor(codigoPedido string, usuarioID uint64) (bool, error) {
+	return s.repo.IsPedidoGerenciadoPor(codigoPedido, usuarioID)
 }
@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+)
+
+const (
+	// Prefixos dos canais de pub/sub no Redis
+	pedidoStreamCanalPedido      = "pedidos:stream:pedido:"
+	pedidoStreamCanalRestaurante = "pedidos:stream:restaurante:"
+
+	// Timeout para publicar e para confirmar uma assinatura
+	pedidoStreamTimeout = 2 * time.Second
+)
+
+// StatusPedidoAlterado é enviado aos streams quando o status de um pedido muda
+type StatusPedidoAlterado struct {
+	Codigo         string             `json:"codigo"`
+	RestauranteID  uint64             `json:"restauranteId"`
+	Status         model.StatusPedido `json:"status"`
+	DataOcorrencia time.Time          `json:"dataOcorrencia"`
+}
+
+// NewStatusPedidoAlterado cria a notificação com o status atual do pedido
+func NewStatusPedidoAlterado(pedido *model.Pedido) StatusPedidoAlterado {
+	return StatusPedidoAlterado{
+		Codigo:         pedido.Codigo,
+		RestauranteID:  pedido.RestauranteID,
+		Status:         pedido.Status,
+		DataOcorrencia: time.Now(),
+	}
+}
+
+// PedidoStreamService distribui as mudanças de status dos pedidos via Redis pub/sub,
+// para que streams abertos em qualquer réplica da API recebam as notificações
+type PedidoStreamService struct {
+	redisClient  *redis.Client
+	encerrado    chan struct{}
+	encerrarOnce sync.Once
+}
+
+// NewPedidoStreamService cria um novo serviço de streams de pedidos
+func NewPedidoStreamService(redisCfg *config.RedisConfig) *PedidoStreamService {
+	client := redis.NewClient(&redis.Options{
+		Addr:        fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
+		Password:    redisCfg.Password,
+		DB:          redisCfg.DB,
+		DialTimeout: 2 * time.Second,
+	})
+
+	return &PedidoStreamService{
+		redisClient: client,
+		encerrado:   make(chan struct{}),
+	}
+}
+
+// Publicar notifica os streams do pedido e do restaurante. Falhas são apenas registradas:
+// o stream é uma conveniência e os eventos duráveis seguem pelo outbox.
+func (s *PedidoStreamService) Publicar(pedido *model.Pedido) {
+	data, err := json.Marshal(NewStatusPedidoAlterado(pedido))
+	if err != nil {
+		log.Printf("Aviso: Falha ao serializar status do pedido %s para stream: %v", pedido.Codigo, err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), pedidoStreamTimeout)
+	defer cancel()
+
+	canais := []string{
+		pedidoStreamCanalPedido + pedido.Codigo,
+		pedidoStreamCanalRestaurante + strconv.FormatUint(pedido.RestauranteID, 10),
+	}
+	for _, canal := range canais {
+		if err := s.redisClient.Publish(ctx, canal, data).Err(); err != nil {
+			log.Printf("Aviso: Falha ao publicar status do pedido %s no canal %s: %v", pedido.Codigo, canal, err)
+		}
+	}
+}
+
+// AssinaturaPedidos entrega as notificações de um canal até ser fechada
+type AssinaturaPedidos struct {
+	pubsub  *redis.PubSub
+	Eventos <-chan StatusPedidoAlterado
+}
+
+// Close cancela a assinatura no Redis
+func (a *AssinaturaPedidos) Close() error {
+	return a.pubsub.Close()
+}
+
+// AssinarPedido recebe as mudanças de status de um pedido
+func (s *PedidoStreamService) AssinarPedido(ctx context.Context, codigoPedido string) (*AssinaturaPedidos, error) {
+	return s.assinar(ctx, pedidoStreamCanalPedido+codigoPedido)
+}
+
+// AssinarRestaurante recebe as mudanças de status de todos os pedidos do restaurante
+func (s *PedidoStreamService) AssinarRestaurante(ctx context.Context, restauranteID uint64) (*AssinaturaPedidos, error) {
+	return s.assinar(ctx, pedidoStreamCanalRestaurante+strconv.FormatUint(restauranteID, 10))
+}
+
+func (s *PedidoStreamService) assinar(ctx context.Context, canal string) (*AssinaturaPedidos, error) {
+	pubsub := s.redisClient.Subscribe(ctx, canal)
+
+	// Aguarda a confirmação para falhar cedo se o Redis estiver indisponível
+	confirmCtx, cancel := context.WithTimeout(ctx, pedidoStreamTimeout)
+	defer cancel()
+	if _, err := pubsub.Receive(confirmCtx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("falha ao assinar canal %s: %w", canal, err)
+	}
+
+	eventos := make(chan StatusPedidoAlterado)
+	go func() {
+		defer close(eventos)
+		for msg := range pubsub.Channel() {
+			var evt StatusPedidoAlterado
+			if err := json.Unmarshal([]byte(msg.Payload), &evt); err != nil {
+				log.Printf("Aviso: Mensagem invalida no canal %s: %v", canal, err)
+				continue
+			}
+			select {
+			case eventos <- evt:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return &AssinaturaPedidos{pubsub: pubsub, Eventos: eventos}, nil
+}
+
+// Encerrado é fechado quando a aplicação está desligando e os streams devem terminar
+func (s *PedidoStreamService) Encerrado() <-chan struct{} {
+	return s.encerrado
+}
+
+// Encerrar sinaliza aos streams abertos que a aplicação está desligando
+func (s *PedidoStreamService) Encerrar() {
+	s.encerrarOnce.Do(func() {
+		close(s.encerrado)
+	})
+}
+
+// Close fecha a conexão com o Redis
+func (s *PedidoStreamService) Close() error {
+	s.Encerrar()
+	return s.redisClient.Close()
+}
@@ -6,27 +6,30 @@ import (
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
 type ProdutoService struct {
 	repo           repository.ProdutoRepository
 	restauranteSvc *RestauranteService
+	carrinhoCache  *CarrinhoCacheService
 }
 
-func NewProdutoService(repo repository.ProdutoRepository, restauranteSvc *RestauranteService) *ProdutoService {
+func NewProdutoService(repo repository.ProdutoRepository, restauranteSvc *RestauranteService, carrinhoCache *CarrinhoCacheService) *ProdutoService {
 	return &ProdutoService{
 		repo:           repo,
 		restauranteSvc: restauranteSvc,
+		carrinhoCache:  carrinhoCache,
 	}
 }
 
-func (s *ProdutoService) FindAllByRestaurante(restauranteID uint64, incluirInativos bool) ([]model.Produto, error) {
+func (s *ProdutoService) FindAllByRestaurante(restauranteID uint64, incluirInativos bool, page *pagination.Pageable) (*pagination.Page[model.Produto], error) {
 	// Validate restaurante exists
 	if _, err := s.restauranteSvc.FindByID(restauranteID); err != nil {
 		return nil, err
 	}
-	return s.repo.FindAllByRestaurante(restauranteID, incluirInativos)
+	return s.repo.FindAllByRestaurante(restauranteID, incluirInativos, page)
 }
 
 func (s *ProdutoService) FindByID(restauranteID, produtoID uint64) (*model.Produto, error) {
@@ -51,6 +54,24 @@ func (s *ProdutoService) Save(restauranteID uint64, produto *model.Produto) erro
 		return err
 	}
 
+	// Estado anterior, para repreçar os carrinhos quando o preço muda ou o produto é inativado
+	var anterior *model.Produto
+	if produto.ID != 0 {
+		var err error
+		if anterior, err = s.repo.FindByID(restauranteID, produto.ID); err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+	}
+
 	produto.RestauranteID = restauranteID
-	return s.repo.Save(produto)
+	if err := s.repo.Save(produto); err != nil {
+		return err
+	}
+
+	if anterior != nil && (!anterior.Preco.Equal(produto.Preco) || anterior.Ativo && !produto.Ativo) {
+		// Repreçar os carrinhos pode envolver muitas chaves; roda fora da requisição com uma cópia
+		alterado := *produto
+		go s.carrinhoCache.ProdutoAlterado(&alterado)
+	}
+	return nil
 }
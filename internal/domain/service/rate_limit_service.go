@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+)
+
+const (
+	// Prefixos das chaves no Redis
+	rateLimitPrefix     = "ratelimit:"
+	loginFalhasPrefix   = "login:falhas:"
+	loginBloqueioPrefix = "login:bloqueio:"
+
+	// Timeout para operações no Redis
+	rateLimitTimeout = 200 * time.Millisecond
+
+	defaultLoginMaxTentativas = 5
+	defaultLoginBloqueioBase  = 30 * time.Second
+	defaultLoginBloqueioMax   = time.Hour
+	defaultLoginJanelaFalhas  = time.Hour
+)
+
+// janelaDeslizanteScript registra a requisição em um sorted set com os instantes (ms)
+// das requisições da janela. Retorna 0 quando permitida, ou os milissegundos até a
+// requisição mais antiga sair da janela.
+var janelaDeslizanteScript = redis.NewScript(`
+local key = KEYS[1]
+local agora = tonumber(ARGV[1])
+local janela = tonumber(ARGV[2])
+local limite = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', agora - janela)
+if redis.call('ZCARD', key) < limite then
+	redis.call('ZADD', key, agora, ARGV[4])
+	redis.call('PEXPIRE', key, janela)
+	return 0
+end
+
+local maisAntiga = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+local espera = tonumber(maisAntiga[2]) + janela - agora
+if espera < 1 then
+	espera = 1
+end
+return espera
+`)
+
+// RateLimitService limita requisições com janelas deslizantes no Redis e bloqueia
+// temporariamente contas com falhas de login seguidas. Se o Redis estiver indisponível
+// as requisições são permitidas (fail open), como no cache e na idempotência.
+type RateLimitService struct {
+	redisClient   *redis.Client
+	maxTentativas int
+	bloqueioBase  time.Duration
+	bloqueioMax   time.Duration
+	janelaFalhas  time.Duration
+}
+
+// NewRateLimitService cria um novo serviço de rate limiting
+func NewRateLimitService(redisCfg *config.RedisConfig, cfg *config.RateLimitConfig) *RateLimitService {
+	client := redis.NewClient(&redis.Options{
+		Addr:         fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
+		Password:     redisCfg.Password,
+		DB:           redisCfg.DB,
+		DialTimeout:  2 * time.Second,
+		ReadTimeout:  rateLimitTimeout,
+		WriteTimeout: rateLimitTimeout,
+	})
+
+	s := &RateLimitService{
+		redisClient:   client,
+		maxTentativas: cfg.BloqueioLogin.MaxTentativas,
+		bloqueioBase:  time.Duration(cfg.BloqueioLogin.BloqueioBaseSeconds) * time.Second,
+		bloqueioMax:   time.Duration(cfg.BloqueioLogin.BloqueioMaxSeconds) * time.Second,
+		janelaFalhas:  time.Duration(cfg.BloqueioLogin.JanelaFalhasSeconds) * time.Second,
+	}
+	if s.maxTentativas <= 0 {
+		s.maxTentativas = defaultLoginMaxTentativas
+	}
+	if s.bloqueioBase <= 0 {
+		s.bloqueioBase = defaultLoginBloqueioBase
+	}
+	if s.bloqueioMax <= 0 {
+		s.bloqueioMax = defaultLoginBloqueioMax
+	}
+	if s.janelaFalhas <= 0 {
+		s.janelaFalhas = defaultLoginJanelaFalhas
+	}
+	return s
+}
+
+// Permitir registra uma requisição na janela da chave. Retorna LimiteRequisicoesExcedidoException
+// quando o limite da janela já foi atingido.
+func (s *RateLimitService) Permitir(chave string, janela config.JanelaRateLimitConfig) error {
+	if janela.Limite <= 0 || janela.JanelaSeconds <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimitTimeout)
+	defer cancel()
+
+	duracao := time.Duration(janela.JanelaSeconds) * time.Second
+	espera, err := janelaDeslizanteScript.Run(ctx, s.redisClient,
+		[]string{rateLimitPrefix + chave},
+		time.Now().UnixMilli(), duracao.Milliseconds(), janela.Limite, uuid.New().String(),
+	).Int64()
+	if err != nil {
+		log.Printf("Aviso: Falha ao verificar rate limit %s: %v", chave, err)
+		return nil
+	}
+	if espera > 0 {
+		return exception.NewLimiteRequisicoesExcedidoException(time.Duration(espera) * time.Millisecond)
+	}
+	return nil
+}
+
+// VerificarBloqueioLogin retorna LimiteRequisicoesExcedidoException enquanto a conta estiver bloqueada
+func (s *RateLimitService) VerificarBloqueioLogin(email string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimitTimeout)
+	defer cancel()
+
+	restante, err := s.redisClient.PTTL(ctx, loginBloqueioPrefix+ChaveConta(email)).Result()
+	if err != nil {
+		log.Printf("Aviso: Falha ao verificar bloqueio de login: %v", err)
+		return nil
+	}
+	if restante > 0 {
+		return exception.NewLoginBloqueadoException(restante)
+	}
+	return nil
+}
+
+// RegistrarFalhaLogin conta a falha e bloqueia a conta a partir de maxTentativas falhas.
+// Cada falha além do limite dobra o bloqueio. Falhas são contadas mesmo para e-mails sem
+// conta, para que o bloqueio não revele quais e-mails estão cadastrados.
+func (s *RateLimitService) RegistrarFalhaLogin(email string) {
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimitTimeout)
+	defer cancel()
+
+	conta := ChaveConta(email)
+	falhasKey := loginFalhasPrefix + conta
+
+	pipe := s.redisClient.TxPipeline()
+	incr := pipe.Incr(ctx, falhasKey)
+	pipe.Expire(ctx, falhasKey, s.janelaFalhas)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Aviso: Falha ao registrar tentativa de login: %v", err)
+		return
+	}
+
+	falhas := int(incr.Val())
+	if falhas < s.maxTentativas {
+		return
+	}
+
+	bloqueio := s.duracaoBloqueio(falhas - s.maxTentativas)
+	if err := s.redisClient.Set(ctx, loginBloqueioPrefix+conta, strconv.Itoa(falhas), bloqueio).Err(); err != nil {
+		log.Printf("Aviso: Falha ao bloquear login: %v", err)
+		return
+	}
+	log.Printf("Aviso: Login bloqueado por %s apos %d falhas seguidas", bloqueio, falhas)
+}
+
+// RegistrarSucessoLogin zera as falhas da conta
+func (s *RateLimitService) RegistrarSucessoLogin(email string) {
+	ctx, cancel := context.WithTimeout(context.Background(), rateLimitTimeout)
+	defer cancel()
+
+	if err := s.redisClient.Del(ctx, loginFalhasPrefix+ChaveConta(email)).Err(); err != nil {
+		log.Printf("Aviso: Falha ao limpar tentativas de login: %v", err)
+	}
+}
+
+func (s *RateLimitService) duracaoBloqueio(excedentes int) time.Duration {
+	bloqueio := s.bloqueioBase
+	for i := 0; i < excedentes && bloqueio < s.bloqueioMax; i++ {
+		bloqueio *= 2
+	}
+	if bloqueio > s.bloqueioMax {
+		bloqueio = s.bloqueioMax
+	}
+	return bloqueio
+}
+
+// Close fecha a conexão com o Redis
+func (s *RateLimitService) Close() error {
+	return s.redisClient.Close()
+}
+
+// ChaveConta normaliza o e-mail e usa o hash para não gravar e-mails no Redis
+func ChaveConta(email string) string {
+	sum := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(email))))
+	return hex.EncodeToString(sum[:])
+}
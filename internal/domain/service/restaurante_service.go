@@ -2,11 +2,13 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"log"
 
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -37,8 +39,15 @@ func NewRestauranteService(
 	}
 }
 
-func (s *RestauranteService) FindAll() ([]model.Restaurante, error) {
-	return s.repo.FindAll()
+// raioBuscaPadraoKm é usado na busca por localização quando o raio não é informado
+const raioBuscaPadraoKm = 10
+
+// FindAll pesquisa restaurantes com os filtros informados
+func (s *RestauranteService) FindAll(filter *repository.RestauranteFilter, page *pagination.Pageable) (*pagination.Page[model.Restaurante], error) {
+	if filter != nil && filter.Latitude != nil && filter.Longitude != nil && filter.RaioKm <= 0 {
+		filter.RaioKm = raioBuscaPadraoKm
+	}
+	return s.repo.FindAll(filter, page)
 }
 
 func (s *RestauranteService) FindByID(id uint64) (*model.Restaurante, error) {
@@ -108,6 +117,13 @@ func (s *RestauranteService) Save(restaurante *model.Restaurante) error {
 		restaurante.Endereco.Cidade = *cidade
 	}
 
+	if restaurante.FusoHorario == "" {
+		restaurante.FusoHorario = model.FusoHorarioPadrao
+	}
+	if _, err := restaurante.Localizacao(); err != nil {
+		return exception.NewNegocioException(fmt.Sprintf("Fuso horario %s invalido", restaurante.FusoHorario))
+	}
+
 	err = s.repo.Save(restaurante)
 	if err != nil {
 		return err
@@ -122,7 +138,7 @@ func (s *RestauranteService) Save(restaurante *model.Restaurante) error {
 }
 
 func (s *RestauranteService) Ativar(id uint64) error {
-	restaurante, err := s.FindByID(id)
+	restaurante, err := s.findParaAtualizacao(id)
 	if err != nil {
 		return err
 	}
@@ -136,7 +152,7 @@ func (s *RestauranteService) Ativar(id uint64) error {
 }
 
 func (s *RestauranteService) Inativar(id uint64) error {
-	restaurante, err := s.FindByID(id)
+	restaurante, err := s.findParaAtualizacao(id)
 	if err != nil {
 		return err
 	}
@@ -168,7 +184,7 @@ func (s *RestauranteService) InativarEmMassa(ids []uint64) error {
 }
 
 func (s *RestauranteService) Abrir(id uint64) error {
-	restaurante, err := s.FindByID(id)
+	restaurante, err := s.findParaAtualizacao(id)
 	if err != nil {
 		return err
 	}
@@ -182,7 +198,7 @@ func (s *RestauranteService) Abrir(id uint64) error {
 }
 
 func (s *RestauranteService) Fechar(id uint64) error {
-	restaurante, err := s.FindByID(id)
+	restaurante, err := s.findParaAtualizacao(id)
 	if err != nil {
 		return err
 	}
@@ -255,8 +271,91 @@ func (s *RestauranteService) DesassociarResponsavel(restauranteID, usuarioID uin
 	return nil
 }
 
+// AplicarAgenda abre ou fecha o restaurante ativo conforme a agenda de funcionamento, somente
+// quando a situação prevista mudou desde a última aplicada. Retorna false se nada foi alterado.
+func (s *RestauranteService) AplicarAgenda(id uint64, aberto bool) (bool, error) {
+	aplicada, err := s.repo.AplicarAgenda(id, aberto)
+	if err != nil || !aplicada {
+		return false, err
+	}
+	s.invalidateCache(id)
+	return true, nil
+}
+
+// LimparAgenda devolve ao controle manual os restaurantes inativos ou fora da lista dos que
+// têm agenda. Só a situação aplicada é esquecida; a abertura atual não muda.
+func (s *RestauranteService) LimparAgenda(restauranteIDs []uint64) error {
+	return s.repo.LimparAgenda(restauranteIDs)
+}
+
+// findParaAtualizacao busca o restaurante direto do banco. A versão em cache não
+// contém todos os campos (ex.: endereço), e salvá-la sobrescreveria os dados.
+func (s *RestauranteService) findParaAtualizacao(id uint64) (*model.Restaurante, error) {
+	restaurante, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewRestauranteNaoEncontradoException(id)
+		}
+		return nil, err
+	}
+	return restaurante, nil
+}
+
 func (s *RestauranteService) invalidateCache(id uint64) {
 	if s.cacheSvc != nil {
 		s.cacheSvc.InvalidateRestaurante(id)
 	}
 }
+
+// ExisteResponsavel verifica se o usuário é responsável pelo restaurante
+func (s *RestauranteService) ExisteResponsavel(restauranteID, usuarioID uint64) (bool, error) {
+	return s.repo.ExistsResponsavel(restauranteID, usuarioID)
+}
+
+// FindCidadesEntrega lista as cidades da área de entrega do restaurante
+func (s *RestauranteService) FindCidadesEntrega(restauranteID uint64) ([]model.Cidade, error) {
+	if _, err := s.FindByID(restauranteID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindCidadesEntrega(restauranteID)
+}
+
+func (s *RestauranteService) AssociarCidadeEntrega(restauranteID, cidadeID uint64) error {
+	if _, err := s.FindByID(restauranteID); err != nil {
+		return err
+	}
+	if _, err := s.cidadeSvc.FindByID(cidadeID); err != nil {
+		return err
+	}
+	return s.repo.AddCidadeEntrega(restauranteID, cidadeID)
+}
+
+func (s *RestauranteService) DesassociarCidadeEntrega(restauranteID, cidadeID uint64) error {
+	if _, err := s.FindByID(restauranteID); err != nil {
+		return err
+	}
+	if _, err := s.cidadeSvc.FindByID(cidadeID); err != nil {
+		return err
+	}
+	return s.repo.RemoveCidadeEntrega(restauranteID, cidadeID)
+}
+
+// AtendeCidade verifica se a cidade está na área de entrega do restaurante.
+// Sem área cadastrada, o restaurante entrega apenas na cidade do próprio endereço.
+func (s *RestauranteService) AtendeCidade(restaurante *model.Restaurante, cidadeID uint64) (bool, error) {
+	cidades, err := s.repo.FindCidadesEntrega(restaurante.ID)
+	if err != nil {
+		return false, err
+	}
+
+	if len(cidades) == 0 {
+		return restaurante.Endereco.CidadeID == 0 || restaurante.Endereco.CidadeID == cidadeID, nil
+	}
+
+	for _, cidade := range cidades {
+		if cidade.ID == cidadeID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
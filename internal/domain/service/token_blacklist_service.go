@@ -18,10 +18,9 @@ const (
 // TokenBlacklistService gerencia tokens JWT invalidados (logout) usando Redis.
 type TokenBlacklistService struct {
 	redisClient *redis.Client
-	jwtCfg      *config.JWTConfig
 }
 
-func NewTokenBlacklistService(redisCfg *config.RedisConfig, jwtCfg *config.JWTConfig) *TokenBlacklistService {
+func NewTokenBlacklistService(redisCfg *config.RedisConfig) *TokenBlacklistService {
 	client := redis.NewClient(&redis.Options{
 		Addr:     fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
 		Password: redisCfg.Password,
@@ -30,18 +29,16 @@ func NewTokenBlacklistService(redisCfg *config.RedisConfig, jwtCfg *config.JWTCo
 
 	return &TokenBlacklistService{
 		redisClient: client,
-		jwtCfg:      jwtCfg,
 	}
 }
 
 // InvalidateToken adiciona um token à blacklist no Redis.
-// O token é armazenado com TTL baseado na sua data de expiração.
+// O token é armazenado com TTL baseado na sua data de expiração. A assinatura
+// não é verificada aqui: o token já foi validado pelo middleware de autenticação.
 func (s *TokenBlacklistService) InvalidateToken(tokenString string) error {
 	ctx := context.Background()
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		return []byte(s.jwtCfg.SecretKey), nil
-	})
+	token, _, err := jwt.NewParser().ParseUnverified(tokenString, jwt.MapClaims{})
 	if err != nil {
 		return err
 	}
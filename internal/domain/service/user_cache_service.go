@@ -25,11 +25,14 @@ const (
 
 // CachedUser representa os dados do usuário armazenados em cache
 type CachedUser struct {
-	ID          uint64        `json:"id"`
-	Nome        string        `json:"nome"`
-	Email       string        `json:"email"`
-	Grupos      []CachedGrupo `json:"grupos"`
-	Authorities []string      `json:"authorities"`
+	ID    uint64 `json:"id"`
+	Nome  string `json:"nome"`
+	Email string `json:"email"`
+	// Ponteiro para distinguir entradas gravadas antes da verificação de e-mail existir
+	EmailVerificado      *bool         `json:"emailVerificado,omitempty"`
+	DataVerificacaoEmail *time.Time    `json:"dataVerificacaoEmail,omitempty"`
+	Grupos               []CachedGrupo `json:"grupos"`
+	Authorities          []string      `json:"authorities"`
 }
 
 // CachedGrupo representa um grupo em cache
@@ -85,6 +88,9 @@ func (s *UserCacheService) GetUser(userID uint64) (*CachedUser, error) {
 	if err := json.Unmarshal(data, &cachedUser); err != nil {
 		return nil, err
 	}
+	if cachedUser.EmailVerificado == nil {
+		return nil, nil // Entrada antiga, sem o status de verificação
+	}
 
 	return &cachedUser, nil
 }
@@ -125,9 +131,11 @@ func (s *UserCacheService) GetAuthorities(userID uint64) ([]string, error) {
 // toCachedUser converte um modelo de usuário para a versão em cache
 func (s *UserCacheService) toCachedUser(user *model.Usuario) *CachedUser {
 	cachedUser := &CachedUser{
-		ID:    user.ID,
-		Nome:  user.Nome,
-		Email: user.Email,
+		ID:                   user.ID,
+		Nome:                 user.Nome,
+		Email:                user.Email,
+		EmailVerificado:      &user.EmailVerificado,
+		DataVerificacaoEmail: user.DataVerificacaoEmail,
 	}
 
 	authoritiesMap := make(map[string]bool)
@@ -159,9 +167,13 @@ func (s *UserCacheService) toCachedUser(user *model.Usuario) *CachedUser {
 // ToModel converte um CachedUser para model.Usuario
 func (c *CachedUser) ToModel() *model.Usuario {
 	usuario := &model.Usuario{
-		ID:    c.ID,
-		Nome:  c.Nome,
-		Email: c.Email,
+		ID:                   c.ID,
+		Nome:                 c.Nome,
+		Email:                c.Email,
+		DataVerificacaoEmail: c.DataVerificacaoEmail,
+	}
+	if c.EmailVerificado != nil {
+		usuario.EmailVerificado = *c.EmailVerificado
 	}
 
 	for _, cg := range c.Grupos {
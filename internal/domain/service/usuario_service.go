@@ -3,41 +3,61 @@ package service
 import (
 	"errors"
 	"log"
+	"time"
 
 	"github.com/yurisasc/algafood-go/internal/domain/exception"
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	"github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
 type UsuarioService struct {
-	repo     repository.UsuarioRepository
-	grupoSvc *GrupoService
-	cacheSvc *UserCacheService
+	repo             repository.UsuarioRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	grupoSvc         *GrupoService
+	cacheSvc         *UserCacheService
+	rateLimitSvc     *RateLimitService
 }
 
-func NewUsuarioService(repo repository.UsuarioRepository, grupoSvc *GrupoService, cacheSvc *UserCacheService) *UsuarioService {
+func NewUsuarioService(repo repository.UsuarioRepository, refreshTokenRepo repository.RefreshTokenRepository, grupoSvc *GrupoService, cacheSvc *UserCacheService, rateLimitSvc *RateLimitService) *UsuarioService {
 	return &UsuarioService{
-		repo:     repo,
-		grupoSvc: grupoSvc,
-		cacheSvc: cacheSvc,
+		repo:             repo,
+		refreshTokenRepo: refreshTokenRepo,
+		grupoSvc:         grupoSvc,
+		cacheSvc:         cacheSvc,
+		rateLimitSvc:     rateLimitSvc,
 	}
 }
 
+// Authenticate valida as credenciais. Contas com muitas falhas seguidas ficam
+// temporariamente bloqueadas, sem que a senha seja sequer verificada.
 func (s *UsuarioService) Authenticate(email, password string) (*model.Usuario, error) {
+	if s.rateLimitSvc != nil {
+		if err := s.rateLimitSvc.VerificarBloqueioLogin(email); err != nil {
+			return nil, err
+		}
+	}
+
 	usuario, err := s.repo.FindByEmail(email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.registrarFalhaLogin(email)
 			return nil, exception.NewAuthenticationException("Usuario ou senha invalidos")
 		}
 		return nil, err
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(usuario.Senha), []byte(password)); err != nil {
+		s.registrarFalhaLogin(email)
 		return nil, exception.NewAuthenticationException("Usuario ou senha invalidos")
 	}
 
+	if s.rateLimitSvc != nil {
+		s.rateLimitSvc.RegistrarSucessoLogin(email)
+	}
+
 	// Carrega com grupos para o token JWT
 	userWithGroups, err := s.repo.FindByID(usuario.ID)
 	if err == nil {
@@ -51,8 +71,14 @@ func (s *UsuarioService) Authenticate(email, password string) (*model.Usuario, e
 	return usuario, nil
 }
 
-func (s *UsuarioService) FindAll() ([]model.Usuario, error) {
-	return s.repo.FindAll()
+func (s *UsuarioService) registrarFalhaLogin(email string) {
+	if s.rateLimitSvc != nil {
+		s.rateLimitSvc.RegistrarFalhaLogin(email)
+	}
+}
+
+func (s *UsuarioService) FindAll(page *pagination.Pageable) (*pagination.Page[model.Usuario], error) {
+	return s.repo.FindAll(page)
 }
 
 func (s *UsuarioService) FindByID(id uint64) (*model.Usuario, error) {
@@ -114,6 +140,8 @@ func (s *UsuarioService) Save(usuario *model.Usuario) error {
 	return nil
 }
 
+// AlterarSenha troca a senha após conferir a atual e revoga todas as sessões (refresh tokens)
+// do usuário, inclusive a de quem fez a troca.
 func (s *UsuarioService) AlterarSenha(id uint64, senhaAtual, novaSenha string) error {
 	usuario, err := s.FindByID(id)
 	if err != nil {
@@ -142,6 +170,74 @@ func (s *UsuarioService) AlterarSenha(id uint64, senhaAtual, novaSenha string) e
 		s.cacheSvc.InvalidateUser(id)
 	}
 
+	if err := s.refreshTokenRepo.RevogarPorUsuario(id, time.Now()); err != nil {
+		log.Printf("Erro ao revogar sessoes do usuario %d apos alteracao de senha: %v", id, err)
+	}
+	return nil
+}
+
+// RedefinirSenha troca a senha sem exigir a atual (link de redefinição enviado por e-mail),
+// consumindo o token na mesma transação. Retorna false se o token já tinha sido usado.
+// Como o usuário provou ter acesso ao e-mail, ele também é marcado como verificado.
+func (s *UsuarioService) RedefinirSenha(token *model.TokenUsuario, novaSenha string) (bool, error) {
+	usuario, err := s.findByIDSemCache(token.UsuarioID)
+	if err != nil {
+		return false, err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(novaSenha), bcrypt.DefaultCost)
+	if err != nil {
+		return false, err
+	}
+	agora := time.Now()
+	usuario.Senha = string(hashedPassword)
+	if !usuario.EmailVerificado {
+		usuario.VerificarEmail(agora)
+	}
+
+	consumido, err := s.repo.SaveConsumindoToken(usuario, token.ID, agora)
+	if err != nil || !consumido {
+		return false, err
+	}
+	if s.cacheSvc != nil {
+		s.cacheSvc.InvalidateUser(usuario.ID)
+	}
+	return true, nil
+}
+
+// ConfirmarEmail marca o e-mail do usuário como verificado
+func (s *UsuarioService) ConfirmarEmail(id uint64) error {
+	usuario, err := s.findByIDSemCache(id)
+	if err != nil {
+		return err
+	}
+	if usuario.EmailVerificado {
+		return nil
+	}
+
+	usuario.VerificarEmail(time.Now())
+	return s.saveEInvalidarCache(usuario)
+}
+
+// findByIDSemCache carrega o usuário completo (com senha) para alterações
+func (s *UsuarioService) findByIDSemCache(id uint64) (*model.Usuario, error) {
+	usuario, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, exception.NewUsuarioNaoEncontradoException(id)
+		}
+		return nil, err
+	}
+	return usuario, nil
+}
+
+func (s *UsuarioService) saveEInvalidarCache(usuario *model.Usuario) error {
+	if err := s.repo.Save(usuario); err != nil {
+		return err
+	}
+	if s.cacheSvc != nil {
+		s.cacheSvc.InvalidateUser(usuario.ID)
+	}
 	return nil
 }
 
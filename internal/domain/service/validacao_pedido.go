@@ -0,0 +1,194 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/yurisasc/algafood-go/internal/domain/exception"
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+)
+
+// DefaultMaxQuantidadePorItem é usado quando pedido.max_quantidade_por_item não está configurado
+const DefaultMaxQuantidadePorItem = 50
+
+// ValidacaoPedido reúne os dados carregados durante a emissão para os validadores
+type ValidacaoPedido struct {
+	Pedido      *model.Pedido
+	Cliente     *model.Usuario
+	Restaurante *model.Restaurante
+	// Produtos indexados por ProdutoID
+	Produtos map[uint64]*model.Produto
+}
+
+// ValidadorPedido é uma regra aplicada ao pedido antes da emissão. Violações devem ser
+// retornadas como NegocioException com os campos inválidos; qualquer outro erro
+// interrompe a emissão.
+type ValidadorPedido interface {
+	Validar(v *ValidacaoPedido) error
+}
+
+// validarPedido executa todos os validadores e reúne as violações em uma única NegocioException
+func validarPedido(validadores []ValidadorPedido, v *ValidacaoPedido) error {
+	var violacoes []*exception.NegocioException
+	var campos []exception.CampoInvalido
+
+	for _, validador := range validadores {
+		err := validador.Validar(v)
+		if err == nil {
+			continue
+		}
+
+		var negocio *exception.NegocioException
+		if !errors.As(err, &negocio) {
+			return err
+		}
+
+		violacoes = append(violacoes, negocio)
+		if len(negocio.Campos) == 0 {
+			campos = append(campos, exception.CampoInvalido{Nome: "pedido", Mensagem: negocio.Message})
+		}
+		campos = append(campos, negocio.Campos...)
+	}
+
+	switch len(violacoes) {
+	case 0:
+		return nil
+	case 1:
+		return exception.NewNegocioExceptionComCampos(violacoes[0].Message, campos...)
+	default:
+		return exception.NewNegocioExceptionComCampos(
+			fmt.Sprintf("O pedido nao pode ser emitido: %d regras foram violadas", len(violacoes)), campos...)
+	}
+}
+
+// ClienteVerificadoValidador exige que o cliente tenha confirmado o e-mail
+type ClienteVerificadoValidador struct{}
+
+func NewClienteVerificadoValidador() *ClienteVerificadoValidador {
+	return &ClienteVerificadoValidador{}
+}
+
+func (ClienteVerificadoValidador) Validar(v *ValidacaoPedido) error {
+	if !v.Cliente.EmailVerificado {
+		msg := "O e-mail do cliente precisa ser verificado antes de emitir pedidos"
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "cliente", Mensagem: msg})
+	}
+	return nil
+}
+
+// RestauranteDisponivelValidador rejeita pedidos para restaurantes inativos ou fechados
+type RestauranteDisponivelValidador struct{}
+
+func NewRestauranteDisponivelValidador() *RestauranteDisponivelValidador {
+	return &RestauranteDisponivelValidador{}
+}
+
+func (RestauranteDisponivelValidador) Validar(v *ValidacaoPedido) error {
+	if !v.Restaurante.Ativo {
+		msg := fmt.Sprintf("O restaurante %s esta inativo e nao pode receber pedidos", v.Restaurante.Nome)
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "restaurante", Mensagem: msg})
+	}
+	if !v.Restaurante.Aberto {
+		msg := fmt.Sprintf("O restaurante %s esta fechado e nao pode receber pedidos", v.Restaurante.Nome)
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "restaurante", Mensagem: msg})
+	}
+	return nil
+}
+
+// ProdutosAtivosValidador rejeita itens de produtos inativos
+type ProdutosAtivosValidador struct{}
+
+func NewProdutosAtivosValidador() *ProdutosAtivosValidador {
+	return &ProdutosAtivosValidador{}
+}
+
+func (ProdutosAtivosValidador) Validar(v *ValidacaoPedido) error {
+	var campos []exception.CampoInvalido
+	for i, item := range v.Pedido.Itens {
+		produto, ok := v.Produtos[item.ProdutoID]
+		if ok && !produto.Ativo {
+			campos = append(campos, exception.CampoInvalido{
+				Nome:     fmt.Sprintf("itens[%d].produtoId", i),
+				Mensagem: fmt.Sprintf("O produto %s esta inativo", produto.Nome),
+			})
+		}
+	}
+
+	if len(campos) > 0 {
+		return exception.NewNegocioExceptionComCampos("O pedido possui produtos inativos", campos...)
+	}
+	return nil
+}
+
+// QuantidadeMaximaItemValidador limita a quantidade de cada item do pedido
+type QuantidadeMaximaItemValidador struct {
+	maximo int
+}
+
+func NewQuantidadeMaximaItemValidador(maximo int) *QuantidadeMaximaItemValidador {
+	if maximo <= 0 {
+		maximo = DefaultMaxQuantidadePorItem
+	}
+	return &QuantidadeMaximaItemValidador{maximo: maximo}
+}
+
+func (q *QuantidadeMaximaItemValidador) Validar(v *ValidacaoPedido) error {
+	var campos []exception.CampoInvalido
+	for i, item := range v.Pedido.Itens {
+		if item.Quantidade > q.maximo {
+			campos = append(campos, exception.CampoInvalido{
+				Nome:     fmt.Sprintf("itens[%d].quantidade", i),
+				Mensagem: fmt.Sprintf("A quantidade deve ser de no maximo %d", q.maximo),
+			})
+		}
+	}
+
+	if len(campos) > 0 {
+		return exception.NewNegocioExceptionComCampos(
+			fmt.Sprintf("Cada item do pedido pode ter no maximo %d unidades", q.maximo), campos...)
+	}
+	return nil
+}
+
+// ValorMinimoPedidoValidador exige o valor mínimo de pedido definido pelo restaurante.
+// O mínimo é comparado com o subtotal, sem a taxa de frete.
+type ValorMinimoPedidoValidador struct{}
+
+func NewValorMinimoPedidoValidador() *ValorMinimoPedidoValidador {
+	return &ValorMinimoPedidoValidador{}
+}
+
+func (ValorMinimoPedidoValidador) Validar(v *ValidacaoPedido) error {
+	minimo := v.Restaurante.ValorMinimoPedido
+	if minimo.IsPositive() && v.Pedido.Subtotal.LessThan(minimo) {
+		msg := fmt.Sprintf("O valor minimo de pedido do restaurante %s e %s", v.Restaurante.Nome, minimo.StringFixed(2))
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "itens", Mensagem: msg})
+	}
+	return nil
+}
+
+// AreaEntregaValidador exige que a cidade de entrega esteja na área atendida pelo restaurante
+type AreaEntregaValidador struct {
+	restauranteSvc *RestauranteService
+}
+
+func NewAreaEntregaValidador(restauranteSvc *RestauranteService) *AreaEntregaValidador {
+	return &AreaEntregaValidador{restauranteSvc: restauranteSvc}
+}
+
+func (a *AreaEntregaValidador) Validar(v *ValidacaoPedido) error {
+	cidadeID := v.Pedido.EnderecoEntrega.CidadeID
+	if cidadeID == 0 {
+		return nil
+	}
+
+	atende, err := a.restauranteSvc.AtendeCidade(v.Restaurante, cidadeID)
+	if err != nil {
+		return err
+	}
+	if !atende {
+		msg := fmt.Sprintf("O restaurante %s nao entrega na cidade informada", v.Restaurante.Nome)
+		return exception.NewNegocioExceptionComCampos(msg, exception.CampoInvalido{Nome: "enderecoEntrega.cidade", Mensagem: msg})
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package migration
+
+import (
+	"context"
+	"log"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+	"github.com/yurisasc/algafood-go/migrations"
+)
+
+// Bancos criados pelo docker-compose antigo, que montava migrations/ no
+// docker-entrypoint-initdb.d, têm o esquema das migrações até versaoLegado
+// (com tabelaLegado e sem tabelaPosterior) mas não têm a tabela schema_migrations.
+const (
+	versaoLegado    int64 = 2
+	tabelaLegado          = "restaurante"
+	tabelaPosterior       = "evento_outbox"
+)
+
+// AplicarPendentes aplica as migrações embutidas na inicialização da API.
+// Réplicas iniciando juntas aguardam o lock e encontram o banco já atualizado.
+// Um banco legado (ver versaoLegado) é registrado nessa versão antes do up.
+func AplicarPendentes(ctx context.Context, cfg *config.DatabaseConfig) error {
+	db, err := config.NewMigrationDB(cfg)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := NewMigrator(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	adotado, err := migrator.AdotarLegado(ctx, versaoLegado, tabelaLegado, tabelaPosterior)
+	if err != nil {
+		return err
+	}
+	if adotado {
+		log.Printf("Esquema existente sem controle de versao registrado na versao %d", versaoLegado)
+	}
+
+	aplicadas, err := migrator.Up(ctx)
+	if err != nil {
+		return err
+	}
+
+	if aplicadas > 0 {
+		log.Printf("%d migracao(oes) aplicada(s)", aplicadas)
+	} else {
+		log.Println("Banco de dados ja esta na versao mais recente")
+	}
+	return nil
+}
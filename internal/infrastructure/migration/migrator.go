@@ -0,0 +1,361 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+const (
+	// schemaTable usa o mesmo formato do golang-migrate, então o CLI oficial
+	// continua funcionando no mesmo banco
+	schemaTable = "schema_migrations"
+
+	// lockTimeout é quanto uma réplica espera enquanto outra aplica as migrações
+	lockTimeout = 5 * time.Minute
+
+	// SemVersao indica que nenhuma migração foi aplicada
+	SemVersao int64 = -1
+)
+
+var arquivoMigracao = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// ErrBancoSujo indica que uma migração falhou no meio e precisa de intervenção manual
+var ErrBancoSujo = errors.New("banco de dados em estado sujo: corrija manualmente e use 'force' com a versao correta")
+
+// Migracao é um par de scripts up/down de uma versão
+type Migracao struct {
+	Versao int64
+	Nome   string
+	up     string
+	down   string
+}
+
+// Versao é a versão atual registrada no banco
+type Versao struct {
+	Numero int64
+	Sujo   bool
+}
+
+// StatusMigracao indica se uma migração já foi aplicada
+type StatusMigracao struct {
+	Migracao
+	Aplicada bool
+}
+
+// Migrator aplica as migrações embutidas no binário. As alterações são feitas
+// sob um lock nomeado do MySQL para que apenas uma réplica migre por vez.
+type Migrator struct {
+	db        *sql.DB
+	source    fs.FS
+	migracoes []Migracao
+}
+
+// NewMigrator carrega as migrações de source. O db deve ter multiStatements habilitado.
+func NewMigrator(db *sql.DB, source fs.FS) (*Migrator, error) {
+	migracoes, err := carregarMigracoes(source)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, source: source, migracoes: migracoes}, nil
+}
+
+func carregarMigracoes(source fs.FS) ([]Migracao, error) {
+	entries, err := fs.ReadDir(source, ".")
+	if err != nil {
+		return nil, fmt.Errorf("falha ao listar migracoes: %w", err)
+	}
+
+	porVersao := make(map[int64]*Migracao)
+	for _, entry := range entries {
+		m := arquivoMigracao.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || m == nil {
+			continue
+		}
+
+		versao, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("versao invalida em %s: %w", entry.Name(), err)
+		}
+
+		migracao, ok := porVersao[versao]
+		if !ok {
+			migracao = &Migracao{Versao: versao, Nome: m[2]}
+			porVersao[versao] = migracao
+		} else if migracao.Nome != m[2] {
+			return nil, fmt.Errorf("versao %d duplicada: %s e %s", versao, migracao.Nome, m[2])
+		}
+
+		if m[3] == "up" {
+			migracao.up = entry.Name()
+		} else {
+			migracao.down = entry.Name()
+		}
+	}
+
+	migracoes := make([]Migracao, 0, len(porVersao))
+	for _, migracao := range porVersao {
+		if migracao.up == "" {
+			return nil, fmt.Errorf("migracao %d_%s sem script up", migracao.Versao, migracao.Nome)
+		}
+		migracoes = append(migracoes, *migracao)
+	}
+	sort.Slice(migracoes, func(i, j int) bool { return migracoes[i].Versao < migracoes[j].Versao })
+
+	return migracoes, nil
+}
+
+// Up aplica todas as migrações pendentes e retorna quantas foram aplicadas
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	aplicadas := 0
+	err := m.comLock(ctx, func(conn *sql.Conn) error {
+		atual, err := lerVersao(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if atual.Sujo {
+			return fmt.Errorf("versao %d: %w", atual.Numero, ErrBancoSujo)
+		}
+
+		for _, migracao := range m.migracoes {
+			if migracao.Versao <= atual.Numero {
+				continue
+			}
+			log.Printf("Aplicando migracao %d_%s", migracao.Versao, migracao.Nome)
+			if err := m.executar(ctx, conn, migracao.up, migracao.Versao); err != nil {
+				return fmt.Errorf("migracao %d_%s: %w", migracao.Versao, migracao.Nome, err)
+			}
+			aplicadas++
+		}
+		return nil
+	})
+	return aplicadas, err
+}
+
+// Down reverte as últimas n migrações aplicadas e retorna quantas foram revertidas
+func (m *Migrator) Down(ctx context.Context, n int) (int, error) {
+	if n <= 0 {
+		return 0, fmt.Errorf("quantidade de migracoes para reverter deve ser positiva")
+	}
+
+	revertidas := 0
+	err := m.comLock(ctx, func(conn *sql.Conn) error {
+		atual, err := lerVersao(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if atual.Sujo {
+			return fmt.Errorf("versao %d: %w", atual.Numero, ErrBancoSujo)
+		}
+
+		for revertidas < n && atual.Numero != SemVersao {
+			i := m.indice(atual.Numero)
+			if i < 0 {
+				return fmt.Errorf("versao %d registrada no banco nao existe nas migracoes embutidas", atual.Numero)
+			}
+			migracao := m.migracoes[i]
+			if migracao.down == "" {
+				return fmt.Errorf("migracao %d_%s sem script down", migracao.Versao, migracao.Nome)
+			}
+
+			anterior := SemVersao
+			if i > 0 {
+				anterior = m.migracoes[i-1].Versao
+			}
+
+			log.Printf("Revertendo migracao %d_%s", migracao.Versao, migracao.Nome)
+			if err := m.executar(ctx, conn, migracao.down, anterior); err != nil {
+				return fmt.Errorf("migracao %d_%s: %w", migracao.Versao, migracao.Nome, err)
+			}
+			atual.Numero = anterior
+			revertidas++
+		}
+		return nil
+	})
+	return revertidas, err
+}
+
+// Force registra a versão informada como limpa, sem executar scripts.
+// Use SemVersao para marcar o banco como sem migrações.
+func (m *Migrator) Force(ctx context.Context, versao int64) error {
+	if versao != SemVersao && m.indice(versao) < 0 {
+		return fmt.Errorf("versao %d nao existe nas migracoes embutidas", versao)
+	}
+
+	return m.comLock(ctx, func(conn *sql.Conn) error {
+		return gravarVersao(ctx, conn, versao, false)
+	})
+}
+
+// AdotarLegado registra a versão informada em bancos sem nenhuma migração registrada,
+// criados pelos scripts aplicados fora do migrator: a tabela existente deve estar no
+// banco e a posterior, criada pela migração seguinte, não. Se ela também existir, a
+// versão não pode ser deduzida e deve ser registrada com Force. Retorna true quando a
+// versão foi registrada.
+func (m *Migrator) AdotarLegado(ctx context.Context, versao int64, existente, posterior string) (bool, error) {
+	if m.indice(versao) < 0 {
+		return false, fmt.Errorf("versao %d nao existe nas migracoes embutidas", versao)
+	}
+
+	adotado := false
+	err := m.comLock(ctx, func(conn *sql.Conn) error {
+		atual, err := lerVersao(ctx, conn)
+		if err != nil || atual.Numero != SemVersao {
+			return err
+		}
+
+		legado, err := tabelaExiste(ctx, conn, existente)
+		if err != nil || !legado {
+			return err
+		}
+		alterado, err := tabelaExiste(ctx, conn, posterior)
+		if err != nil {
+			return err
+		}
+		if alterado {
+			return fmt.Errorf("banco com esquema existente sem %s: registre a versao atual com 'force'", schemaTable)
+		}
+
+		adotado = true
+		return gravarVersao(ctx, conn, versao, false)
+	})
+	return adotado, err
+}
+
+// Version retorna a versão atual do banco
+func (m *Migrator) Version(ctx context.Context) (Versao, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return Versao{}, err
+	}
+	defer conn.Close()
+
+	if err := criarTabelaVersao(ctx, conn); err != nil {
+		return Versao{}, err
+	}
+	return lerVersao(ctx, conn)
+}
+
+// Status lista as migrações embutidas indicando quais já foram aplicadas
+func (m *Migrator) Status(ctx context.Context) ([]StatusMigracao, Versao, error) {
+	atual, err := m.Version(ctx)
+	if err != nil {
+		return nil, Versao{}, err
+	}
+
+	status := make([]StatusMigracao, len(m.migracoes))
+	for i, migracao := range m.migracoes {
+		status[i] = StatusMigracao{Migracao: migracao, Aplicada: migracao.Versao <= atual.Numero}
+	}
+	return status, atual, nil
+}
+
+// executar roda um script marcando o banco como sujo até o script terminar,
+// como o golang-migrate faz
+func (m *Migrator) executar(ctx context.Context, conn *sql.Conn, arquivo string, versao int64) error {
+	script, err := fs.ReadFile(m.source, arquivo)
+	if err != nil {
+		return err
+	}
+
+	if err := gravarVersao(ctx, conn, versao, true); err != nil {
+		return err
+	}
+	if _, err := conn.ExecContext(ctx, string(script)); err != nil {
+		return err
+	}
+	return gravarVersao(ctx, conn, versao, false)
+}
+
+func (m *Migrator) indice(versao int64) int {
+	for i, migracao := range m.migracoes {
+		if migracao.Versao == versao {
+			return i
+		}
+	}
+	return -1
+}
+
+// comLock executa fn em uma conexão que detém o lock de migração.
+// GET_LOCK vale para a sessão, por isso tudo roda na mesma conexão.
+func (m *Migrator) comLock(ctx context.Context, fn func(conn *sql.Conn) error) error {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var banco string
+	if err := conn.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&banco); err != nil {
+		return err
+	}
+	lockName := schemaTable + ":" + banco
+
+	var obtido sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, int(lockTimeout.Seconds())).Scan(&obtido); err != nil {
+		return fmt.Errorf("falha ao obter lock de migracao: %w", err)
+	}
+	if !obtido.Valid || obtido.Int64 != 1 {
+		return fmt.Errorf("timeout aguardando lock de migracao %s", lockName)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", lockName); err != nil {
+			log.Printf("Aviso: Falha ao liberar lock de migracao: %v", err)
+		}
+	}()
+
+	if err := criarTabelaVersao(ctx, conn); err != nil {
+		return err
+	}
+	return fn(conn)
+}
+
+func criarTabelaVersao(ctx context.Context, conn *sql.Conn) error {
+	_, err := conn.ExecContext(ctx, "CREATE TABLE IF NOT EXISTS `"+schemaTable+"` (version BIGINT NOT NULL PRIMARY KEY, dirty BOOLEAN NOT NULL)")
+	if err != nil {
+		return fmt.Errorf("falha ao criar tabela %s: %w", schemaTable, err)
+	}
+	return nil
+}
+
+func lerVersao(ctx context.Context, conn *sql.Conn) (Versao, error) {
+	var v Versao
+	err := conn.QueryRowContext(ctx, "SELECT version, dirty FROM `"+schemaTable+"` LIMIT 1").Scan(&v.Numero, &v.Sujo)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Versao{Numero: SemVersao}, nil
+	}
+	if err != nil {
+		return Versao{}, fmt.Errorf("falha ao ler versao do banco: %w", err)
+	}
+	return v, nil
+}
+
+func tabelaExiste(ctx context.Context, conn *sql.Conn, tabela string) (bool, error) {
+	var existe int
+	err := conn.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = DATABASE() AND table_name = ?", tabela).Scan(&existe)
+	if err != nil {
+		return false, fmt.Errorf("falha ao verificar a tabela %s: %w", tabela, err)
+	}
+	return existe > 0, nil
+}
+
+func gravarVersao(ctx context.Context, conn *sql.Conn, versao int64, sujo bool) error {
+	if _, err := conn.ExecContext(ctx, "DELETE FROM `"+schemaTable+"`"); err != nil {
+		return fmt.Errorf("falha ao gravar versao do banco: %w", err)
+	}
+	if versao == SemVersao {
+		return nil
+	}
+	if _, err := conn.ExecContext(ctx, "INSERT INTO `"+schemaTable+"` (version, dirty) VALUES (?, ?)", versao, sujo); err != nil {
+		return fmt.Errorf("falha ao gravar versao do banco: %w", err)
+	}
+	return nil
+}
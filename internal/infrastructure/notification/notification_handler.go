@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html"
 	"log"
 	"time"
 
@@ -23,8 +24,11 @@ type PedidoEventDetail struct {
 	RestauranteNome  string          `json:"restauranteNome"`
 	ValorTotal       decimal.Decimal `json:"valorTotal"`
 	DataConfirmacao  *time.Time      `json:"dataConfirmacao,omitempty"`
+	DataPreparacao   *time.Time      `json:"dataPreparacao,omitempty"`
+	DataSaidaEntrega *time.Time      `json:"dataSaidaEntrega,omitempty"`
 	DataCancelamento *time.Time      `json:"dataCancelamento,omitempty"`
 	DataEntrega      *time.Time      `json:"dataEntrega,omitempty"`
+	Motivo           string          `json:"motivo,omitempty"`
 }
 
 // NotificationHandler processa mensagens SQS e envia notificações por email
@@ -46,6 +50,10 @@ func (h *NotificationHandler) Handle(ctx context.Context, message *sqs.SQSMessag
 	switch message.DetailType {
 	case "PedidoConfirmado":
 		return h.handlePedidoConfirmado(ctx, message.Detail)
+	case "PedidoEmPreparacao":
+		return h.handlePedidoEmPreparacao(ctx, message.Detail)
+	case "PedidoSaiuParaEntrega":
+		return h.handlePedidoSaiuParaEntrega(ctx, message.Detail)
 	case "PedidoCancelado":
 		return h.handlePedidoCancelado(ctx, message.Detail)
 	case "PedidoEntregue":
@@ -86,6 +94,68 @@ func (h *NotificationHandler) handlePedidoConfirmado(ctx context.Context, detail
 	return h.sendEmail(evento.ClienteEmail, subject, body)
 }
 
+func (h *NotificationHandler) handlePedidoEmPreparacao(ctx context.Context, detail json.RawMessage) error {
+	var evento PedidoEventDetail
+	if err := json.Unmarshal(detail, &evento); err != nil {
+		return fmt.Errorf("failed to unmarshal PedidoEmPreparacao: %w", err)
+	}
+
+	subject := fmt.Sprintf("Pedido em preparo - Código: %s", evento.PedidoCodigo)
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h1>Pedido em Preparo!</h1>
+			<p>Olá, <strong>%s</strong>!</p>
+			<p>O restaurante <strong>%s</strong> começou a preparar o seu pedido.</p>
+			<hr>
+			<h3>Detalhes do Pedido:</h3>
+			<ul>
+				<li><strong>Código:</strong> %s</li>
+				<li><strong>Valor Total:</strong> R$ %s</li>
+				<li><strong>Início do Preparo:</strong> %s</li>
+			</ul>
+			<hr>
+			<p>Avisaremos quando o pedido sair para entrega.</p>
+			<p>Equipe AlgaFood</p>
+		</body>
+		</html>
+	`, evento.ClienteNome, evento.RestauranteNome, evento.PedidoCodigo,
+		evento.ValorTotal.StringFixed(2), formatTime(evento.DataPreparacao))
+
+	return h.sendEmail(evento.ClienteEmail, subject, body)
+}
+
+func (h *NotificationHandler) handlePedidoSaiuParaEntrega(ctx context.Context, detail json.RawMessage) error {
+	var evento PedidoEventDetail
+	if err := json.Unmarshal(detail, &evento); err != nil {
+		return fmt.Errorf("failed to unmarshal PedidoSaiuParaEntrega: %w", err)
+	}
+
+	subject := fmt.Sprintf("Pedido saiu para entrega - Código: %s", evento.PedidoCodigo)
+	body := fmt.Sprintf(`
+		<html>
+		<body>
+			<h1>Pedido a Caminho!</h1>
+			<p>Olá, <strong>%s</strong>!</p>
+			<p>Seu pedido do restaurante <strong>%s</strong> saiu para entrega.</p>
+			<hr>
+			<h3>Detalhes do Pedido:</h3>
+			<ul>
+				<li><strong>Código:</strong> %s</li>
+				<li><strong>Valor Total:</strong> R$ %s</li>
+				<li><strong>Saída para Entrega:</strong> %s</li>
+			</ul>
+			<hr>
+			<p>Fique atento, o entregador chegará em breve!</p>
+			<p>Equipe AlgaFood</p>
+		</body>
+		</html>
+	`, evento.ClienteNome, evento.RestauranteNome, evento.PedidoCodigo,
+		evento.ValorTotal.StringFixed(2), formatTime(evento.DataSaidaEntrega))
+
+	return h.sendEmail(evento.ClienteEmail, subject, body)
+}
+
 func (h *NotificationHandler) handlePedidoCancelado(ctx context.Context, detail json.RawMessage) error {
 	var evento PedidoEventDetail
 	if err := json.Unmarshal(detail, &evento); err != nil {
@@ -105,6 +175,7 @@ func (h *NotificationHandler) handlePedidoCancelado(ctx context.Context, detail
 				<li><strong>Código:</strong> %s</li>
 				<li><strong>Valor Total:</strong> R$ %s</li>
 				<li><strong>Data de Cancelamento:</strong> %s</li>
+				<li><strong>Motivo:</strong> %s</li>
 			</ul>
 			<hr>
 			<p>Caso tenha dúvidas, entre em contato conosco.</p>
@@ -112,7 +183,7 @@ func (h *NotificationHandler) handlePedidoCancelado(ctx context.Context, detail
 		</body>
 		</html>
 	`, evento.ClienteNome, evento.RestauranteNome, evento.PedidoCodigo,
-		evento.ValorTotal.StringFixed(2), formatTime(evento.DataCancelamento))
+		evento.ValorTotal.StringFixed(2), formatTime(evento.DataCancelamento), formatMotivo(evento.Motivo))
 
 	return h.sendEmail(evento.ClienteEmail, subject, body)
 }
@@ -164,6 +235,13 @@ func (h *NotificationHandler) sendEmail(to, subject, body string) error {
 	return nil
 }
 
+func formatMotivo(motivo string) string {
+	if motivo == "" {
+		return "Não informado"
+	}
+	return html.EscapeString(motivo)
+}
+
 func formatTime(t *time.Time) string {
 	if t == nil {
 		return "N/A"
@@ -0,0 +1,95 @@
+package outbox
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+// defaultPollInterval é usado quando outbox.poll_interval_seconds não está configurado
+const defaultPollInterval = 5 * time.Second
+
+// PendingPublisher publica um lote de eventos pendentes do outbox
+type PendingPublisher interface {
+	PublicarPendentes(ctx context.Context) (int, error)
+}
+
+// Relay drena periodicamente o outbox para o publicador de eventos
+type Relay struct {
+	publisher    PendingPublisher
+	pollInterval time.Duration
+	stopChan     chan struct{}
+	stopOnce     sync.Once
+	done         chan struct{}
+}
+
+// NewRelay cria um novo relay do outbox
+func NewRelay(cfg *config.OutboxConfig, publisher PendingPublisher) *Relay {
+	pollInterval := time.Duration(cfg.PollIntervalSeconds) * time.Second
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	return &Relay{
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		stopChan:     make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+}
+
+// Start inicia o relay em uma goroutine
+func (r *Relay) Start(ctx context.Context) {
+	log.Printf("Iniciando relay do outbox (intervalo: %s)", r.pollInterval)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+
+		for {
+			r.drain(ctx)
+
+			select {
+			case <-r.stopChan:
+				log.Println("Relay do outbox parado")
+				return
+			case <-ctx.Done():
+				log.Println("Contexto do relay do outbox cancelado")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop para o relay e aguarda o lote em andamento terminar
+func (r *Relay) Stop() {
+	r.stopOnce.Do(func() {
+		close(r.stopChan)
+	})
+	<-r.done
+}
+
+// drain publica lotes até não haver mais eventos prontos para envio
+func (r *Relay) drain(ctx context.Context) {
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		publicados, err := r.publisher.PublicarPendentes(ctx)
+		if err != nil {
+			log.Printf("Erro ao processar outbox: %v", err)
+			return
+		}
+		if publicados == 0 {
+			return
+		}
+		log.Printf("Relay do outbox publicou %d eventos", publicados)
+	}
+}
@@ -0,0 +1,139 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// FakeTokenRecusado simula um cartão recusado pelo emissor
+	FakeTokenRecusado = "tok_recusado"
+	// FakeTokenPendente simula um gateway assíncrono: o resultado chega pelo webhook
+	FakeTokenPendente = "tok_pendente"
+
+	// FakeSignatureHeader carrega o HMAC-SHA256 (hex) do corpo do webhook
+	FakeSignatureHeader = "X-Fake-Signature"
+)
+
+// fakeWebhookPayload é o corpo aceito no webhook do provedor fake
+type fakeWebhookPayload struct {
+	ID            string            `json:"id"`
+	TransactionID string            `json:"transactionId"`
+	Status        TransactionStatus `json:"status"`
+	Message       string            `json:"message"`
+}
+
+// FakePaymentProvider simula um gateway em memória para desenvolvimento e testes.
+// Qualquer token é aprovado, exceto FakeTokenRecusado e FakeTokenPendente.
+type FakePaymentProvider struct {
+	webhookSecret string
+
+	mu         sync.Mutex
+	transacoes map[string]*Transaction
+}
+
+func NewFakePaymentProvider(webhookSecret string) *FakePaymentProvider {
+	return &FakePaymentProvider{
+		webhookSecret: webhookSecret,
+		transacoes:    make(map[string]*Transaction),
+	}
+}
+
+func (p *FakePaymentProvider) Name() string {
+	return "fake"
+}
+
+func (p *FakePaymentProvider) Authorize(_ context.Context, req AuthorizeRequest) (*Transaction, error) {
+	transacao := &Transaction{
+		ID:     "fake_" + uuid.New().String(),
+		Status: StatusAuthorized,
+		Amount: req.Amount,
+	}
+	switch req.Token {
+	case FakeTokenRecusado:
+		transacao.Status = StatusDeclined
+		transacao.Message = "Pagamento recusado pelo emissor"
+	case FakeTokenPendente:
+		transacao.Status = StatusPending
+	}
+
+	log.Printf("[FAKE PAYMENT] Autorizacao %s de %s para %s: %s", transacao.ID, req.Amount.StringFixed(2), req.Reference, transacao.Status)
+	return p.guardar(transacao), nil
+}
+
+func (p *FakePaymentProvider) Capture(_ context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error) {
+	return p.alterarStatus(transactionID, StatusAuthorized, StatusCaptured, amount)
+}
+
+func (p *FakePaymentProvider) Refund(_ context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error) {
+	return p.alterarStatus(transactionID, StatusCaptured, StatusRefunded, amount)
+}
+
+func (p *FakePaymentProvider) Void(_ context.Context, transactionID string) (*Transaction, error) {
+	return p.alterarStatus(transactionID, StatusAuthorized, StatusDeclined, decimal.Zero)
+}
+
+func (p *FakePaymentProvider) VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if !verificarHMAC(p.webhookSecret, body, header.Get(FakeSignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload fakeWebhookPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("payload do webhook invalido: %w", err)
+	}
+
+	p.mu.Lock()
+	if transacao, ok := p.transacoes[payload.TransactionID]; ok {
+		transacao.Status = payload.Status
+	}
+	p.mu.Unlock()
+
+	return &WebhookEvent{
+		ID:            payload.ID,
+		TransactionID: payload.TransactionID,
+		Status:        payload.Status,
+		Message:       payload.Message,
+	}, nil
+}
+
+// Sign assina um corpo de webhook, para simular notificações do gateway localmente
+func (p *FakePaymentProvider) Sign(body []byte) string {
+	return assinarHMAC(p.webhookSecret, body)
+}
+
+func (p *FakePaymentProvider) guardar(transacao *Transaction) *Transaction {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.transacoes[transacao.ID] = transacao
+	copia := *transacao
+	return &copia
+}
+
+func (p *FakePaymentProvider) alterarStatus(transactionID string, esperado, novo TransactionStatus, amount decimal.Decimal) (*Transaction, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// As transações se perdem ao reiniciar a API; as desconhecidas seguem no status esperado
+	transacao, ok := p.transacoes[transactionID]
+	if !ok {
+		transacao = &Transaction{ID: transactionID, Status: esperado}
+		p.transacoes[transactionID] = transacao
+	}
+	if transacao.Status != esperado {
+		return nil, fmt.Errorf("transacao %s esta %s, esperado %s", transactionID, transacao.Status, esperado)
+	}
+
+	transacao.Status = novo
+	transacao.Amount = amount
+	log.Printf("[FAKE PAYMENT] Transacao %s: %s", transactionID, novo)
+	copia := *transacao
+	return &copia, nil
+}
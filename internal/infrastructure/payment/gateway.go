@@ -0,0 +1,189 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+const (
+	defaultGatewayTimeout = 15 * time.Second
+	// GatewaySignatureHeader carrega o HMAC-SHA256 (hex) do corpo do webhook
+	GatewaySignatureHeader = "X-Gateway-Signature"
+)
+
+// GatewayPaymentProvider é o modelo de adaptador para um gateway real com API HTTP/JSON:
+// cobrança autorizada sem captura, captura e estorno posteriores e webhooks assinados
+// com HMAC. Os caminhos, campos e status abaixo seguem o formato comum desses gateways;
+// ajuste gatewayCharge, gatewayWebhook e statusGateway à API do gateway contratado.
+type GatewayPaymentProvider struct {
+	baseURL       string
+	apiKey        string
+	webhookSecret string
+	client        *http.Client
+}
+
+// gatewayCharge é a cobrança na API do gateway (valores em centavos)
+type gatewayCharge struct {
+	ID          string `json:"id,omitempty"`
+	Reference   string `json:"reference,omitempty"`
+	Amount      int64  `json:"amount"`
+	Currency    string `json:"currency,omitempty"`
+	Token       string `json:"source,omitempty"`
+	Description string `json:"description,omitempty"`
+	Capture     *bool  `json:"capture,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Message     string `json:"failure_message,omitempty"`
+}
+
+// gatewayWebhook é a notificação enviada pelo gateway
+type gatewayWebhook struct {
+	ID   string        `json:"id"`
+	Type string        `json:"type"`
+	Data gatewayCharge `json:"data"`
+}
+
+// statusGateway traduz os status do gateway para TransactionStatus
+var statusGateway = map[string]TransactionStatus{
+	"pending":    StatusPending,
+	"processing": StatusPending,
+	"authorized": StatusAuthorized,
+	"captured":   StatusCaptured,
+	"paid":       StatusCaptured,
+	"declined":   StatusDeclined,
+	"failed":     StatusDeclined,
+	"canceled":   StatusDeclined,
+	"refunded":   StatusRefunded,
+}
+
+func NewGatewayPaymentProvider(cfg *config.PagamentoConfig) (*GatewayPaymentProvider, error) {
+	if cfg.Gateway.BaseURL == "" || cfg.Gateway.APIKey == "" {
+		return nil, fmt.Errorf("pagamento.gateway.base_url e pagamento.gateway.api_key sao obrigatorios")
+	}
+
+	timeout := time.Duration(cfg.Gateway.TimeoutSeconds) * time.Second
+	if timeout <= 0 {
+		timeout = defaultGatewayTimeout
+	}
+
+	return &GatewayPaymentProvider{
+		baseURL:       strings.TrimRight(cfg.Gateway.BaseURL, "/"),
+		apiKey:        cfg.Gateway.APIKey,
+		webhookSecret: cfg.WebhookSecret,
+		client:        &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (p *GatewayPaymentProvider) Name() string {
+	return "gateway"
+}
+
+func (p *GatewayPaymentProvider) Authorize(ctx context.Context, req AuthorizeRequest) (*Transaction, error) {
+	capturar := false
+	return p.enviar(ctx, "/charges", req.IdempotencyKey, gatewayCharge{
+		Reference:   req.Reference,
+		Amount:      centavos(req.Amount),
+		Currency:    strings.ToLower(req.Currency),
+		Token:       req.Token,
+		Description: req.Description,
+		Capture:     &capturar,
+	})
+}
+
+func (p *GatewayPaymentProvider) Capture(ctx context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error) {
+	return p.enviar(ctx, "/charges/"+url.PathEscape(transactionID)+"/capture", "", gatewayCharge{Amount: centavos(amount)})
+}
+
+func (p *GatewayPaymentProvider) Refund(ctx context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error) {
+	return p.enviar(ctx, "/charges/"+url.PathEscape(transactionID)+"/refunds", "", gatewayCharge{Amount: centavos(amount)})
+}
+
+func (p *GatewayPaymentProvider) Void(ctx context.Context, transactionID string) (*Transaction, error) {
+	return p.enviar(ctx, "/charges/"+url.PathEscape(transactionID)+"/cancel", "", gatewayCharge{})
+}
+
+func (p *GatewayPaymentProvider) VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error) {
+	if !verificarHMAC(p.webhookSecret, body, header.Get(GatewaySignatureHeader)) {
+		return nil, ErrInvalidSignature
+	}
+
+	var payload gatewayWebhook
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("payload do webhook invalido: %w", err)
+	}
+	status, ok := statusGateway[payload.Data.Status]
+	if !ok {
+		return nil, fmt.Errorf("status %q desconhecido no webhook %s", payload.Data.Status, payload.ID)
+	}
+
+	return &WebhookEvent{
+		ID:            payload.ID,
+		TransactionID: payload.Data.ID,
+		Status:        status,
+		Message:       payload.Data.Message,
+	}, nil
+}
+
+// enviar faz o POST autenticado e converte a cobrança devolvida em Transaction
+func (p *GatewayPaymentProvider) enviar(ctx context.Context, caminho, chaveIdempotencia string, corpo gatewayCharge) (*Transaction, error) {
+	payload, err := json.Marshal(corpo)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+caminho, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+	// A chave de idempotência evita cobranças duplicadas quando a requisição é repetida
+	if chaveIdempotencia != "" {
+		req.Header.Set("Idempotency-Key", chaveIdempotencia)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("falha ao chamar o gateway de pagamento: %w", err)
+	}
+	defer resp.Body.Close()
+
+	resposta, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, err
+	}
+
+	// Recusas (402) vêm com a cobrança no corpo; outros erros são falhas de integração
+	if resp.StatusCode >= 300 && resp.StatusCode != http.StatusPaymentRequired {
+		return nil, fmt.Errorf("gateway de pagamento respondeu %d: %s", resp.StatusCode, strings.TrimSpace(string(resposta)))
+	}
+
+	var cobranca gatewayCharge
+	if err := json.Unmarshal(resposta, &cobranca); err != nil {
+		return nil, fmt.Errorf("resposta do gateway de pagamento invalida: %w", err)
+	}
+	status, ok := statusGateway[cobranca.Status]
+	if !ok {
+		return nil, fmt.Errorf("status %q desconhecido na resposta do gateway", cobranca.Status)
+	}
+
+	return &Transaction{
+		ID:      cobranca.ID,
+		Status:  status,
+		Amount:  decimal.New(cobranca.Amount, -2),
+		Message: cobranca.Message,
+	}, nil
+}
+
+func centavos(valor decimal.Decimal) int64 {
+	return valor.Shift(2).Round(0).IntPart()
+}
@@ -0,0 +1,101 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shopspring/decimal"
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+// TransactionStatus é a situação de uma transação no gateway
+type TransactionStatus string
+
+const (
+	// StatusPending indica que o gateway ainda vai notificar o resultado pelo webhook
+	StatusPending    TransactionStatus = "PENDING"
+	StatusAuthorized TransactionStatus = "AUTHORIZED"
+	StatusCaptured   TransactionStatus = "CAPTURED"
+	StatusDeclined   TransactionStatus = "DECLINED"
+	StatusRefunded   TransactionStatus = "REFUNDED"
+)
+
+// ErrInvalidSignature é retornado quando a assinatura do webhook não confere
+var ErrInvalidSignature = errors.New("assinatura do webhook invalida")
+
+// AuthorizeRequest descreve a cobrança a ser autorizada
+type AuthorizeRequest struct {
+	// Reference identifica a cobrança no gateway (código do pedido)
+	Reference string
+	// IdempotencyKey identifica a tentativa de pagamento: repetir a mesma requisição não
+	// cobra duas vezes, mas uma nova tentativa após uma recusa chega ao gateway
+	IdempotencyKey string
+	Amount         decimal.Decimal
+	Currency       string
+	// Token do meio de pagamento gerado pelo front-end diretamente no gateway;
+	// os dados do cartão nunca passam pela API
+	Token       string
+	Description string
+}
+
+// Transaction é o resultado de uma operação no gateway
+type Transaction struct {
+	ID     string
+	Status TransactionStatus
+	Amount decimal.Decimal
+	// Message traz o motivo da recusa, quando houver
+	Message string
+}
+
+// WebhookEvent é uma notificação de mudança de status enviada pelo gateway
+type WebhookEvent struct {
+	ID            string
+	TransactionID string
+	Status        TransactionStatus
+	Message       string
+}
+
+// PaymentProvider integra a API com um gateway de pagamento
+type PaymentProvider interface {
+	// Name identifica o provedor nos pagamentos gravados
+	Name() string
+	Authorize(ctx context.Context, req AuthorizeRequest) (*Transaction, error)
+	Capture(ctx context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error)
+	Refund(ctx context.Context, transactionID string, amount decimal.Decimal) (*Transaction, error)
+	// Void cancela uma autorização ainda não capturada, liberando o limite do cliente
+	Void(ctx context.Context, transactionID string) (*Transaction, error)
+	// VerifyWebhook valida a assinatura da notificação e a converte em WebhookEvent
+	VerifyWebhook(header http.Header, body []byte) (*WebhookEvent, error)
+}
+
+// NewPaymentProvider cria o provedor de pagamento de acordo com a configuração
+func NewPaymentProvider(cfg *config.PagamentoConfig) (PaymentProvider, error) {
+	switch cfg.Type {
+	case "gateway":
+		return NewGatewayPaymentProvider(cfg)
+	case "", "fake":
+		return NewFakePaymentProvider(cfg.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("provedor de pagamento %q nao suportado", cfg.Type)
+	}
+}
+
+// assinarHMAC calcula a assinatura HMAC-SHA256 (hex) usada nos webhooks
+func assinarHMAC(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verificarHMAC compara a assinatura recebida em tempo constante
+func verificarHMAC(secret string, body []byte, assinatura string) bool {
+	if secret == "" || assinatura == "" {
+		return false
+	}
+	return hmac.Equal([]byte(assinarHMAC(secret, body)), []byte(assinatura))
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// atualizarNotaRestaurante recalcula a média e o total de avaliações do restaurante
+const atualizarNotaRestaurante = `UPDATE restaurante SET
+	avaliacao_media = (SELECT COALESCE(AVG(nota), 0) FROM avaliacao WHERE restaurante_id = ?),
+	total_avaliacoes = (SELECT COUNT(*) FROM avaliacao WHERE restaurante_id = ?)
+	WHERE id = ?`
+
+type avaliacaoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewAvaliacaoRepository creates a new AvaliacaoRepository
+func NewAvaliacaoRepository(db *gorm.DB) *avaliacaoRepositoryImpl {
+	return &avaliacaoRepositoryImpl{db: db}
+}
+
+var camposOrdenacaoAvaliacao = pagination.CamposOrdenacao{
+	"id":          "id",
+	"nota":        "nota",
+	"dataCriacao": "data_criacao",
+}
+
+func (r *avaliacaoRepositoryImpl) FindAllByRestaurante(restauranteID uint64, page *pagination.Pageable) (*pagination.Page[model.Avaliacao], error) {
+	var avaliacoes []model.Avaliacao
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoAvaliacao, "dataCriacao,desc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Model(&model.Avaliacao{}).Where("restaurante_id = ?", restauranteID)
+	query.Count(&total)
+
+	if err := query.
+		Preload("Cliente").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Order(ordenacao.SQL()).
+		Find(&avaliacoes).Error; err != nil {
+		return nil, err
+	}
+
+	return pagination.NewPage(avaliacoes, total, page), nil
+}
+
+func (r *avaliacaoRepositoryImpl) FindByID(restauranteID, avaliacaoID uint64) (*model.Avaliacao, error) {
+	var avaliacao model.Avaliacao
+	if err := r.db.Preload("Cliente").
+		Where("restaurante_id = ? AND id = ?", restauranteID, avaliacaoID).
+		First(&avaliacao).Error; err != nil {
+		return nil, err
+	}
+	return &avaliacao, nil
+}
+
+func (r *avaliacaoRepositoryImpl) FindByPedido(pedidoID uint64) (*model.Avaliacao, error) {
+	var avaliacao model.Avaliacao
+	if err := r.db.Preload("Cliente").Where("pedido_id = ?", pedidoID).First(&avaliacao).Error; err != nil {
+		return nil, err
+	}
+	return &avaliacao, nil
+}
+
+func (r *avaliacaoRepositoryImpl) Adicionar(avaliacao *model.Avaliacao) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Pedido", "Cliente").Create(avaliacao).Error; err != nil {
+			return err
+		}
+		return tx.Exec(atualizarNotaRestaurante, avaliacao.RestauranteID, avaliacao.RestauranteID, avaliacao.RestauranteID).Error
+	})
+}
+
+func (r *avaliacaoRepositoryImpl) Save(avaliacao *model.Avaliacao) error {
+	return r.db.Omit("Pedido", "Cliente").Save(avaliacao).Error
+}
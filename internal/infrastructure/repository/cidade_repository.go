@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -14,12 +15,27 @@ func NewCidadeRepository(db *gorm.DB) *cidadeRepositoryImpl {
 	return &cidadeRepositoryImpl{db: db}
 }
 
-func (r *cidadeRepositoryImpl) FindAll() ([]model.Cidade, error) {
+var camposOrdenacaoCidade = pagination.CamposOrdenacao{
+	"id":   "id",
+	"nome": "nome",
+}
+
+func (r *cidadeRepositoryImpl) FindAll(page *pagination.Pageable) (*pagination.Page[model.Cidade], error) {
 	var cidades []model.Cidade
-	if err := r.db.Preload("Estado").Find(&cidades).Error; err != nil {
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoCidade, "id,asc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.Model(&model.Cidade{}).Count(&total)
+
+	if err := r.db.Preload("Estado").Offset(page.Offset()).Limit(page.Size).Order(ordenacao.SQL()).Find(&cidades).Error; err != nil {
 		return nil, err
 	}
-	return cidades, nil
+
+	return pagination.NewPage(cidades, total, page), nil
 }
 
 func (r *cidadeRepositoryImpl) FindByID(id uint64) (*model.Cidade, error) {
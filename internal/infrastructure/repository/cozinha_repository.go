@@ -15,13 +15,23 @@ func NewCozinhaRepository(db *gorm.DB) *cozinhaRepositoryImpl {
 	return &cozinhaRepositoryImpl{db: db}
 }
 
+var camposOrdenacaoCozinha = pagination.CamposOrdenacao{
+	"id":   "id",
+	"nome": "nome",
+}
+
 func (r *cozinhaRepositoryImpl) FindAll(page *pagination.Pageable) (*pagination.Page[model.Cozinha], error) {
 	var cozinhas []model.Cozinha
 	var total int64
 
+	ordenacao, err := page.Ordenacao(camposOrdenacaoCozinha, "id,asc", "id")
+	if err != nil {
+		return nil, err
+	}
+
 	r.db.Model(&model.Cozinha{}).Count(&total)
 
-	if err := r.db.Offset(page.Offset()).Limit(page.Size).Find(&cozinhas).Error; err != nil {
+	if err := r.db.Offset(page.Offset()).Limit(page.Size).Order(ordenacao.SQL()).Find(&cozinhas).Error; err != nil {
 		return nil, err
 	}
 
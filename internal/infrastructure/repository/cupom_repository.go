@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	domainRepo "github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+)
+
+// selectCupomComUsos preenche TotalUsos com os pedidos não cancelados que usaram o cupom
+const selectCupomComUsos = `cupom.*, (SELECT COUNT(*) FROM cupom_uso cu JOIN pedido p ON p.id = cu.pedido_id
+	WHERE cu.cupom_id = cupom.id AND p.status <> 'CANCELADO') AS total_usos`
+
+type cupomRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewCupomRepository creates a new CupomRepository
+func NewCupomRepository(db *gorm.DB) *cupomRepositoryImpl {
+	return &cupomRepositoryImpl{db: db}
+}
+
+var camposOrdenacaoCupom = pagination.CamposOrdenacao{
+	"id":           "id",
+	"codigo":       "codigo",
+	"validoAte":    "valido_ate",
+	"dataCadastro": "data_cadastro",
+}
+
+func (r *cupomRepositoryImpl) FindAll(filter *domainRepo.CupomFilter, page *pagination.Pageable) (*pagination.Page[model.Cupom], error) {
+	var cupons []model.Cupom
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoCupom, "id,desc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Model(&model.Cupom{})
+	if filter != nil {
+		if filter.RestauranteID != nil {
+			query = query.Where("restaurante_id = ?", *filter.RestauranteID)
+		}
+		if filter.Ativo != nil {
+			query = query.Where("ativo = ?", *filter.Ativo)
+		}
+	}
+	query.Count(&total)
+
+	if err := query.Select(selectCupomComUsos).
+		Preload("Restaurante").
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Order(ordenacao.SQL()).
+		Find(&cupons).Error; err != nil {
+		return nil, err
+	}
+
+	return pagination.NewPage(cupons, total, page), nil
+}
+
+func (r *cupomRepositoryImpl) FindByID(id uint64) (*model.Cupom, error) {
+	var cupom model.Cupom
+	if err := r.db.Select(selectCupomComUsos).Preload("Restaurante").First(&cupom, id).Error; err != nil {
+		return nil, err
+	}
+	return &cupom, nil
+}
+
+func (r *cupomRepositoryImpl) FindByCodigo(codigo string) (*model.Cupom, error) {
+	var cupom model.Cupom
+	if err := r.db.Select(selectCupomComUsos).Where("codigo = ?", codigo).First(&cupom).Error; err != nil {
+		return nil, err
+	}
+	return &cupom, nil
+}
+
+func (r *cupomRepositoryImpl) Save(cupom *model.Cupom) error {
+	return r.db.Omit("Restaurante").Save(cupom).Error
+}
+
+func (r *cupomRepositoryImpl) Delete(id uint64) error {
+	return r.db.Delete(&model.Cupom{}, id).Error
+}
+
+func (r *cupomRepositoryImpl) ContarUsosCliente(cupomID, clienteID uint64) (int64, error) {
+	return contarUsosCupom(r.db, cupomID, &clienteID)
+}
+
+// contarUsosCupom conta os pedidos não cancelados com o cupom, de todos os clientes ou de um cliente
+func contarUsosCupom(db *gorm.DB, cupomID uint64, clienteID *uint64) (int64, error) {
+	var usos int64
+	query := db.Table("cupom_uso cu").
+		Joins("JOIN pedido p ON p.id = cu.pedido_id").
+		Where("cu.cupom_id = ? AND p.status <> ?", cupomID, model.StatusPedidoCancelado)
+	if clienteID != nil {
+		query = query.Where("cu.usuario_cliente_id = ?", *clienteID)
+	}
+	if err := query.Count(&usos).Error; err != nil {
+		return 0, err
+	}
+	return usos, nil
+}
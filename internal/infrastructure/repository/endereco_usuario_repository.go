@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type enderecoUsuarioRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewEnderecoUsuarioRepository creates a new EnderecoUsuarioRepository
+func NewEnderecoUsuarioRepository(db *gorm.DB) *enderecoUsuarioRepositoryImpl {
+	return &enderecoUsuarioRepositoryImpl{db: db}
+}
+
+func (r *enderecoUsuarioRepositoryImpl) preloadCidade() *gorm.DB {
+	return r.db.Preload("Endereco.Cidade").Preload("Endereco.Cidade.Estado")
+}
+
+func (r *enderecoUsuarioRepositoryImpl) FindAllByUsuario(usuarioID uint64) ([]model.EnderecoUsuario, error) {
+	var enderecos []model.EnderecoUsuario
+	if err := r.preloadCidade().
+		Where("usuario_id = ?", usuarioID).
+		Order("padrao DESC, id").
+		Find(&enderecos).Error; err != nil {
+		return nil, err
+	}
+	return enderecos, nil
+}
+
+func (r *enderecoUsuarioRepositoryImpl) FindByID(usuarioID, enderecoID uint64) (*model.EnderecoUsuario, error) {
+	var endereco model.EnderecoUsuario
+	if err := r.preloadCidade().
+		Where("usuario_id = ? AND id = ?", usuarioID, enderecoID).
+		First(&endereco).Error; err != nil {
+		return nil, err
+	}
+	return &endereco, nil
+}
+
+func (r *enderecoUsuarioRepositoryImpl) FindPadrao(usuarioID uint64) (*model.EnderecoUsuario, error) {
+	var endereco model.EnderecoUsuario
+	if err := r.preloadCidade().
+		Where("usuario_id = ? AND padrao = ?", usuarioID, true).
+		First(&endereco).Error; err != nil {
+		return nil, err
+	}
+	return &endereco, nil
+}
+
+func (r *enderecoUsuarioRepositoryImpl) Save(endereco *model.EnderecoUsuario) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(endereco).Error; err != nil {
+			return err
+		}
+		if !endereco.Padrao {
+			return nil
+		}
+		return tx.Model(&model.EnderecoUsuario{}).
+			Where("usuario_id = ? AND id <> ? AND padrao = ?", endereco.UsuarioID, endereco.ID, true).
+			Update("padrao", false).Error
+	})
+}
+
+func (r *enderecoUsuarioRepositoryImpl) Delete(usuarioID, enderecoID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var endereco model.EnderecoUsuario
+		if err := tx.Where("usuario_id = ? AND id = ?", usuarioID, enderecoID).First(&endereco).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&endereco).Error; err != nil {
+			return err
+		}
+		if !endereco.Padrao {
+			return nil
+		}
+
+		var proximo model.EnderecoUsuario
+		if err := tx.Where("usuario_id = ?", usuarioID).Order("id").First(&proximo).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			return err
+		}
+		return tx.Model(&proximo).Update("padrao", true).Error
+	})
+}
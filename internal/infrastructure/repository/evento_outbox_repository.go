@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type eventoOutboxRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewEventoOutboxRepository creates a new EventoOutboxRepository
+func NewEventoOutboxRepository(db *gorm.DB) *eventoOutboxRepositoryImpl {
+	return &eventoOutboxRepositoryImpl{db: db}
+}
+
+var camposOrdenacaoEventoOutbox = pagination.CamposOrdenacao{
+	"id":               "id",
+	"tipo":             "tipo",
+	"status":           "status",
+	"tentativas":       "tentativas",
+	"dataCriacao":      "data_criacao",
+	"proximaTentativa": "proxima_tentativa",
+}
+
+func (r *eventoOutboxRepositoryImpl) FindAll(status *model.StatusEventoOutbox, page *pagination.Pageable) (*pagination.Page[model.EventoOutbox], error) {
+	var eventos []model.EventoOutbox
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoEventoOutbox, "dataCriacao,desc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Model(&model.EventoOutbox{})
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	query.Count(&total)
+
+	if err := query.
+		Offset(page.Offset()).
+		Limit(page.Size).
+		Order(ordenacao.SQL()).
+		Find(&eventos).Error; err != nil {
+		return nil, err
+	}
+
+	return pagination.NewPage(eventos, total, page), nil
+}
+
+func (r *eventoOutboxRepositoryImpl) FindByID(id uint64) (*model.EventoOutbox, error) {
+	var evento model.EventoOutbox
+	if err := r.db.First(&evento, id).Error; err != nil {
+		return nil, err
+	}
+	return &evento, nil
+}
+
+// ReservarPendentes reserva um lote de eventos pendentes para esta instância: as linhas são
+// bloqueadas com SKIP LOCKED (outras instâncias pegam as seguintes) e a próxima tentativa é
+// adiada pela duração da reserva, para que não sejam publicadas de novo enquanto esta
+// instância as publica. Se a instância cair, os eventos voltam à fila quando a reserva expira.
+func (r *eventoOutboxRepositoryImpl) ReservarPendentes(limite int, reserva time.Duration) ([]model.EventoOutbox, error) {
+	var eventos []model.EventoOutbox
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		agora := time.Now()
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND proxima_tentativa <= ?", model.StatusEventoOutboxPendente, agora).
+			Order("id ASC").
+			Limit(limite).
+			Find(&eventos).Error; err != nil {
+			return err
+		}
+		if len(eventos) == 0 {
+			return nil
+		}
+
+		ids := make([]uint64, len(eventos))
+		expiracao := agora.Add(reserva)
+		for i := range eventos {
+			ids[i] = eventos[i].ID
+			eventos[i].ProximaTentativa = expiracao
+		}
+		return tx.Model(&model.EventoOutbox{}).Where("id IN ?", ids).Update("proxima_tentativa", expiracao).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return eventos, nil
+}
+
+func (r *eventoOutboxRepositoryImpl) Save(evento *model.EventoOutbox) error {
+	return r.db.Save(evento).Error
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type excecaoHorarioRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewExcecaoHorarioRepository creates a new ExcecaoHorarioRepository
+func NewExcecaoHorarioRepository(db *gorm.DB) *excecaoHorarioRepositoryImpl {
+	return &excecaoHorarioRepositoryImpl{db: db}
+}
+
+func (r *excecaoHorarioRepositoryImpl) FindAllByRestaurante(restauranteID uint64) ([]model.ExcecaoHorario, error) {
+	var excecoes []model.ExcecaoHorario
+	if err := r.db.Where("restaurante_id = ?", restauranteID).Order("data").Find(&excecoes).Error; err != nil {
+		return nil, err
+	}
+	return excecoes, nil
+}
+
+func (r *excecaoHorarioRepositoryImpl) FindByID(restauranteID, excecaoID uint64) (*model.ExcecaoHorario, error) {
+	var excecao model.ExcecaoHorario
+	if err := r.db.Where("restaurante_id = ? AND id = ?", restauranteID, excecaoID).First(&excecao).Error; err != nil {
+		return nil, err
+	}
+	return &excecao, nil
+}
+
+func (r *excecaoHorarioRepositoryImpl) Save(excecao *model.ExcecaoHorario) error {
+	return r.db.Save(excecao).Error
+}
+
+func (r *excecaoHorarioRepositoryImpl) Delete(excecaoID uint64) error {
+	return r.db.Delete(&model.ExcecaoHorario{}, excecaoID).Error
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type favoritoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFavoritoRepository creates a new FavoritoRepository
+func NewFavoritoRepository(db *gorm.DB) *favoritoRepositoryImpl {
+	return &favoritoRepositoryImpl{db: db}
+}
+
+func (r *favoritoRepositoryImpl) FindRestaurantes(usuarioID uint64) ([]model.Restaurante, error) {
+	var restaurantes []model.Restaurante
+	if err := r.db.
+		Joins("JOIN usuario_restaurante_favorito f ON f.restaurante_id = restaurante.id").
+		Where("f.usuario_id = ?", usuarioID).
+		Preload("Cozinha").
+		Order("f.data_cadastro DESC").
+		Find(&restaurantes).Error; err != nil {
+		return nil, err
+	}
+	return restaurantes, nil
+}
+
+func (r *favoritoRepositoryImpl) FindProdutos(usuarioID uint64) ([]model.Produto, error) {
+	var produtos []model.Produto
+	if err := r.db.
+		Joins("JOIN usuario_produto_favorito f ON f.produto_id = produto.id").
+		Where("f.usuario_id = ?", usuarioID).
+		Preload("Restaurante").
+		Order("f.data_cadastro DESC").
+		Find(&produtos).Error; err != nil {
+		return nil, err
+	}
+	return produtos, nil
+}
+
+// Favoritar novamente não é erro: o INSERT IGNORE mantém o registro existente
+func (r *favoritoRepositoryImpl) AdicionarRestaurante(usuarioID, restauranteID uint64) error {
+	return r.db.Exec("INSERT IGNORE INTO usuario_restaurante_favorito (usuario_id, restaurante_id, data_cadastro) VALUES (?, ?, ?)",
+		usuarioID, restauranteID, time.Now()).Error
+}
+
+func (r *favoritoRepositoryImpl) RemoverRestaurante(usuarioID, restauranteID uint64) error {
+	return r.db.Exec("DELETE FROM usuario_restaurante_favorito WHERE usuario_id = ? AND restaurante_id = ?", usuarioID, restauranteID).Error
+}
+
+func (r *favoritoRepositoryImpl) AdicionarProduto(usuarioID, produtoID uint64) error {
+	return r.db.Exec("INSERT IGNORE INTO usuario_produto_favorito (usuario_id, produto_id, data_cadastro) VALUES (?, ?, ?)",
+		usuarioID, produtoID, time.Now()).Error
+}
+
+func (r *favoritoRepositoryImpl) RemoverProduto(usuarioID, produtoID uint64) error {
+	return r.db.Exec("DELETE FROM usuario_produto_favorito WHERE usuario_id = ? AND produto_id = ?", usuarioID, produtoID).Error
+}
@@ -24,7 +24,7 @@ func (r *fotoProdutoRepositoryImpl) FindByProdutoID(produtoID uint64) (*model.Fo
 
 func (r *fotoProdutoRepositoryImpl) Save(foto *model.FotoProduto) error {
 	// Use upsert - update if exists, insert if not
-	return r.db.Save(foto).Error
+	return r.db.Omit("Produto").Save(foto).Error
 }
 
 func (r *fotoProdutoRepositoryImpl) Delete(produtoID uint64) error {
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type freteRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewFreteRepository creates a new FreteRepository
+func NewFreteRepository(db *gorm.DB) *freteRepositoryImpl {
+	return &freteRepositoryImpl{db: db}
+}
+
+func (r *freteRepositoryImpl) FindConfiguracao(restauranteID uint64) (*model.ConfiguracaoFrete, error) {
+	var configuracao model.ConfiguracaoFrete
+	if err := r.db.
+		Preload("Localidades", func(db *gorm.DB) *gorm.DB {
+			return db.Order("cidade_id, bairro")
+		}).
+		Preload("Localidades.Cidade.Estado").
+		Preload("Faixas", func(db *gorm.DB) *gorm.DB {
+			return db.Order("distancia_max_km")
+		}).
+		Where("restaurante_id = ?", restauranteID).
+		First(&configuracao).Error; err != nil {
+		return nil, err
+	}
+	return &configuracao, nil
+}
+
+func (r *freteRepositoryImpl) SaveConfiguracao(configuracao *model.ConfiguracaoFrete) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("restaurante_id = ?", configuracao.RestauranteID).Delete(&model.FreteLocalidade{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("restaurante_id = ?", configuracao.RestauranteID).Delete(&model.FaixaFreteDistancia{}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Omit(clause.Associations).Save(configuracao).Error; err != nil {
+			return err
+		}
+
+		for i := range configuracao.Localidades {
+			configuracao.Localidades[i].ID = 0
+			configuracao.Localidades[i].RestauranteID = configuracao.RestauranteID
+		}
+		if len(configuracao.Localidades) > 0 {
+			if err := tx.Omit(clause.Associations).Create(&configuracao.Localidades).Error; err != nil {
+				return err
+			}
+		}
+
+		for i := range configuracao.Faixas {
+			configuracao.Faixas[i].ID = 0
+			configuracao.Faixas[i].RestauranteID = configuracao.RestauranteID
+		}
+		if len(configuracao.Faixas) > 0 {
+			if err := tx.Create(&configuracao.Faixas).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type grupoOpcoesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewGrupoOpcoesRepository creates a new GrupoOpcoesRepository
+func NewGrupoOpcoesRepository(db *gorm.DB) *grupoOpcoesRepositoryImpl {
+	return &grupoOpcoesRepositoryImpl{db: db}
+}
+
+func preloadOpcoes(db *gorm.DB) *gorm.DB {
+	return db.Order("id")
+}
+
+func (r *grupoOpcoesRepositoryImpl) FindAllByProduto(produtoID uint64) ([]model.GrupoOpcoes, error) {
+	var grupos []model.GrupoOpcoes
+	if err := r.db.
+		Preload("Opcoes", preloadOpcoes).
+		Where("produto_id = ?", produtoID).
+		Order("id").
+		Find(&grupos).Error; err != nil {
+		return nil, err
+	}
+	return grupos, nil
+}
+
+func (r *grupoOpcoesRepositoryImpl) FindByID(produtoID, grupoID uint64) (*model.GrupoOpcoes, error) {
+	var grupo model.GrupoOpcoes
+	if err := r.db.
+		Preload("Opcoes", preloadOpcoes).
+		Where("produto_id = ? AND id = ?", produtoID, grupoID).
+		First(&grupo).Error; err != nil {
+		return nil, err
+	}
+	return &grupo, nil
+}
+
+// Save grava o grupo e sincroniza suas opções: opções com ID são atualizadas (mantendo
+// a referência dos pedidos), as sem ID são criadas e as ausentes são removidas
+func (r *grupoOpcoesRepositoryImpl) Save(grupo *model.GrupoOpcoes) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit(clause.Associations).Save(grupo).Error; err != nil {
+			return err
+		}
+
+		mantidas := make([]uint64, 0, len(grupo.Opcoes))
+		for _, opcao := range grupo.Opcoes {
+			if opcao.ID != 0 {
+				mantidas = append(mantidas, opcao.ID)
+			}
+		}
+		remocao := tx.Where("grupo_opcao_id = ?", grupo.ID)
+		if len(mantidas) > 0 {
+			remocao = remocao.Where("id NOT IN ?", mantidas)
+		}
+		if err := remocao.Delete(&model.OpcaoProduto{}).Error; err != nil {
+			return err
+		}
+
+		for i := range grupo.Opcoes {
+			grupo.Opcoes[i].GrupoID = grupo.ID
+			if err := tx.Save(&grupo.Opcoes[i]).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *grupoOpcoesRepositoryImpl) Delete(grupoID uint64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("grupo_opcao_id = ?", grupoID).Delete(&model.OpcaoProduto{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&model.GrupoOpcoes{}, grupoID).Error
+	})
+}
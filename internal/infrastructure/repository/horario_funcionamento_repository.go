@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+// travaAgenda é o nome do lock (GET_LOCK) que serializa a sincronização da agenda entre réplicas
+const travaAgenda = "algafood:agenda-funcionamento"
+
+type horarioFuncionamentoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewHorarioFuncionamentoRepository creates a new HorarioFuncionamentoRepository
+func NewHorarioFuncionamentoRepository(db *gorm.DB) *horarioFuncionamentoRepositoryImpl {
+	return &horarioFuncionamentoRepositoryImpl{db: db}
+}
+
+func (r *horarioFuncionamentoRepositoryImpl) FindAllByRestaurante(restauranteID uint64) ([]model.HorarioFuncionamento, error) {
+	var horarios []model.HorarioFuncionamento
+	if err := r.db.Where("restaurante_id = ?", restauranteID).
+		Order("FIELD(dia_semana, 'DOMINGO', 'SEGUNDA', 'TERCA', 'QUARTA', 'QUINTA', 'SEXTA', 'SABADO')").
+		Order("hora_abertura").
+		Find(&horarios).Error; err != nil {
+		return nil, err
+	}
+	return horarios, nil
+}
+
+func (r *horarioFuncionamentoRepositoryImpl) FindByID(restauranteID, horarioID uint64) (*model.HorarioFuncionamento, error) {
+	var horario model.HorarioFuncionamento
+	if err := r.db.Where("restaurante_id = ? AND id = ?", restauranteID, horarioID).First(&horario).Error; err != nil {
+		return nil, err
+	}
+	return &horario, nil
+}
+
+func (r *horarioFuncionamentoRepositoryImpl) Save(horario *model.HorarioFuncionamento) error {
+	return r.db.Save(horario).Error
+}
+
+func (r *horarioFuncionamentoRepositoryImpl) Delete(horarioID uint64) error {
+	return r.db.Delete(&model.HorarioFuncionamento{}, horarioID).Error
+}
+
+func (r *horarioFuncionamentoRepositoryImpl) FindRestauranteIDs() ([]uint64, error) {
+	var ids []uint64
+	if err := r.db.Model(&model.HorarioFuncionamento{}).
+		Distinct("restaurante_id").
+		Pluck("restaurante_id", &ids).Error; err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// ComTravaAgenda usa GET_LOCK sem espera: se outra réplica detém o lock, o ciclo é pulado.
+// O lock vale para a sessão, por isso é obtido e liberado na mesma conexão.
+func (r *horarioFuncionamentoRepositoryImpl) ComTravaAgenda(ctx context.Context, fn func() error) (bool, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return false, err
+	}
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	var obtido sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, 0)", travaAgenda).Scan(&obtido); err != nil {
+		return false, fmt.Errorf("falha ao obter lock da agenda: %w", err)
+	}
+	if !obtido.Valid || obtido.Int64 != 1 {
+		return false, nil
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT RELEASE_LOCK(?)", travaAgenda); err != nil {
+			log.Printf("Aviso: Falha ao liberar lock da agenda: %v", err)
+		}
+	}()
+
+	return true, fn()
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type pagamentoRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPagamentoRepository creates a new PagamentoRepository
+func NewPagamentoRepository(db *gorm.DB) *pagamentoRepositoryImpl {
+	return &pagamentoRepositoryImpl{db: db}
+}
+
+func (r *pagamentoRepositoryImpl) FindUltimoByPedido(pedidoID uint64) (*model.Pagamento, error) {
+	var pagamento model.Pagamento
+	if err := r.db.Where("pedido_id = ?", pedidoID).Order("id DESC").First(&pagamento).Error; err != nil {
+		return nil, err
+	}
+	return &pagamento, nil
+}
+
+func (r *pagamentoRepositoryImpl) FindByTransacao(provedor, transacaoID string) (*model.Pagamento, error) {
+	var pagamento model.Pagamento
+	if err := r.db.Preload("Pedido").
+		Where("provedor = ? AND transacao_id = ?", provedor, transacaoID).
+		First(&pagamento).Error; err != nil {
+		return nil, err
+	}
+	return &pagamento, nil
+}
+
+func (r *pagamentoRepositoryImpl) FindCapturadosDePedidosCancelados(limite int) ([]model.Pagamento, error) {
+	var pagamentos []model.Pagamento
+	err := r.db.Joins("JOIN pedido ON pedido.id = pagamento.pedido_id").
+		Where("pagamento.status = ? AND pedido.status = ?", model.StatusPagamentoCapturado, model.StatusPedidoCancelado).
+		Order("pagamento.id").
+		Limit(limite).
+		Find(&pagamentos).Error
+	return pagamentos, err
+}
+
+func (r *pagamentoRepositoryImpl) Save(pagamento *model.Pagamento) error {
+	return r.db.Omit("Pedido").Save(pagamento).Error
+}
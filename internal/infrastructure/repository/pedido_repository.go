@@ -1,12 +1,14 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"github.com/yurisasc/algafood-go/internal/domain/model"
 	domainRepo "github.com/yurisasc/algafood-go/internal/domain/repository"
 	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type pedidoRepositoryImpl struct {
@@ -18,40 +20,35 @@ func NewPedidoRepository(db *gorm.DB) *pedidoRepositoryImpl {
 	return &pedidoRepositoryImpl{db: db}
 }
 
+// camposOrdenacaoPedido são os campos aceitos em sort nas pesquisas de pedidos. Todas as
+// colunas são NOT NULL, requisito da paginação por cursor.
+var camposOrdenacaoPedido = pagination.CamposOrdenacao{
+	"codigo":      "codigo",
+	"dataCriacao": "data_criacao",
+	"subtotal":    "subtotal",
+	"valorTotal":  "valor_total",
+	"status":      "status",
+}
+
+const ordenacaoPadraoPedido = "dataCriacao,desc"
+
 func (r *pedidoRepositoryImpl) FindAll(filter *domainRepo.PedidoFilter, page *pagination.Pageable) (*pagination.Page[model.Pedido], error) {
 	var pedidos []model.Pedido
 	var total int64
 
-	query := r.db.Model(&model.Pedido{})
-
-	// Apply filters
-	if filter != nil {
-		if filter.ClienteID != nil {
-			query = query.Where("usuario_cliente_id = ?", *filter.ClienteID)
-		}
-		if filter.RestauranteID != nil {
-			query = query.Where("restaurante_id = ?", *filter.RestauranteID)
-		}
-		if filter.Status != nil {
-			query = query.Where("status = ?", *filter.Status)
-		}
-		if filter.DataCriacaoInicio != nil {
-			t, _ := time.Parse("2006-01-02", *filter.DataCriacaoInicio)
-			query = query.Where("data_criacao >= ?", t)
-		}
-		if filter.DataCriacaoFim != nil {
-			t, _ := time.Parse("2006-01-02", *filter.DataCriacaoFim)
-			query = query.Where("data_criacao <= ?", t)
-		}
+	ordenacao, err := page.Ordenacao(camposOrdenacaoPedido, ordenacaoPadraoPedido, "id")
+	if err != nil {
+		return nil, err
 	}
 
+	query := filtrarPedidos(r.db.Model(&model.Pedido{}), filter)
 	query.Count(&total)
 
 	// Busca apenas dados básicos - relacionamentos serão populados via cache no serviço
 	if err := query.
 		Offset(page.Offset()).
 		Limit(page.Size).
-		Order("data_criacao DESC").
+		Order(ordenacao.SQL()).
 		Find(&pedidos).Error; err != nil {
 		return nil, err
 	}
@@ -59,11 +56,91 @@ func (r *pedidoRepositoryImpl) FindAll(filter *domainRepo.PedidoFilter, page *pa
 	return pagination.NewPage(pedidos, total, page), nil
 }
 
+// FindAllByCursor pagina por keyset: em vez de OFFSET, busca as linhas após a posição
+// do cursor, o que mantém o custo constante em tabelas grandes. Não calcula o total.
+func (r *pedidoRepositoryImpl) FindAllByCursor(filter *domainRepo.PedidoFilter, page *pagination.Pageable) (*pagination.CursorPage[model.Pedido], error) {
+	var pedidos []model.Pedido
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoPedido, ordenacaoPadraoPedido, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := filtrarPedidos(r.db.Model(&model.Pedido{}), filter)
+
+	condicao, args, err := ordenacao.CondicaoApos(*page.Cursor)
+	if err != nil {
+		return nil, err
+	}
+	if condicao != "" {
+		query = query.Where(condicao, args...)
+	}
+
+	// Uma linha a mais indica se há próxima página
+	if err := query.
+		Limit(page.Size + 1).
+		Order(ordenacao.SQL()).
+		Find(&pedidos).Error; err != nil {
+		return nil, err
+	}
+
+	return pagination.NewCursorPage(pedidos, page, ordenacao, func(p *model.Pedido) []any {
+		valores := make([]any, len(ordenacao))
+		for i, ordem := range ordenacao {
+			switch ordem.Coluna {
+			case "codigo":
+				valores[i] = p.Codigo
+			case "data_criacao":
+				valores[i] = p.DataCriacao.Format(formatoDataCursor)
+			case "subtotal":
+				valores[i] = p.Subtotal.String()
+			case "valor_total":
+				valores[i] = p.ValorTotal.String()
+			case "status":
+				valores[i] = string(p.Status)
+			case "id":
+				valores[i] = p.ID
+			}
+		}
+		return valores
+	}), nil
+}
+
+// formatoDataCursor grava datas do cursor no formato de DATETIME do MySQL
+const formatoDataCursor = "2006-01-02 15:04:05.999999"
+
+func filtrarPedidos(query *gorm.DB, filter *domainRepo.PedidoFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.ClienteID != nil {
+		query = query.Where("usuario_cliente_id = ?", *filter.ClienteID)
+	}
+	if filter.RestauranteID != nil {
+		query = query.Where("restaurante_id = ?", *filter.RestauranteID)
+	}
+	if filter.Status != nil {
+		query = query.Where("status IN ?", filter.Status.Detalhados())
+	}
+	if filter.StatusDetalhado != nil {
+		query = query.Where("status = ?", *filter.StatusDetalhado)
+	}
+	if filter.DataCriacaoInicio != nil {
+		t, _ := time.Parse("2006-01-02", *filter.DataCriacaoInicio)
+		query = query.Where("data_criacao >= ?", t)
+	}
+	if filter.DataCriacaoFim != nil {
+		t, _ := time.Parse("2006-01-02", *filter.DataCriacaoFim)
+		query = query.Where("data_criacao <= ?", t)
+	}
+	return query
+}
+
 func (r *pedidoRepositoryImpl) FindByCodigo(codigo string) (*model.Pedido, error) {
 	var pedido model.Pedido
 	// Carrega apenas os itens - os outros relacionamentos serão populados via cache no serviço
 	if err := r.db.
-		Preload("Itens").
+		Preload("Itens.Opcoes").
 		Where("codigo = ?", codigo).
 		First(&pedido).Error; err != nil {
 		return nil, err
@@ -77,6 +154,104 @@ func (r *pedidoRepositoryImpl) Save(pedido *model.Pedido) error {
 	return r.db.Omit("Restaurante", "Cliente", "FormaPagamento", "EnderecoEntrega.Cidade", "Itens.Produto").Save(pedido).Error
 }
 
+func (r *pedidoRepositoryImpl) SaveComHistorico(pedido *model.Pedido, historico *model.PedidoStatusHistorico) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return saveComHistorico(tx, pedido, historico)
+	})
+}
+
+// errCupomEsgotado desfaz a transação quando o cupom não tem mais usos disponíveis
+var errCupomEsgotado = errors.New("cupom sem usos disponiveis")
+
+func (r *pedidoRepositoryImpl) SaveComCupom(pedido *model.Pedido, historico *model.PedidoStatusHistorico, cupom *model.Cupom) (bool, error) {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Bloqueia o cupom para que usos concorrentes sejam contados um de cada vez
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&model.Cupom{}, cupom.ID).Error; err != nil {
+			return err
+		}
+
+		if cupom.LimiteUsos != nil {
+			usos, err := contarUsosCupom(tx, cupom.ID, nil)
+			if err != nil {
+				return err
+			}
+			if usos >= int64(*cupom.LimiteUsos) {
+				return errCupomEsgotado
+			}
+		}
+		if cupom.LimiteUsosPorCliente != nil {
+			usos, err := contarUsosCupom(tx, cupom.ID, &pedido.ClienteID)
+			if err != nil {
+				return err
+			}
+			if usos >= int64(*cupom.LimiteUsosPorCliente) {
+				return errCupomEsgotado
+			}
+		}
+
+		if err := saveComHistorico(tx, pedido, historico); err != nil {
+			return err
+		}
+		return tx.Create(&model.CupomUso{CupomID: cupom.ID, PedidoID: pedido.ID, ClienteID: pedido.ClienteID}).Error
+	})
+	if errors.Is(err, errCupomEsgotado) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (r *pedidoRepositoryImpl) SaveComEvento(pedido *model.Pedido, historico *model.PedidoStatusHistorico, evento *model.EventoOutbox) (bool, error) {
+	gravado := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Só as colunas de status mudam nas transições; o WHERE descarta a gravação quando
+		// outra requisição alterou o status depois da leitura
+		result := tx.Model(&model.Pedido{}).
+			Where("id = ? AND status = ?", pedido.ID, *historico.StatusAnterior).
+			Updates(map[string]any{
+				"status":             pedido.Status,
+				"data_confirmacao":   pedido.DataConfirmacao,
+				"data_preparacao":    pedido.DataPreparacao,
+				"data_saida_entrega": pedido.DataSaidaEntrega,
+				"data_cancelamento":  pedido.DataCancelamento,
+				"data_entrega":       pedido.DataEntrega,
+			})
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+		gravado = true
+
+		historico.PedidoID = pedido.ID
+		if err := tx.Omit("Usuario").Create(historico).Error; err != nil {
+			return err
+		}
+		return tx.Create(evento).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return gravado, nil
+}
+
+// saveComHistorico grava o pedido e, com o ID já gerado, a mudança de status
+func saveComHistorico(tx *gorm.DB, pedido *model.Pedido, historico *model.PedidoStatusHistorico) error {
+	if err := tx.Omit("Restaurante", "Cliente", "FormaPagamento", "EnderecoEntrega.Cidade", "Itens.Produto").Save(pedido).Error; err != nil {
+		return err
+	}
+	historico.PedidoID = pedido.ID
+	return tx.Omit("Usuario").Create(historico).Error
+}
+
+func (r *pedidoRepositoryImpl) FindHistorico(pedidoID uint64) ([]model.PedidoStatusHistorico, error) {
+	var historico []model.PedidoStatusHistorico
+	if err := r.db.
+		Where("pedido_id = ?", pedidoID).
+		Order("data_alteracao, id").
+		Find(&historico).Error; err != nil {
+		return nil, err
+	}
+	return historico, nil
+}
+
 func (r *pedidoRepositoryImpl) IsPedidoGerenciadoPor(codigoPedido string, usuarioID uint64) (bool, error) {
 	var count int64
 	if err := r.db.Table("pedido p").
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -14,18 +15,33 @@ func NewProdutoRepository(db *gorm.DB) *produtoRepositoryImpl {
 	return &produtoRepositoryImpl{db: db}
 }
 
-func (r *produtoRepositoryImpl) FindAllByRestaurante(restauranteID uint64, incluirInativos bool) ([]model.Produto, error) {
+var camposOrdenacaoProduto = pagination.CamposOrdenacao{
+	"id":    "id",
+	"nome":  "nome",
+	"preco": "preco",
+}
+
+func (r *produtoRepositoryImpl) FindAllByRestaurante(restauranteID uint64, incluirInativos bool, page *pagination.Pageable) (*pagination.Page[model.Produto], error) {
 	var produtos []model.Produto
-	query := r.db.Where("restaurante_id = ?", restauranteID)
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoProduto, "id,asc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := r.db.Model(&model.Produto{}).Where("restaurante_id = ?", restauranteID)
 
 	if !incluirInativos {
 		query = query.Where("ativo = ?", true)
 	}
 
-	if err := query.Find(&produtos).Error; err != nil {
+	query.Count(&total)
+
+	if err := query.Offset(page.Offset()).Limit(page.Size).Order(ordenacao.SQL()).Find(&produtos).Error; err != nil {
 		return nil, err
 	}
-	return produtos, nil
+	return pagination.NewPage(produtos, total, page), nil
 }
 
 func (r *produtoRepositoryImpl) FindByID(restauranteID, produtoID uint64) (*model.Produto, error) {
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository creates a new RefreshTokenRepository
+func NewRefreshTokenRepository(db *gorm.DB) *refreshTokenRepositoryImpl {
+	return &refreshTokenRepositoryImpl{db: db}
+}
+
+func (r *refreshTokenRepositoryImpl) FindByHash(tokenHash string) (*model.RefreshToken, error) {
+	var token model.RefreshToken
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepositoryImpl) Save(token *model.RefreshToken) error {
+	return r.db.Save(token).Error
+}
+
+// MarcarUsado marca o token como usado apenas se ele ainda não foi usado, para que
+// duas renovações concorrentes com o mesmo token não sejam aceitas
+func (r *refreshTokenRepositoryImpl) MarcarUsado(id uint64, data time.Time) (bool, error) {
+	result := r.db.Model(&model.RefreshToken{}).
+		Where("id = ? AND data_uso IS NULL AND data_revogacao IS NULL", id).
+		Update("data_uso", data)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *refreshTokenRepositoryImpl) RevogarFamilia(familia string, data time.Time) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("familia = ? AND data_revogacao IS NULL", familia).
+		Update("data_revogacao", data).Error
+}
+
+func (r *refreshTokenRepositoryImpl) RevogarPorUsuario(usuarioID uint64, data time.Time) error {
+	return r.db.Model(&model.RefreshToken{}).
+		Where("usuario_id = ? AND data_revogacao IS NULL", usuarioID).
+		Update("data_revogacao", data).Error
+}
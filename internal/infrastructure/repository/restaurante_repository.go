@@ -1,7 +1,12 @@
 package repository
 
 import (
+	"math"
+	"strings"
+
 	"github.com/yurisasc/algafood-go/internal/domain/model"
+	domainRepo "github.com/yurisasc/algafood-go/internal/domain/repository"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -14,12 +19,134 @@ func NewRestauranteRepository(db *gorm.DB) *restauranteRepositoryImpl {
 	return &restauranteRepositoryImpl{db: db}
 }
 
-func (r *restauranteRepositoryImpl) FindAll() ([]model.Restaurante, error) {
+var camposOrdenacaoRestaurante = pagination.CamposOrdenacao{
+	"id":           "id",
+	"nome":         "nome",
+	"taxaFrete":    "taxa_frete",
+	"dataCadastro": "data_cadastro",
+	"avaliacao":    "avaliacao_media",
+}
+
+// camposOrdenacaoRestauranteProximidade inclui a distância, disponível apenas na busca por localização
+var camposOrdenacaoRestauranteProximidade = pagination.CamposOrdenacao{
+	"id":           "id",
+	"nome":         "nome",
+	"taxaFrete":    "taxa_frete",
+	"dataCadastro": "data_cadastro",
+	"avaliacao":    "avaliacao_media",
+	"distancia":    "distancia_km",
+}
+
+// kmPorGrauLatitude aproxima a distância de um grau de latitude, usada no pré-filtro por bounding box
+const kmPorGrauLatitude = 111.045
+
+func (r *restauranteRepositoryImpl) FindAll(filter *domainRepo.RestauranteFilter, page *pagination.Pageable) (*pagination.Page[model.Restaurante], error) {
 	var restaurantes []model.Restaurante
-	if err := r.db.Preload("Cozinha").Find(&restaurantes).Error; err != nil {
+	var total int64
+
+	buscaPorLocalizacao := filter != nil && filter.Latitude != nil && filter.Longitude != nil
+
+	campos, padrao := camposOrdenacaoRestaurante, "id,asc"
+	if buscaPorLocalizacao {
+		campos, padrao = camposOrdenacaoRestauranteProximidade, "distancia,asc"
+	}
+	ordenacao, err := page.Ordenacao(campos, padrao, "id")
+	if err != nil {
+		return nil, err
+	}
+
+	query := filtrarRestaurantes(r.db.Model(&model.Restaurante{}), filter)
+	query.Count(&total)
+
+	if buscaPorLocalizacao {
+		query = query.Select("restaurante.*, ST_Distance_Sphere(POINT(endereco_longitude, endereco_latitude), POINT(?, ?)) / 1000 AS distancia_km",
+			*filter.Longitude, *filter.Latitude)
+	}
+
+	if err := query.Preload("Cozinha").Offset(page.Offset()).Limit(page.Size).Order(ordenacao.SQL()).Find(&restaurantes).Error; err != nil {
 		return nil, err
 	}
-	return restaurantes, nil
+
+	return pagination.NewPage(restaurantes, total, page), nil
+}
+
+// filtrarRestaurantes aplica os filtros da pesquisa de restaurantes
+func filtrarRestaurantes(query *gorm.DB, filter *domainRepo.RestauranteFilter) *gorm.DB {
+	if filter == nil {
+		return query
+	}
+	if filter.Nome != nil && strings.TrimSpace(*filter.Nome) != "" {
+		query = query.Where("nome LIKE ?", "%"+escaparLike(strings.TrimSpace(*filter.Nome))+"%")
+	}
+	if filter.CozinhaID != nil {
+		query = query.Where("cozinha_id = ?", *filter.CozinhaID)
+	}
+	if filter.CidadeID != nil {
+		// Mesma regra de AtendeCidade: sem área de entrega cadastrada, o restaurante
+		// entrega apenas na cidade do próprio endereço
+		query = query.Where(`(EXISTS (SELECT 1 FROM restaurante_cidade_entrega rce WHERE rce.restaurante_id = restaurante.id AND rce.cidade_id = ?)
+			OR (endereco_cidade_id = ? AND NOT EXISTS (SELECT 1 FROM restaurante_cidade_entrega rce WHERE rce.restaurante_id = restaurante.id)))`,
+			*filter.CidadeID, *filter.CidadeID)
+	}
+	if filter.Aberto != nil {
+		query = query.Where("aberto = ?", *filter.Aberto)
+	}
+	if filter.Ativo != nil {
+		query = query.Where("ativo = ?", *filter.Ativo)
+	}
+	// Os filtros de frete usam a faixa de taxas da estratégia configurada: taxaFreteMax
+	// seleciona quem cobra até o valor em alguma entrega e taxaFreteMin quem cobra ao
+	// menos o valor em alguma entrega
+	if filter.TaxaFreteMin != nil {
+		query = query.Where(taxaFreteEfetiva("MAX")+" >= ?", *filter.TaxaFreteMin)
+	}
+	if filter.TaxaFreteMax != nil {
+		query = query.Where(taxaFreteEfetiva("MIN")+" <= ?", *filter.TaxaFreteMax)
+	}
+	if filter.FreteGratis != nil {
+		// Frete grátis em qualquer entrega, independente do subtotal (freteGratisAcima)
+		if *filter.FreteGratis {
+			query = query.Where(taxaFreteEfetiva("MAX") + " = 0")
+		} else {
+			query = query.Where(taxaFreteEfetiva("MAX") + " > 0")
+		}
+	}
+	if filter.Latitude != nil && filter.Longitude != nil {
+		query = filtrarPorRaio(query, *filter.Latitude, *filter.Longitude, filter.RaioKm)
+	}
+	return query
+}
+
+// taxaFreteEfetiva calcula a menor (MIN) ou a maior (MAX) taxa cobrada pelo restaurante na
+// estratégia de frete configurada. Sem configuração, ou com FIXO, vale taxa_frete; uma tabela
+// de localidades ou de faixas vazia resulta em NULL, e o restaurante não passa no filtro.
+func taxaFreteEfetiva(agregacao string) string {
+	return `(CASE (SELECT rf.tipo FROM restaurante_frete rf WHERE rf.restaurante_id = restaurante.id)
+		WHEN '` + string(model.TipoFreteLocalidade) + `' THEN (SELECT ` + agregacao + `(fl.taxa) FROM restaurante_frete_localidade fl WHERE fl.restaurante_id = restaurante.id)
+		WHEN '` + string(model.TipoFreteDistancia) + `' THEN (SELECT ` + agregacao + `(ff.taxa) FROM restaurante_frete_faixa ff WHERE ff.restaurante_id = restaurante.id)
+		ELSE restaurante.taxa_frete END)`
+}
+
+// filtrarPorRaio seleciona os restaurantes com coordenadas a até raioKm do ponto.
+// O bounding box permite usar o índice das coordenadas antes do cálculo da distância.
+func filtrarPorRaio(query *gorm.DB, latitude, longitude, raioKm float64) *gorm.DB {
+	deltaLatitude := raioKm / kmPorGrauLatitude
+	query = query.Where("endereco_latitude BETWEEN ? AND ?", latitude-deltaLatitude, latitude+deltaLatitude).
+		Where("endereco_longitude IS NOT NULL")
+
+	// Perto dos polos o grau de longitude tende a zero e o bounding box não ajuda
+	if cosLatitude := math.Cos(latitude * math.Pi / 180); cosLatitude > 0.01 {
+		deltaLongitude := raioKm / (kmPorGrauLatitude * cosLatitude)
+		query = query.Where("endereco_longitude BETWEEN ? AND ?", longitude-deltaLongitude, longitude+deltaLongitude)
+	}
+
+	return query.Where("ST_Distance_Sphere(POINT(endereco_longitude, endereco_latitude), POINT(?, ?)) <= ?",
+		longitude, latitude, raioKm*1000)
+}
+
+// escaparLike escapa os curingas do LIKE para que o termo seja buscado literalmente
+func escaparLike(termo string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(termo)
 }
 
 func (r *restauranteRepositoryImpl) FindByID(id uint64) (*model.Restaurante, error) {
@@ -64,3 +191,41 @@ func (r *restauranteRepositoryImpl) ExistsResponsavel(restauranteID, usuarioID u
 	}
 	return count > 0, nil
 }
+
+func (r *restauranteRepositoryImpl) FindCidadesEntrega(restauranteID uint64) ([]model.Cidade, error) {
+	var cidades []model.Cidade
+	if err := r.db.Preload("Estado").
+		Joins("JOIN restaurante_cidade_entrega rce ON rce.cidade_id = cidade.id").
+		Where("rce.restaurante_id = ?", restauranteID).
+		Order("cidade.nome").
+		Find(&cidades).Error; err != nil {
+		return nil, err
+	}
+	return cidades, nil
+}
+
+func (r *restauranteRepositoryImpl) AddCidadeEntrega(restauranteID, cidadeID uint64) error {
+	return r.db.Exec("INSERT IGNORE INTO restaurante_cidade_entrega (restaurante_id, cidade_id) VALUES (?, ?)", restauranteID, cidadeID).Error
+}
+
+func (r *restauranteRepositoryImpl) RemoveCidadeEntrega(restauranteID, cidadeID uint64) error {
+	return r.db.Exec("DELETE FROM restaurante_cidade_entrega WHERE restaurante_id = ? AND cidade_id = ?", restauranteID, cidadeID).Error
+}
+
+func (r *restauranteRepositoryImpl) AplicarAgenda(restauranteID uint64, aberto bool) (bool, error) {
+	result := r.db.Model(&model.Restaurante{}).
+		Where("id = ? AND ativo = ? AND (agenda_aberto IS NULL OR agenda_aberto <> ?)", restauranteID, true, aberto).
+		Updates(map[string]any{"agenda_aberto": aberto, "aberto": aberto})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *restauranteRepositoryImpl) LimparAgenda(restauranteIDs []uint64) error {
+	query := r.db.Model(&model.Restaurante{}).Where("agenda_aberto IS NOT NULL")
+	if len(restauranteIDs) > 0 {
+		query = query.Where("(ativo = ? OR id NOT IN ?)", false, restauranteIDs)
+	}
+	return query.Update("agenda_aberto", nil).Error
+}
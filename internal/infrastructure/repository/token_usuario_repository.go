@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"gorm.io/gorm"
+)
+
+type tokenUsuarioRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTokenUsuarioRepository creates a new TokenUsuarioRepository
+func NewTokenUsuarioRepository(db *gorm.DB) *tokenUsuarioRepositoryImpl {
+	return &tokenUsuarioRepositoryImpl{db: db}
+}
+
+func (r *tokenUsuarioRepositoryImpl) FindByHash(tokenHash string) (*model.TokenUsuario, error) {
+	var token model.TokenUsuario
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *tokenUsuarioRepositoryImpl) Save(token *model.TokenUsuario) error {
+	return r.db.Save(token).Error
+}
+
+// MarcarUsado marca o token como usado apenas se ele ainda não foi usado, para que
+// o mesmo link não seja aceito por duas requisições concorrentes
+func (r *tokenUsuarioRepositoryImpl) MarcarUsado(id uint64, data time.Time) (bool, error) {
+	result := r.db.Model(&model.TokenUsuario{}).
+		Where("id = ? AND data_uso IS NULL", id).
+		Update("data_uso", data)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *tokenUsuarioRepositoryImpl) InvalidarPendentes(usuarioID uint64, tipo model.TipoTokenUsuario, data time.Time) error {
+	return r.db.Model(&model.TokenUsuario{}).
+		Where("usuario_id = ? AND tipo = ? AND data_uso IS NULL", usuarioID, tipo).
+		Update("data_uso", data).Error
+}
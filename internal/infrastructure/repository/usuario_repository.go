@@ -1,7 +1,10 @@
 package repository
 
 import (
+	"time"
+
 	"github.com/yurisasc/algafood-go/internal/domain/model"
+	"github.com/yurisasc/algafood-go/pkg/pagination"
 	"gorm.io/gorm"
 )
 
@@ -14,12 +17,29 @@ func NewUsuarioRepository(db *gorm.DB) *usuarioRepositoryImpl {
 	return &usuarioRepositoryImpl{db: db}
 }
 
-func (r *usuarioRepositoryImpl) FindAll() ([]model.Usuario, error) {
+var camposOrdenacaoUsuario = pagination.CamposOrdenacao{
+	"id":           "id",
+	"nome":         "nome",
+	"email":        "email",
+	"dataCadastro": "data_cadastro",
+}
+
+func (r *usuarioRepositoryImpl) FindAll(page *pagination.Pageable) (*pagination.Page[model.Usuario], error) {
 	var usuarios []model.Usuario
-	if err := r.db.Find(&usuarios).Error; err != nil {
+	var total int64
+
+	ordenacao, err := page.Ordenacao(camposOrdenacaoUsuario, "id,asc", "id")
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.Model(&model.Usuario{}).Count(&total)
+
+	if err := r.db.Offset(page.Offset()).Limit(page.Size).Order(ordenacao.SQL()).Find(&usuarios).Error; err != nil {
 		return nil, err
 	}
-	return usuarios, nil
+
+	return pagination.NewPage(usuarios, total, page), nil
 }
 
 func (r *usuarioRepositoryImpl) FindByID(id uint64) (*model.Usuario, error) {
@@ -42,6 +62,24 @@ func (r *usuarioRepositoryImpl) Save(usuario *model.Usuario) error {
 	return r.db.Save(usuario).Error
 }
 
+func (r *usuarioRepositoryImpl) SaveConsumindoToken(usuario *model.Usuario, tokenID uint64, data time.Time) (bool, error) {
+	consumido := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&model.TokenUsuario{}).
+			Where("id = ? AND data_uso IS NULL", tokenID).
+			Update("data_uso", data)
+		if result.Error != nil || result.RowsAffected != 1 {
+			return result.Error
+		}
+		consumido = true
+		return tx.Save(usuario).Error
+	})
+	if err != nil {
+		return false, err
+	}
+	return consumido, nil
+}
+
 func (r *usuarioRepositoryImpl) AddGrupo(usuarioID, grupoID uint64) error {
 	return r.db.Exec("INSERT INTO usuario_grupo (usuario_id, grupo_id) VALUES (?, ?)", usuarioID, grupoID).Error
 }
@@ -21,9 +21,10 @@ func (r *vendaQueryRepositoryImpl) ConsultarVendasDiarias(filter *domainRepo.Ven
 		SELECT
 			DATE(CONVERT_TZ(p.data_criacao, '+00:00', ?)) as data,
 			COUNT(p.id) as total_vendas,
-			SUM(p.valor_total) as total_faturado
+			SUM(p.valor_total) as total_faturado,
+			SUM(p.desconto) as total_descontos
 		FROM pedido p
-		WHERE p.status IN ('CONFIRMADO', 'ENTREGUE')
+		WHERE p.status IN ('CONFIRMADO', 'PREPARANDO', 'SAIU_PARA_ENTREGA', 'ENTREGUE')
 	`
 	args := []interface{}{timeOffset}
 
@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+// defaultInterval é usado quando horario.scheduler_interval_seconds não está configurado
+const defaultInterval = 30 * time.Second
+
+// Sincronizador abre e fecha os restaurantes conforme a agenda de funcionamento
+type Sincronizador interface {
+	SincronizarAberturas(ctx context.Context) error
+}
+
+// AberturaScheduler executa periodicamente a sincronização da agenda dos restaurantes
+type AberturaScheduler struct {
+	sincronizador Sincronizador
+	interval      time.Duration
+	stopChan      chan struct{}
+	stopOnce      sync.Once
+	done          chan struct{}
+}
+
+// NewAberturaScheduler cria um novo scheduler de abertura/fechamento
+func NewAberturaScheduler(cfg *config.HorarioConfig, sincronizador Sincronizador) *AberturaScheduler {
+	interval := time.Duration(cfg.SchedulerIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &AberturaScheduler{
+		sincronizador: sincronizador,
+		interval:      interval,
+		stopChan:      make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+}
+
+// Start inicia o scheduler em uma goroutine
+func (s *AberturaScheduler) Start(ctx context.Context) {
+	log.Printf("Iniciando scheduler de horarios de funcionamento (intervalo: %s)", s.interval)
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.sincronizador.SincronizarAberturas(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Erro ao sincronizar horarios de funcionamento: %v", err)
+			}
+
+			select {
+			case <-s.stopChan:
+				log.Println("Scheduler de horarios de funcionamento parado")
+				return
+			case <-ctx.Done():
+				log.Println("Contexto do scheduler de horarios de funcionamento cancelado")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop para o scheduler e aguarda a sincronização em andamento terminar
+func (s *AberturaScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+	<-s.done
+}
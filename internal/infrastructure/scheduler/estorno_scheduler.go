@@ -0,0 +1,78 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+// defaultEstornoInterval é usado quando pagamento.estorno_interval_seconds não está configurado
+const defaultEstornoInterval = 60 * time.Second
+
+// Estornador refaz os estornos de pedidos cancelados que ainda estão com o valor capturado
+type Estornador interface {
+	EstornarCancelados(ctx context.Context) error
+}
+
+// EstornoScheduler executa periodicamente os estornos pendentes
+type EstornoScheduler struct {
+	estornador Estornador
+	interval   time.Duration
+	stopChan   chan struct{}
+	stopOnce   sync.Once
+	done       chan struct{}
+}
+
+// NewEstornoScheduler cria um novo scheduler de estornos
+func NewEstornoScheduler(cfg *config.PagamentoConfig, estornador Estornador) *EstornoScheduler {
+	interval := time.Duration(cfg.EstornoIntervalSeconds) * time.Second
+	if interval <= 0 {
+		interval = defaultEstornoInterval
+	}
+
+	return &EstornoScheduler{
+		estornador: estornador,
+		interval:   interval,
+		stopChan:   make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+// Start inicia o scheduler em uma goroutine
+func (s *EstornoScheduler) Start(ctx context.Context) {
+	log.Printf("Iniciando scheduler de estornos (intervalo: %s)", s.interval)
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.estornador.EstornarCancelados(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Erro ao refazer estornos pendentes: %v", err)
+			}
+
+			select {
+			case <-s.stopChan:
+				log.Println("Scheduler de estornos parado")
+				return
+			case <-ctx.Done():
+				log.Println("Contexto do scheduler de estornos cancelado")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop para o scheduler e aguarda os estornos em andamento terminarem
+func (s *EstornoScheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChan)
+	})
+	<-s.done
+}
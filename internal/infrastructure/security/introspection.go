@@ -0,0 +1,167 @@
+package security
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+const (
+	// Prefixo das chaves do cache de introspecção no Redis
+	introspectionCachePrefix = "token:introspection:"
+
+	// Tempo máximo de cache quando o servidor não informa exp
+	introspectionCacheSemExp = time.Minute
+
+	introspectionTimeout      = 5 * time.Second
+	introspectionCacheTimeout = 100 * time.Millisecond
+)
+
+// ErrTokenInativo indica que o servidor de autorização considerou o token inválido
+var ErrTokenInativo = errors.New("token inativo")
+
+// introspectionResponse segue a RFC 7662; email não é padrão mas é comum nos servidores
+type introspectionResponse struct {
+	Active        bool   `json:"active"`
+	Sub           string `json:"sub"`
+	Username      string `json:"username"`
+	Email         string `json:"email"`
+	EmailVerified *bool  `json:"email_verified"`
+	Exp           int64  `json:"exp"`
+}
+
+// IntrospectionValidator valida tokens opacos no endpoint de introspecção do
+// servidor de autorização. Os tokens ativos ficam em cache no Redis até o exp,
+// para não consultar o servidor a cada requisição.
+type IntrospectionValidator struct {
+	cfg         *config.OpaqueTokenConfig
+	httpClient  *http.Client
+	redisClient *redis.Client
+}
+
+func NewIntrospectionValidator(cfg *config.OpaqueTokenConfig, redisCfg *config.RedisConfig) *IntrospectionValidator {
+	client := redis.NewClient(&redis.Options{
+		Addr:     fmt.Sprintf("%s:%d", redisCfg.Host, redisCfg.Port),
+		Password: redisCfg.Password,
+		DB:       redisCfg.DB,
+	})
+
+	return &IntrospectionValidator{
+		cfg:         cfg,
+		httpClient:  &http.Client{Timeout: introspectionTimeout},
+		redisClient: client,
+	}
+}
+
+func (v *IntrospectionValidator) Validar(ctx context.Context, token string) (*Identidade, error) {
+	chave := introspectionCachePrefix + hashToken(token)
+
+	if identidade, ok := v.buscarCache(ctx, chave); ok {
+		return identidade, nil
+	}
+
+	resp, err := v.introspectar(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Active {
+		return nil, ErrTokenInativo
+	}
+
+	ttl := introspectionCacheSemExp
+	if resp.Exp > 0 {
+		ttl = time.Until(time.Unix(resp.Exp, 0))
+		if ttl <= 0 {
+			return nil, ErrTokenInativo
+		}
+	}
+
+	// Tokens externos identificam o usuário só pelo e-mail, nunca por um sub numérico
+	verificado := resp.EmailVerified == nil || *resp.EmailVerified
+	identidade := &Identidade{Subject: resp.Sub, Email: emailExterno(resp.Email, verificado, resp.Username)}
+
+	v.gravarCache(ctx, chave, identidade, ttl)
+	return identidade, nil
+}
+
+func (v *IntrospectionValidator) introspectar(ctx context.Context, token string) (*introspectionResponse, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.cfg.IntrospectionURI, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(v.cfg.ClientID, v.cfg.ClientSecret)
+
+	httpResp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("falha na introspeccao do token: %w", err)
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("falha na introspeccao do token: status %d", httpResp.StatusCode)
+	}
+
+	var resp introspectionResponse
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("resposta de introspeccao invalida: %w", err)
+	}
+	return &resp, nil
+}
+
+func (v *IntrospectionValidator) buscarCache(ctx context.Context, chave string) (*Identidade, bool) {
+	ctx, cancel := context.WithTimeout(ctx, introspectionCacheTimeout)
+	defer cancel()
+
+	data, err := v.redisClient.Get(ctx, chave).Bytes()
+	if err != nil {
+		return nil, false
+	}
+
+	var identidade Identidade
+	if err := json.Unmarshal(data, &identidade); err != nil {
+		return nil, false
+	}
+	return &identidade, true
+}
+
+func (v *IntrospectionValidator) gravarCache(ctx context.Context, chave string, identidade *Identidade, ttl time.Duration) {
+	data, err := json.Marshal(identidade)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, introspectionCacheTimeout)
+	defer cancel()
+
+	if err := v.redisClient.Set(ctx, chave, data, ttl).Err(); err != nil {
+		log.Printf("Aviso: Falha ao armazenar introspeccao em cache: %v", err)
+	}
+}
+
+// Close fecha a conexão com o Redis
+func (v *IntrospectionValidator) Close() error {
+	return v.redisClient.Close()
+}
+
+// hashToken evita guardar o token em texto puro nas chaves do Redis
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package security
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+func TestIntrospectionValidatorValidar(t *testing.T) {
+	verificado, naoVerificado := true, false
+	exp := time.Now().Add(time.Hour).Unix()
+	respostas := map[string]introspectionResponse{
+		"ativo":          {Active: true, Sub: "42", Email: "maria@example.com", Exp: exp},
+		"username":       {Active: true, Sub: "42", Username: "joao@example.com", Exp: exp},
+		"nao-verificado": {Active: true, Sub: "42", Email: "maria@example.com", EmailVerified: &naoVerificado, Exp: exp},
+		"verificado":     {Active: true, Email: "maria@example.com", EmailVerified: &verificado, Exp: exp},
+		"inativo":        {Active: false},
+		"expirado":       {Active: true, Email: "maria@example.com", Exp: time.Now().Add(-time.Minute).Unix()},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if usuario, senha, ok := r.BasicAuth(); !ok || usuario != "algafood-api" || senha != "segredo" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(respostas[r.PostForm.Get("token")])
+	}))
+	defer srv.Close()
+
+	// Redis fora do ar: o cache é opcional e a introspecção segue funcionando
+	validator := NewIntrospectionValidator(
+		&config.OpaqueTokenConfig{IntrospectionURI: srv.URL, ClientID: "algafood-api", ClientSecret: "segredo"},
+		&config.RedisConfig{Host: "127.0.0.1", Port: 1},
+	)
+	defer validator.Close()
+
+	tests := []struct {
+		token     string
+		wantErr   bool
+		wantEmail string
+	}{
+		{token: "ativo", wantEmail: "maria@example.com"},
+		{token: "username", wantEmail: "joao@example.com"},
+		{token: "nao-verificado", wantEmail: ""},
+		{token: "verificado", wantEmail: "maria@example.com"},
+		{token: "inativo", wantErr: true},
+		{token: "expirado", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.token, func(t *testing.T) {
+			identidade, err := validator.Validar(context.Background(), tt.token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %+v", identidade)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// O sub numérico do servidor externo nunca vira ID de usuário
+			if identidade.UsuarioID != 0 {
+				t.Errorf("UsuarioID = %d, esperava 0", identidade.UsuarioID)
+			}
+			if identidade.Email != tt.wantEmail {
+				t.Errorf("Email = %q, esperava %q", identidade.Email, tt.wantEmail)
+			}
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package security
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Tempo que as chaves de um JWKS remoto ficam em cache
+	jwksRemotoTTL = time.Hour
+	// Intervalo mínimo entre buscas forçadas por um kid desconhecido
+	jwksRemotoIntervaloMinimo = time.Minute
+	jwksRemotoTimeout         = 5 * time.Second
+)
+
+// JWK é uma chave pública no formato JSON Web Key (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// JWKSet é o documento publicado em /.well-known/jwks.json
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+func novoJWK(kid, alg string, key crypto.PublicKey) (JWK, bool) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return JWK{
+			Kty: "RSA",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			N:   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, true
+	case *ecdsa.PublicKey:
+		tamanho := (k.Curve.Params().BitSize + 7) / 8
+		return JWK{
+			Kty: "EC",
+			Kid: kid,
+			Use: "sig",
+			Alg: alg,
+			Crv: k.Curve.Params().Name,
+			X:   base64.RawURLEncoding.EncodeToString(k.X.FillBytes(make([]byte, tamanho))),
+			Y:   base64.RawURLEncoding.EncodeToString(k.Y.FillBytes(make([]byte, tamanho))),
+		}, true
+	}
+	return JWK{}, false
+}
+
+// chavePublica converte o JWK de volta para uma chave pública
+func (j JWK) chavePublica() (crypto.PublicKey, error) {
+	switch j.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(j.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(j.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch j.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("curva %s nao suportada", j.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(j.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(j.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	}
+	return nil, fmt.Errorf("tipo de chave %s nao suportado", j.Kty)
+}
+
+// JWKSRemoto busca e mantém em cache as chaves públicas de outro emissor
+type JWKSRemoto struct {
+	url        string
+	emissor    Emissor
+	httpClient *http.Client
+
+	mu           sync.Mutex
+	chaves       map[string]crypto.PublicKey
+	atualizadoEm time.Time
+	ultimaBusca  time.Time
+	// buscando é fechado ao fim da busca em andamento; nil quando não há busca
+	buscando chan struct{}
+}
+
+// NewJWKSRemoto cria o cliente do JWKS. As chaves são buscadas sob demanda,
+// então o emissor remoto não precisa estar no ar quando a API inicia.
+func NewJWKSRemoto(url string, emissor Emissor) *JWKSRemoto {
+	emissor.local = false
+	return &JWKSRemoto{
+		url:        url,
+		emissor:    emissor,
+		httpClient: &http.Client{Timeout: jwksRemotoTimeout},
+		chaves:     make(map[string]crypto.PublicKey),
+	}
+}
+
+// Chave retorna a chave pública do kid, buscando o JWKS de novo quando o cache
+// expira ou o kid é desconhecido (o emissor pode ter rotacionado as chaves).
+// A busca é feita fora do lock; requisições concorrentes aguardam a mesma busca.
+func (r *JWKSRemoto) Chave(ctx context.Context, kid string) (crypto.PublicKey, error) {
+	r.mu.Lock()
+	chave, ok := r.chaves[kid]
+	expirado := time.Since(r.atualizadoEm) > jwksRemotoTTL
+	if ok && !expirado {
+		r.mu.Unlock()
+		return chave, nil
+	}
+
+	espera := r.buscando
+	if espera == nil && (expirado || time.Since(r.ultimaBusca) >= jwksRemotoIntervaloMinimo) {
+		espera = make(chan struct{})
+		r.buscando = espera
+		r.ultimaBusca = time.Now()
+		go r.buscar(espera)
+	}
+	r.mu.Unlock()
+
+	if espera != nil {
+		select {
+		case <-espera:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if chave, ok := r.chaves[kid]; ok {
+		return chave, nil
+	}
+	return nil, fmt.Errorf("kid %s desconhecido", kid)
+}
+
+// buscar atualiza as chaves e fecha concluida ao terminar. Em caso de falha,
+// as chaves anteriores continuam valendo.
+func (r *JWKSRemoto) buscar(concluida chan struct{}) {
+	chaves, err := r.baixar()
+
+	r.mu.Lock()
+	if err != nil {
+		log.Printf("Aviso: Falha ao buscar JWKS em %s: %v", r.url, err)
+	} else {
+		r.chaves = chaves
+		r.atualizadoEm = time.Now()
+	}
+	r.buscando = nil
+	r.mu.Unlock()
+	close(concluida)
+}
+
+func (r *JWKSRemoto) baixar() (map[string]crypto.PublicKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), jwksRemotoTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	var set JWKSet
+	if err := json.NewDecoder(resp.Body).Decode(&set); err != nil {
+		return nil, err
+	}
+
+	chaves := make(map[string]crypto.PublicKey, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		chave, err := jwk.chavePublica()
+		if err != nil {
+			log.Printf("Aviso: Chave %s do JWKS ignorada: %v", jwk.Kid, err)
+			continue
+		}
+		chaves[jwk.Kid] = chave
+	}
+	if len(chaves) == 0 {
+		return nil, errors.New("nenhuma chave de assinatura no JWKS")
+	}
+	return chaves, nil
+}
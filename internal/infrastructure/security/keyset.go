@@ -0,0 +1,204 @@
+package security
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+// Chave é uma chave de assinatura carregada de um arquivo PEM.
+// Chaves só com a parte pública servem apenas para validar tokens antigos durante a rotação.
+type Chave struct {
+	Kid     string
+	Metodo  jwt.SigningMethod
+	Privada crypto.Signer
+	Publica crypto.PublicKey
+}
+
+// KeySet reúne as chaves da API. Os tokens são assinados com a chave ativa e
+// validados com qualquer chave do conjunto, o que permite rotacionar as chaves
+// sem invalidar os tokens já emitidos. Sem chaves configuradas, usa HS256 com
+// jwt.secret_key.
+type KeySet struct {
+	ativa   *Chave
+	chaves  map[string]*Chave
+	ordem   []string
+	segredo []byte
+	emissor Emissor
+}
+
+// LoadKeySet carrega as chaves de jwt.keystore.keys
+func LoadKeySet(cfg *config.JWTConfig) (*KeySet, error) {
+	ks := &KeySet{
+		chaves:  make(map[string]*Chave),
+		segredo: []byte(cfg.SecretKey),
+		emissor: Emissor{Issuer: cfg.Issuer, Audience: cfg.Audience, local: true},
+	}
+
+	for _, chaveCfg := range cfg.Keystore.Keys {
+		if chaveCfg.Kid == "" {
+			return nil, fmt.Errorf("chave %s sem kid", chaveCfg.Path)
+		}
+		if _, ok := ks.chaves[chaveCfg.Kid]; ok {
+			return nil, fmt.Errorf("kid %s duplicado", chaveCfg.Kid)
+		}
+
+		chave, err := carregarChave(chaveCfg)
+		if err != nil {
+			return nil, fmt.Errorf("falha ao carregar chave %s: %w", chaveCfg.Kid, err)
+		}
+		ks.chaves[chave.Kid] = chave
+		ks.ordem = append(ks.ordem, chave.Kid)
+	}
+
+	if len(ks.chaves) == 0 {
+		if len(ks.segredo) == 0 {
+			return nil, errors.New("configure jwt.keystore.keys ou jwt.secret_key")
+		}
+		return ks, nil
+	}
+
+	// A chave ativa é a indicada em active_kid ou a primeira com chave privada
+	kidAtivo := cfg.Keystore.ActiveKid
+	if kidAtivo == "" {
+		for _, kid := range ks.ordem {
+			if ks.chaves[kid].Privada != nil {
+				kidAtivo = kid
+				break
+			}
+		}
+	}
+	ativa, ok := ks.chaves[kidAtivo]
+	if !ok || ativa.Privada == nil {
+		return nil, fmt.Errorf("nenhuma chave privada disponivel para assinar (active_kid: %q)", cfg.Keystore.ActiveKid)
+	}
+	ks.ativa = ativa
+
+	return ks, nil
+}
+
+func carregarChave(cfg config.ChaveConfig) (*Chave, error) {
+	data, err := os.ReadFile(cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("arquivo PEM invalido")
+	}
+
+	chave := &Chave{Kid: cfg.Kid}
+	switch block.Type {
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		signer, ok := key.(crypto.Signer)
+		if !ok {
+			return nil, errors.New("tipo de chave privada nao suportado")
+		}
+		chave.Privada = signer
+	case "RSA PRIVATE KEY":
+		key, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chave.Privada = key
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chave.Privada = key
+	case "PUBLIC KEY":
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		chave.Publica = key
+	default:
+		return nil, fmt.Errorf("bloco PEM %q nao suportado", block.Type)
+	}
+
+	if chave.Privada != nil {
+		chave.Publica = chave.Privada.Public()
+	}
+
+	chave.Metodo, err = metodoPara(chave.Publica)
+	if err != nil {
+		return nil, err
+	}
+	return chave, nil
+}
+
+// metodoPara escolhe o algoritmo pelo tipo da chave: RSA usa RS256 e EC usa ES256/ES384/ES512 conforme a curva
+func metodoPara(key crypto.PublicKey) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < 2048 {
+			return nil, errors.New("chaves RSA devem ter pelo menos 2048 bits")
+		}
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PublicKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return jwt.SigningMethodES256, nil
+		case elliptic.P384():
+			return jwt.SigningMethodES384, nil
+		case elliptic.P521():
+			return jwt.SigningMethodES512, nil
+		}
+		return nil, errors.New("curva EC nao suportada")
+	default:
+		return nil, fmt.Errorf("tipo de chave %T nao suportado", key)
+	}
+}
+
+// Assimetrico indica se os tokens são assinados com chave privada (RS/ES)
+func (ks *KeySet) Assimetrico() bool {
+	return ks.ativa != nil
+}
+
+// Assinar emite o token com a chave ativa, informando o kid no cabeçalho
+func (ks *KeySet) Assinar(claims jwt.Claims) (string, error) {
+	if ks.ativa == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.segredo)
+	}
+
+	token := jwt.NewWithClaims(ks.ativa.Metodo, claims)
+	token.Header["kid"] = ks.ativa.Kid
+	return token.SignedString(ks.ativa.Privada)
+}
+
+// Parse valida um token emitido pela própria API
+func (ks *KeySet) Parse(tokenString string) (*jwt.Token, error) {
+	return NewTokenVerifier(ks, nil).Parse(tokenString)
+}
+
+// chave localiza a chave pública do kid informado
+func (ks *KeySet) chave(kid string) (*Chave, bool) {
+	chave, ok := ks.chaves[kid]
+	return chave, ok
+}
+
+// JWKS retorna as chaves públicas no formato JSON Web Key Set
+func (ks *KeySet) JWKS() JWKSet {
+	set := JWKSet{Keys: make([]JWK, 0, len(ks.ordem))}
+	for _, kid := range ks.ordem {
+		chave := ks.chaves[kid]
+		if jwk, ok := novoJWK(chave.Kid, chave.Metodo.Alg(), chave.Publica); ok {
+			set.Keys = append(set.Keys, jwk)
+		}
+	}
+	return set
+}
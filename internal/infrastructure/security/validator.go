@@ -0,0 +1,122 @@
+package security
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/yurisasc/algafood-go/internal/config"
+)
+
+const (
+	EstrategiaJWT           = "jwt"
+	EstrategiaJWKS          = "jwks"
+	EstrategiaIntrospection = "introspection"
+)
+
+// Identidade identifica o dono de um token válido. Dependendo do emissor,
+// apenas parte dos campos é preenchida: UsuarioID só vem de tokens assinados
+// pelas chaves da API; emissores externos identificam o usuário pelo e-mail.
+type Identidade struct {
+	UsuarioID uint64 `json:"usuarioId,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Email     string `json:"email,omitempty"`
+}
+
+// TokenValidator valida o token Bearer de uma requisição
+type TokenValidator interface {
+	Validar(ctx context.Context, token string) (*Identidade, error)
+}
+
+// NewTokenValidator escolhe a estratégia configurada em auth.strategy:
+//   - jwt (padrão): tokens da própria API e, se jwt.jwks_url estiver configurado, do emissor remoto
+//   - jwks: apenas tokens do emissor remoto em jwt.jwks_url
+//   - introspection: tokens opacos validados no endpoint RFC 7662 de auth.opaque_token
+func NewTokenValidator(cfg *config.Config, keySet *KeySet) (TokenValidator, error) {
+	switch cfg.Auth.Strategy {
+	case "", EstrategiaJWT:
+		var remoto *JWKSRemoto
+		if cfg.JWT.JWKSURL != "" {
+			var err error
+			if remoto, err = novoJWKSRemoto(&cfg.JWT); err != nil {
+				return nil, err
+			}
+		}
+		return NewTokenVerifier(keySet, remoto), nil
+	case EstrategiaJWKS:
+		if cfg.JWT.JWKSURL == "" {
+			return nil, fmt.Errorf("auth.strategy %q exige jwt.jwks_url", EstrategiaJWKS)
+		}
+		remoto, err := novoJWKSRemoto(&cfg.JWT)
+		if err != nil {
+			return nil, err
+		}
+		return NewTokenVerifier(nil, remoto), nil
+	case EstrategiaIntrospection:
+		if cfg.Auth.OpaqueToken.IntrospectionURI == "" {
+			return nil, fmt.Errorf("auth.strategy %q exige auth.opaque_token.introspection_uri", EstrategiaIntrospection)
+		}
+		return NewIntrospectionValidator(&cfg.Auth.OpaqueToken, &cfg.Redis), nil
+	default:
+		return nil, fmt.Errorf("auth.strategy %q invalida (use jwt, jwks ou introspection)", cfg.Auth.Strategy)
+	}
+}
+
+// novoJWKSRemoto exige o emissor dos tokens remotos: jwt.jwks_issuer ou, na falta dele, jwt.issuer
+func novoJWKSRemoto(cfg *config.JWTConfig) (*JWKSRemoto, error) {
+	emissor := Emissor{Issuer: cfg.JWKSIssuer, Audience: cfg.JWKSAudience}
+	if emissor.Issuer == "" {
+		emissor.Issuer = cfg.Issuer
+	}
+	if emissor.Audience == "" {
+		emissor.Audience = cfg.Audience
+	}
+	if emissor.Issuer == "" {
+		return nil, fmt.Errorf("jwt.jwks_url exige jwt.jwks_issuer ou jwt.issuer")
+	}
+	return NewJWKSRemoto(cfg.JWKSURL, emissor), nil
+}
+
+// Validar implementa TokenVerifier para tokens JWT. O usuario_id (ou um sub numérico)
+// só é aceito dos tokens da própria API; nos externos vale apenas o e-mail.
+func (v *TokenVerifier) Validar(_ context.Context, tokenString string) (*Identidade, error) {
+	token, emissor, err := v.parse(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("claims invalidas")
+	}
+
+	identidade := &Identidade{}
+	identidade.Subject, _ = claims["sub"].(string)
+
+	if emissor.local {
+		if usuarioID, ok := claims["usuario_id"].(float64); ok {
+			identidade.UsuarioID = uint64(usuarioID)
+		} else if id, err := strconv.ParseUint(identidade.Subject, 10, 64); err == nil {
+			identidade.UsuarioID = id
+		}
+		return identidade, nil
+	}
+
+	verificado, informado := claims["email_verified"].(bool)
+	identidade.Email = emailExterno(claims["email"], verificado || !informado, identidade.Subject)
+	return identidade, nil
+}
+
+// emailExterno escolhe o e-mail de uma identidade externa: o claim email, a menos que o
+// emissor o declare não verificado, ou o login (sub/username) quando ele é um e-mail
+func emailExterno(email interface{}, verificado bool, login string) string {
+	if e, ok := email.(string); ok && e != "" && verificado {
+		return e
+	}
+	if strings.Contains(login, "@") {
+		return login
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	emissorRemoto = "https://auth.example.com"
+	kidRemoto     = "remoto-1"
+)
+
+// servidorJWKS publica a chave pública em um JWKS e conta as buscas recebidas
+func servidorJWKS(t *testing.T, chave *rsa.PrivateKey) (*httptest.Server, *int32) {
+	t.Helper()
+	var buscas int32
+	jwk, _ := novoJWK(kidRemoto, "RS256", &chave.PublicKey)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&buscas, 1)
+		time.Sleep(20 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(JWKSet{Keys: []JWK{jwk}})
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &buscas
+}
+
+func assinarRemoto(t *testing.T, chave *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kidRemoto
+	assinado, err := token.SignedString(chave)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return assinado
+}
+
+func TestTokenVerifierValidarRemoto(t *testing.T) {
+	chave, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, _ := servidorJWKS(t, chave)
+	keySet := &KeySet{segredo: []byte("segredo"), emissor: Emissor{Issuer: "algafood-api", local: true}}
+	verifier := NewTokenVerifier(keySet, NewJWKSRemoto(srv.URL, Emissor{Issuer: emissorRemoto, Audience: "algafood"}))
+
+	exp := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name      string
+		claims    jwt.MapClaims
+		wantErr   bool
+		wantEmail string
+	}{
+		{
+			name:      "ignora usuario_id e sub numerico de emissor externo",
+			claims:    jwt.MapClaims{"iss": emissorRemoto, "aud": "algafood", "exp": exp, "sub": "1", "usuario_id": 1, "email": "maria@example.com"},
+			wantEmail: "maria@example.com",
+		},
+		{
+			name:   "descarta email nao verificado",
+			claims: jwt.MapClaims{"iss": emissorRemoto, "aud": "algafood", "exp": exp, "sub": "1", "email": "maria@example.com", "email_verified": false},
+		},
+		{
+			name:      "usa o sub quando ele e um e-mail",
+			claims:    jwt.MapClaims{"iss": emissorRemoto, "aud": "algafood", "exp": exp, "sub": "joao@example.com"},
+			wantEmail: "joao@example.com",
+		},
+		{
+			name:    "recusa outro emissor",
+			claims:  jwt.MapClaims{"iss": "https://outro.example.com", "aud": "algafood", "exp": exp, "email": "maria@example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "recusa outra audiencia",
+			claims:  jwt.MapClaims{"iss": emissorRemoto, "aud": "outra-api", "exp": exp, "email": "maria@example.com"},
+			wantErr: true,
+		},
+		{
+			name:    "recusa o iss da API assinado pela chave remota",
+			claims:  jwt.MapClaims{"iss": "algafood-api", "aud": "algafood", "exp": exp, "usuario_id": 1},
+			wantErr: true,
+		},
+		{
+			name:    "recusa token expirado",
+			claims:  jwt.MapClaims{"iss": emissorRemoto, "aud": "algafood", "exp": time.Now().Add(-time.Minute).Unix(), "email": "maria@example.com"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identidade, err := verifier.Validar(context.Background(), assinarRemoto(t, chave, tt.claims))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %+v", identidade)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identidade.UsuarioID != 0 {
+				t.Errorf("UsuarioID = %d, esperava 0", identidade.UsuarioID)
+			}
+			if identidade.Email != tt.wantEmail {
+				t.Errorf("Email = %q, esperava %q", identidade.Email, tt.wantEmail)
+			}
+		})
+	}
+}
+
+func TestTokenVerifierValidarLocal(t *testing.T) {
+	keySet := &KeySet{segredo: []byte("segredo"), emissor: Emissor{Issuer: "algafood-api", Audience: "algafood", local: true}}
+	verifier := NewTokenVerifier(keySet, nil)
+
+	exp := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name    string
+		claims  jwt.MapClaims
+		wantID  uint64
+		wantErr bool
+	}{
+		{name: "usuario_id", claims: jwt.MapClaims{"iss": "algafood-api", "aud": "algafood", "exp": exp, "usuario_id": 7}, wantID: 7},
+		{name: "sub numerico", claims: jwt.MapClaims{"iss": "algafood-api", "aud": "algafood", "exp": exp, "sub": "8"}, wantID: 8},
+		{name: "sem audiencia", claims: jwt.MapClaims{"iss": "algafood-api", "exp": exp, "usuario_id": 7}, wantErr: true},
+		{name: "outro emissor", claims: jwt.MapClaims{"iss": "outro", "aud": "algafood", "exp": exp, "usuario_id": 7}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := keySet.Assinar(tt.claims)
+			if err != nil {
+				t.Fatal(err)
+			}
+			identidade, err := verifier.Validar(context.Background(), token)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("esperava erro, obteve %+v", identidade)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identidade.UsuarioID != tt.wantID {
+				t.Errorf("UsuarioID = %d, esperava %d", identidade.UsuarioID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestJWKSRemotoChaveBuscaUmaVez(t *testing.T) {
+	chave, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv, buscas := servidorJWKS(t, chave)
+	remoto := NewJWKSRemoto(srv.URL, Emissor{Issuer: emissorRemoto})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := remoto.Chave(context.Background(), kidRemoto); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := atomic.LoadInt32(buscas); n != 1 {
+		t.Errorf("JWKS buscado %d vezes, esperava 1", n)
+	}
+
+	// Um kid desconhecido logo em seguida não força outra busca
+	if _, err := remoto.Chave(context.Background(), "outro"); err == nil {
+		t.Error("esperava erro para kid desconhecido")
+	}
+	if n := atomic.LoadInt32(buscas); n != 1 {
+		t.Errorf("JWKS buscado %d vezes, esperava 1", n)
+	}
+}
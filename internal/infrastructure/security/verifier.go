@@ -0,0 +1,124 @@
+package security
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// TokenVerifier valida tokens assinados pela própria API (KeySet) ou por um
+// emissor externo cujo JWKS foi configurado em jwt.jwks_url. Com keySet nil,
+// apenas tokens do emissor externo são aceitos. O iss e o aud são conferidos
+// contra o emissor dono da chave que validou a assinatura.
+type TokenVerifier struct {
+	keySet *KeySet
+	remoto *JWKSRemoto
+}
+
+// NewTokenVerifier cria o validador. keySet ou remoto podem ser nil.
+func NewTokenVerifier(keySet *KeySet, remoto *JWKSRemoto) *TokenVerifier {
+	return &TokenVerifier{keySet: keySet, remoto: remoto}
+}
+
+// Emissor reúne o iss e o aud esperados nos tokens de uma origem de chaves.
+// Campos vazios não são conferidos.
+type Emissor struct {
+	Issuer   string
+	Audience string
+	// local indica as chaves da própria API
+	local bool
+}
+
+func (e *Emissor) validar(claims jwt.Claims) error {
+	var opcoes []jwt.ParserOption
+	if e.Issuer != "" {
+		opcoes = append(opcoes, jwt.WithIssuer(e.Issuer))
+	}
+	if e.Audience != "" {
+		opcoes = append(opcoes, jwt.WithAudience(e.Audience))
+	}
+	return jwt.NewValidator(opcoes...).Validate(claims)
+}
+
+// Parse valida a assinatura, a expiração, o emissor e a audiência do token
+func (v *TokenVerifier) Parse(tokenString string) (*jwt.Token, error) {
+	token, _, err := v.parse(tokenString)
+	return token, err
+}
+
+// parse também retorna o emissor cuja chave validou a assinatura
+func (v *TokenVerifier) parse(tokenString string) (*jwt.Token, *Emissor, error) {
+	var emissor *Emissor
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		chave, origem, err := v.keyfunc(token)
+		emissor = origem
+		return chave, err
+	}, jwt.WithValidMethods(v.metodosAceitos()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := emissor.validar(token.Claims); err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", jwt.ErrTokenInvalidClaims, err)
+	}
+	return token, emissor, nil
+}
+
+func (v *TokenVerifier) metodosAceitos() []string {
+	metodos := []string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}
+	// HS256 só é aceito quando a API ainda assina com o segredo compartilhado
+	if v.keySet != nil && !v.keySet.Assimetrico() {
+		metodos = append(metodos, "HS256")
+	}
+	return metodos
+}
+
+func (v *TokenVerifier) keyfunc(token *jwt.Token) (interface{}, *Emissor, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok && v.keySet != nil {
+		return v.keySet.segredo, &v.keySet.emissor, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		return nil, nil, errors.New("token sem kid")
+	}
+
+	if chave, ok := v.chaveLocal(kid); ok {
+		if chave.Metodo.Alg() != token.Method.Alg() {
+			return nil, nil, fmt.Errorf("algoritmo %s nao corresponde a chave %s", token.Method.Alg(), kid)
+		}
+		return chave.Publica, &v.keySet.emissor, nil
+	}
+
+	if v.remoto == nil {
+		return nil, nil, fmt.Errorf("kid %s desconhecido", kid)
+	}
+	chave, err := v.remoto.Chave(context.Background(), kid)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Garante que o algoritmo do cabeçalho corresponde ao tipo da chave
+	switch chave.(type) {
+	case *rsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodRSA); !ok {
+			return nil, nil, fmt.Errorf("algoritmo %s invalido para chave RSA", token.Method.Alg())
+		}
+	case *ecdsa.PublicKey:
+		if _, ok := token.Method.(*jwt.SigningMethodECDSA); !ok {
+			return nil, nil, fmt.Errorf("algoritmo %s invalido para chave EC", token.Method.Alg())
+		}
+	}
+	return chave, &v.remoto.emissor, nil
+}
+
+func (v *TokenVerifier) chaveLocal(kid string) (*Chave, bool) {
+	if v.keySet == nil {
+		return nil, false
+	}
+	return v.keySet.chave(kid)
+}
@@ -14,12 +14,17 @@ import (
 	"github.com/yurisasc/algafood-go/internal/config"
 )
 
+// defaultLocalDirectory is used when no local directory is configured
+const defaultLocalDirectory = "./uploads"
+
 // StorageService interface for file storage operations
 type StorageService interface {
 	Store(filename string, contentType string, content io.Reader) (string, error)
 	Retrieve(filename string) (io.ReadCloser, error)
 	Delete(filename string) error
 	GetURL(filename string) string
+	// IsRemote indica se os arquivos podem ser servidos diretamente pela URL de GetURL
+	IsRemote() bool
 }
 
 // NewStorageService creates a new storage service based on configuration
@@ -39,11 +44,16 @@ type LocalStorageService struct {
 
 // NewLocalStorageService creates a new local storage service
 func NewLocalStorageService(cfg *config.StorageConfig) (*LocalStorageService, error) {
+	directory := cfg.Local.Directory
+	if directory == "" {
+		directory = defaultLocalDirectory
+	}
+
 	// Create directory if not exists
-	if err := os.MkdirAll(cfg.Local.Directory, 0755); err != nil {
+	if err := os.MkdirAll(directory, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create storage directory: %w", err)
 	}
-	return &LocalStorageService{directory: cfg.Local.Directory}, nil
+	return &LocalStorageService{directory: directory}, nil
 }
 
 func (s *LocalStorageService) Store(filename string, contentType string, content io.Reader) (string, error) {
@@ -86,6 +96,10 @@ func (s *LocalStorageService) GetURL(filename string) string {
 	return filepath.Join(s.directory, filename)
 }
 
+func (s *LocalStorageService) IsRemote() bool {
+	return false
+}
+
 // S3StorageService stores files in AWS S3
 type S3StorageService struct {
 	client    *s3.Client
@@ -157,6 +171,10 @@ func (s *S3StorageService) GetURL(filename string) string {
 	return fmt.Sprintf("https://%s.s3.amazonaws.com/%s", s.bucket, s.getKey(filename))
 }
 
+func (s *S3StorageService) IsRemote() bool {
+	return true
+}
+
 func (s *S3StorageService) getKey(filename string) string {
 	if s.directory != "" {
 		return s.directory + "/" + filename
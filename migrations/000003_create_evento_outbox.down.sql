@@ -0,0 +1 @@
+DROP TABLE IF EXISTS evento_outbox;
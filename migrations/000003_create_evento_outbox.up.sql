@@ -0,0 +1,15 @@
+-- Outbox de eventos de dominio (gravado na mesma transacao do pedido)
+CREATE TABLE IF NOT EXISTS evento_outbox (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tipo VARCHAR(60) NOT NULL,
+    payload TEXT NOT NULL,
+    status VARCHAR(15) NOT NULL DEFAULT 'PENDENTE',
+    tentativas INT NOT NULL DEFAULT 0,
+    ultimo_erro VARCHAR(1000),
+    data_ocorrencia DATETIME NOT NULL,
+    data_criacao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    proxima_tentativa DATETIME NOT NULL,
+    data_publicacao DATETIME
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_evento_outbox_status_proxima ON evento_outbox(status, proxima_tentativa);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS restaurante_excecao_horario;
+DROP TABLE IF EXISTS restaurante_horario_funcionamento;
+ALTER TABLE restaurante DROP COLUMN fuso_horario;
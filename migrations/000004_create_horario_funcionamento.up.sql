@@ -0,0 +1,27 @@
+-- Fuso horario usado pela agenda de funcionamento do restaurante
+ALTER TABLE restaurante ADD COLUMN fuso_horario VARCHAR(60) NOT NULL DEFAULT 'America/Sao_Paulo' AFTER aberto;
+
+-- Horarios de funcionamento semanais (varios intervalos por dia)
+CREATE TABLE IF NOT EXISTS restaurante_horario_funcionamento (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    restaurante_id BIGINT NOT NULL,
+    dia_semana VARCHAR(10) NOT NULL,
+    hora_abertura CHAR(5) NOT NULL,
+    hora_fechamento CHAR(5) NOT NULL,
+    CONSTRAINT fk_horario_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_horario_restaurante_dia ON restaurante_horario_funcionamento(restaurante_id, dia_semana);
+
+-- Excecoes de horario (feriados, datas especiais)
+CREATE TABLE IF NOT EXISTS restaurante_excecao_horario (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    restaurante_id BIGINT NOT NULL,
+    data CHAR(10) NOT NULL,
+    descricao VARCHAR(80),
+    fechado BOOLEAN NOT NULL DEFAULT FALSE,
+    hora_abertura CHAR(5),
+    hora_fechamento CHAR(5),
+    CONSTRAINT uk_excecao_restaurante_data UNIQUE (restaurante_id, data),
+    CONSTRAINT fk_excecao_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS restaurante_cidade_entrega;
+ALTER TABLE restaurante DROP COLUMN valor_minimo_pedido;
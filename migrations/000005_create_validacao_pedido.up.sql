@@ -0,0 +1,11 @@
+-- Valor minimo de pedido por restaurante (0 = sem minimo)
+ALTER TABLE restaurante ADD COLUMN valor_minimo_pedido DECIMAL(10,2) NOT NULL DEFAULT 0 AFTER taxa_frete;
+
+-- Area de entrega do restaurante (sem registros, entrega apenas na cidade do restaurante)
+CREATE TABLE IF NOT EXISTS restaurante_cidade_entrega (
+    restaurante_id BIGINT NOT NULL,
+    cidade_id BIGINT NOT NULL,
+    PRIMARY KEY (restaurante_id, cidade_id),
+    CONSTRAINT fk_rce_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id),
+    CONSTRAINT fk_rce_cidade FOREIGN KEY (cidade_id) REFERENCES cidade(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
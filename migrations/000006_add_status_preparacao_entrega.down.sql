@@ -0,0 +1,6 @@
+-- Pedidos nos novos status voltam para CONFIRMADO antes de reduzir a coluna
+UPDATE pedido SET status = 'CONFIRMADO' WHERE status IN ('PREPARANDO', 'SAIU_PARA_ENTREGA');
+
+ALTER TABLE pedido DROP COLUMN data_saida_entrega;
+ALTER TABLE pedido DROP COLUMN data_preparacao;
+ALTER TABLE pedido MODIFY COLUMN status VARCHAR(15) NOT NULL DEFAULT 'CRIADO';
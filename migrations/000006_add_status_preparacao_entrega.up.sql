@@ -0,0 +1,5 @@
+-- Novos status intermediarios: PREPARANDO e SAIU_PARA_ENTREGA (17 caracteres)
+ALTER TABLE pedido MODIFY COLUMN status VARCHAR(20) NOT NULL DEFAULT 'CRIADO';
+
+ALTER TABLE pedido ADD COLUMN data_preparacao DATETIME NULL AFTER data_confirmacao;
+ALTER TABLE pedido ADD COLUMN data_saida_entrega DATETIME NULL AFTER data_preparacao;
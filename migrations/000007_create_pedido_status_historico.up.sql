@@ -0,0 +1,49 @@
+-- Historico de mudancas de status dos pedidos (trilha de auditoria)
+CREATE TABLE IF NOT EXISTS pedido_status_historico (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pedido_id BIGINT NOT NULL,
+    status_anterior VARCHAR(20),
+    status_novo VARCHAR(20) NOT NULL,
+    usuario_id BIGINT,
+    motivo VARCHAR(255),
+    data_alteracao DATETIME NOT NULL,
+    CONSTRAINT fk_psh_pedido FOREIGN KEY (pedido_id) REFERENCES pedido(id),
+    CONSTRAINT fk_psh_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_psh_pedido_data ON pedido_status_historico(pedido_id, data_alteracao);
+
+-- Reconstroi o historico dos pedidos existentes a partir das datas de cada status.
+-- O responsavel pelas transicoes antigas e desconhecido (usuario_id NULL).
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, usuario_id, data_alteracao)
+SELECT id, NULL, 'CRIADO', usuario_cliente_id, data_criacao FROM pedido;
+
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, data_alteracao)
+SELECT id, 'CRIADO', 'CONFIRMADO', data_confirmacao FROM pedido WHERE data_confirmacao IS NOT NULL;
+
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, data_alteracao)
+SELECT id, 'CONFIRMADO', 'PREPARANDO', data_preparacao FROM pedido WHERE data_preparacao IS NOT NULL;
+
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, data_alteracao)
+SELECT id, IF(data_preparacao IS NULL, 'CONFIRMADO', 'PREPARANDO'), 'SAIU_PARA_ENTREGA', data_saida_entrega
+FROM pedido WHERE data_saida_entrega IS NOT NULL;
+
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, data_alteracao)
+SELECT id,
+       CASE
+           WHEN data_saida_entrega IS NOT NULL THEN 'SAIU_PARA_ENTREGA'
+           WHEN data_preparacao IS NOT NULL THEN 'PREPARANDO'
+           ELSE 'CONFIRMADO'
+       END,
+       'ENTREGUE', data_entrega
+FROM pedido WHERE data_entrega IS NOT NULL;
+
+INSERT INTO pedido_status_historico (pedido_id, status_anterior, status_novo, data_alteracao)
+SELECT id,
+       CASE
+           WHEN data_preparacao IS NOT NULL THEN 'PREPARANDO'
+           WHEN data_confirmacao IS NOT NULL THEN 'CONFIRMADO'
+           ELSE 'CRIADO'
+       END,
+       'CANCELADO', data_cancelamento
+FROM pedido WHERE data_cancelamento IS NOT NULL;
@@ -0,0 +1,15 @@
+-- Refresh tokens rotativos (apenas o hash SHA-256 e armazenado)
+CREATE TABLE IF NOT EXISTS refresh_token (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    familia VARCHAR(36) NOT NULL,
+    usuario_id BIGINT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    data_criacao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_expiracao DATETIME NOT NULL,
+    data_uso DATETIME,
+    data_revogacao DATETIME,
+    CONSTRAINT uk_refresh_token_hash UNIQUE (token_hash),
+    CONSTRAINT fk_refresh_token_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_refresh_token_familia ON refresh_token(familia);
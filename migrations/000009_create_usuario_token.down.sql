@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS usuario_token;
+
+ALTER TABLE usuario
+    DROP COLUMN data_verificacao_email,
+    DROP COLUMN email_verificado;
@@ -0,0 +1,21 @@
+-- Verificacao de e-mail. Usuarios ja cadastrados sao considerados verificados.
+ALTER TABLE usuario
+    ADD COLUMN email_verificado TINYINT(1) NOT NULL DEFAULT 0,
+    ADD COLUMN data_verificacao_email DATETIME;
+
+UPDATE usuario SET email_verificado = 1, data_verificacao_email = data_cadastro;
+
+-- Tokens de uso unico enviados por e-mail (apenas o hash SHA-256 e armazenado)
+CREATE TABLE IF NOT EXISTS usuario_token (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    tipo VARCHAR(20) NOT NULL,
+    usuario_id BIGINT NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    data_criacao DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    data_expiracao DATETIME NOT NULL,
+    data_uso DATETIME,
+    CONSTRAINT uk_usuario_token_hash UNIQUE (token_hash),
+    CONSTRAINT fk_usuario_token_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE INDEX idx_usuario_token_usuario_tipo ON usuario_token(usuario_id, tipo);
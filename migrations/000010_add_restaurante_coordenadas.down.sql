@@ -0,0 +1,5 @@
+DROP INDEX idx_restaurante_coordenadas ON restaurante;
+
+ALTER TABLE restaurante
+    DROP COLUMN endereco_longitude,
+    DROP COLUMN endereco_latitude;
@@ -0,0 +1,6 @@
+-- Coordenadas opcionais do endereco do restaurante, usadas na busca por raio
+ALTER TABLE restaurante
+    ADD COLUMN endereco_latitude DECIMAL(10,7),
+    ADD COLUMN endereco_longitude DECIMAL(10,7);
+
+CREATE INDEX idx_restaurante_coordenadas ON restaurante(endereco_latitude, endereco_longitude);
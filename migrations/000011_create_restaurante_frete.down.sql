@@ -0,0 +1,9 @@
+ALTER TABLE pedido
+    DROP COLUMN endereco_longitude,
+    DROP COLUMN endereco_latitude,
+    DROP COLUMN descricao_frete,
+    DROP COLUMN regra_frete;
+
+DROP TABLE IF EXISTS restaurante_frete_faixa;
+DROP TABLE IF EXISTS restaurante_frete_localidade;
+DROP TABLE IF EXISTS restaurante_frete;
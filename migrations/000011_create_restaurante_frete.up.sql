@@ -0,0 +1,38 @@
+-- Estrategia de frete por restaurante. Restaurantes sem configuracao usam a taxa fixa.
+CREATE TABLE IF NOT EXISTS restaurante_frete (
+    restaurante_id BIGINT PRIMARY KEY,
+    tipo VARCHAR(20) NOT NULL,
+    frete_gratis_acima DECIMAL(10,2),
+    CONSTRAINT fk_restaurante_frete_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Tabela por cidade ou bairro (bairro vazio vale para a cidade inteira)
+CREATE TABLE IF NOT EXISTS restaurante_frete_localidade (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    restaurante_id BIGINT NOT NULL,
+    cidade_id BIGINT NOT NULL,
+    bairro VARCHAR(60) NOT NULL DEFAULT '',
+    taxa DECIMAL(10,2) NOT NULL,
+    CONSTRAINT uk_restaurante_frete_localidade UNIQUE (restaurante_id, cidade_id, bairro),
+    CONSTRAINT fk_restaurante_frete_localidade_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id),
+    CONSTRAINT fk_restaurante_frete_localidade_cidade FOREIGN KEY (cidade_id) REFERENCES cidade(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Faixas de distancia
+CREATE TABLE IF NOT EXISTS restaurante_frete_faixa (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    restaurante_id BIGINT NOT NULL,
+    distancia_max_km DECIMAL(6,2) NOT NULL,
+    taxa DECIMAL(10,2) NOT NULL,
+    CONSTRAINT uk_restaurante_frete_faixa UNIQUE (restaurante_id, distancia_max_km),
+    CONSTRAINT fk_restaurante_frete_faixa_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Regra aplicada no pedido (auditoria) e coordenadas da entrega para o frete por distancia
+ALTER TABLE pedido
+    ADD COLUMN regra_frete VARCHAR(20) NOT NULL DEFAULT '',
+    ADD COLUMN descricao_frete VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN endereco_latitude DECIMAL(10,7),
+    ADD COLUMN endereco_longitude DECIMAL(10,7);
+
+UPDATE pedido SET regra_frete = 'FIXO', descricao_frete = 'Taxa de entrega fixa do restaurante';
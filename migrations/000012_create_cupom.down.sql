@@ -0,0 +1,11 @@
+DELETE FROM grupo_permissao WHERE permissao_id = 18;
+DELETE FROM permissao WHERE id = 18;
+
+ALTER TABLE pedido
+    DROP FOREIGN KEY fk_pedido_cupom,
+    DROP COLUMN codigo_cupom,
+    DROP COLUMN cupom_id,
+    DROP COLUMN desconto;
+
+DROP TABLE IF EXISTS cupom_uso;
+DROP TABLE IF EXISTS cupom;
@@ -0,0 +1,46 @@
+-- Cupons de desconto (sem restaurante o cupom vale para todos)
+CREATE TABLE IF NOT EXISTS cupom (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    codigo VARCHAR(30) NOT NULL,
+    descricao VARCHAR(255),
+    tipo VARCHAR(20) NOT NULL,
+    valor DECIMAL(10,2) NOT NULL,
+    restaurante_id BIGINT,
+    valor_minimo_pedido DECIMAL(10,2),
+    valido_de DATETIME,
+    valido_ate DATETIME,
+    limite_usos INT,
+    limite_usos_por_cliente INT,
+    ativo TINYINT(1) NOT NULL DEFAULT 1,
+    data_cadastro DATETIME NOT NULL,
+    data_atualizacao DATETIME NOT NULL,
+    CONSTRAINT uk_cupom_codigo UNIQUE (codigo),
+    CONSTRAINT fk_cupom_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Usos dos cupons; os limites contam apenas pedidos nao cancelados
+CREATE TABLE IF NOT EXISTS cupom_uso (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    cupom_id BIGINT NOT NULL,
+    pedido_id BIGINT NOT NULL,
+    usuario_cliente_id BIGINT NOT NULL,
+    data_uso DATETIME NOT NULL,
+    CONSTRAINT uk_cupom_uso_pedido UNIQUE (pedido_id),
+    INDEX idx_cupom_uso_cliente (cupom_id, usuario_cliente_id),
+    CONSTRAINT fk_cupom_uso_cupom FOREIGN KEY (cupom_id) REFERENCES cupom(id),
+    CONSTRAINT fk_cupom_uso_pedido FOREIGN KEY (pedido_id) REFERENCES pedido(id),
+    CONSTRAINT fk_cupom_uso_usuario FOREIGN KEY (usuario_cliente_id) REFERENCES usuario(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Desconto concedido no pedido
+ALTER TABLE pedido
+    ADD COLUMN desconto DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ADD COLUMN cupom_id BIGINT,
+    ADD COLUMN codigo_cupom VARCHAR(30) NOT NULL DEFAULT '',
+    ADD CONSTRAINT fk_pedido_cupom FOREIGN KEY (cupom_id) REFERENCES cupom(id);
+
+-- Permissao para gerenciar cupons, concedida ao grupo Gerente
+INSERT INTO permissao (id, nome, descricao) VALUES
+(18, 'EDITAR_CUPONS', 'Permite criar ou editar cupons de desconto');
+
+INSERT INTO grupo_permissao (grupo_id, permissao_id) VALUES (1, 18);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS item_pedido_opcao;
+DROP TABLE IF EXISTS produto_opcao;
+DROP TABLE IF EXISTS produto_grupo_opcao;
@@ -0,0 +1,29 @@
+-- Grupos de opcoes dos produtos (tamanho, adicionais...)
+CREATE TABLE IF NOT EXISTS produto_grupo_opcao (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    produto_id BIGINT NOT NULL,
+    nome VARCHAR(60) NOT NULL,
+    min_selecoes INT NOT NULL DEFAULT 0,
+    max_selecoes INT NOT NULL DEFAULT 1,
+    CONSTRAINT fk_produto_grupo_opcao_produto FOREIGN KEY (produto_id) REFERENCES produto(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS produto_opcao (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    grupo_opcao_id BIGINT NOT NULL,
+    nome VARCHAR(60) NOT NULL,
+    preco_adicional DECIMAL(10,2) NOT NULL DEFAULT 0,
+    ativo TINYINT(1) NOT NULL DEFAULT 1,
+    CONSTRAINT fk_produto_opcao_grupo FOREIGN KEY (grupo_opcao_id) REFERENCES produto_grupo_opcao(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Copia das opcoes escolhidas em cada item; sem FK para a opcao, que pode ser removida do cardapio
+CREATE TABLE IF NOT EXISTS item_pedido_opcao (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    item_pedido_id BIGINT NOT NULL,
+    opcao_id BIGINT NOT NULL,
+    grupo_nome VARCHAR(60) NOT NULL,
+    nome VARCHAR(60) NOT NULL,
+    preco_adicional DECIMAL(10,2) NOT NULL,
+    CONSTRAINT fk_item_pedido_opcao_item FOREIGN KEY (item_pedido_id) REFERENCES item_pedido(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,17 @@
+-- Tentativas de pagamento dos pedidos no provedor configurado
+CREATE TABLE IF NOT EXISTS pagamento (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pedido_id BIGINT NOT NULL,
+    provedor VARCHAR(30) NOT NULL,
+    transacao_id VARCHAR(100) NOT NULL,
+    valor DECIMAL(10,2) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    mensagem VARCHAR(255),
+    data_criacao DATETIME NOT NULL,
+    data_atualizacao DATETIME NOT NULL,
+    data_captura DATETIME,
+    data_estorno DATETIME,
+    CONSTRAINT uk_pagamento_transacao UNIQUE (provedor, transacao_id),
+    INDEX idx_pagamento_pedido (pedido_id),
+    CONSTRAINT fk_pagamento_pedido FOREIGN KEY (pedido_id) REFERENCES pedido(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
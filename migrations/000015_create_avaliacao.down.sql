@@ -0,0 +1,5 @@
+ALTER TABLE restaurante
+    DROP COLUMN avaliacao_media,
+    DROP COLUMN total_avaliacoes;
+
+DROP TABLE IF EXISTS avaliacao;
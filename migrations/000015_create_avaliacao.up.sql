@@ -0,0 +1,23 @@
+-- Avaliacoes dos pedidos entregues (uma por pedido), com resposta do restaurante
+CREATE TABLE IF NOT EXISTS avaliacao (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pedido_id BIGINT NOT NULL,
+    restaurante_id BIGINT NOT NULL,
+    usuario_cliente_id BIGINT NOT NULL,
+    nota TINYINT NOT NULL,
+    comentario VARCHAR(500),
+    resposta VARCHAR(500),
+    data_resposta DATETIME,
+    data_criacao DATETIME NOT NULL,
+    CONSTRAINT uk_avaliacao_pedido UNIQUE (pedido_id),
+    INDEX idx_avaliacao_restaurante (restaurante_id, data_criacao),
+    CONSTRAINT fk_avaliacao_pedido FOREIGN KEY (pedido_id) REFERENCES pedido(id),
+    CONSTRAINT fk_avaliacao_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id),
+    CONSTRAINT fk_avaliacao_usuario FOREIGN KEY (usuario_cliente_id) REFERENCES usuario(id),
+    CONSTRAINT ck_avaliacao_nota CHECK (nota BETWEEN 1 AND 5)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Media e total de avaliacoes, mantidos junto com cada nova avaliacao
+ALTER TABLE restaurante
+    ADD COLUMN avaliacao_media DECIMAL(3,2) NOT NULL DEFAULT 0,
+    ADD COLUMN total_avaliacoes INT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS usuario_produto_favorito;
+DROP TABLE IF EXISTS usuario_restaurante_favorito;
+DROP TABLE IF EXISTS usuario_endereco;
@@ -0,0 +1,39 @@
+-- Enderecos salvos pelos clientes (um padrao por usuario). Os pedidos copiam o endereco.
+CREATE TABLE IF NOT EXISTS usuario_endereco (
+    id BIGINT AUTO_INCREMENT PRIMARY KEY,
+    usuario_id BIGINT NOT NULL,
+    apelido VARCHAR(40) NOT NULL,
+    endereco_cep VARCHAR(9),
+    endereco_logradouro VARCHAR(100),
+    endereco_numero VARCHAR(20),
+    endereco_complemento VARCHAR(60),
+    endereco_bairro VARCHAR(60),
+    endereco_cidade_id BIGINT,
+    endereco_latitude DECIMAL(10,7),
+    endereco_longitude DECIMAL(10,7),
+    padrao TINYINT(1) NOT NULL DEFAULT 0,
+    data_cadastro DATETIME NOT NULL,
+    data_atualizacao DATETIME NOT NULL,
+    INDEX idx_usuario_endereco_usuario (usuario_id),
+    CONSTRAINT fk_usuario_endereco_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id),
+    CONSTRAINT fk_usuario_endereco_cidade FOREIGN KEY (endereco_cidade_id) REFERENCES cidade(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+-- Restaurantes e produtos favoritos dos clientes
+CREATE TABLE IF NOT EXISTS usuario_restaurante_favorito (
+    usuario_id BIGINT NOT NULL,
+    restaurante_id BIGINT NOT NULL,
+    data_cadastro DATETIME NOT NULL,
+    PRIMARY KEY (usuario_id, restaurante_id),
+    CONSTRAINT fk_restaurante_favorito_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id),
+    CONSTRAINT fk_restaurante_favorito_restaurante FOREIGN KEY (restaurante_id) REFERENCES restaurante(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS usuario_produto_favorito (
+    usuario_id BIGINT NOT NULL,
+    produto_id BIGINT NOT NULL,
+    data_cadastro DATETIME NOT NULL,
+    PRIMARY KEY (usuario_id, produto_id),
+    CONSTRAINT fk_produto_favorito_usuario FOREIGN KEY (usuario_id) REFERENCES usuario(id),
+    CONSTRAINT fk_produto_favorito_produto FOREIGN KEY (produto_id) REFERENCES produto(id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
@@ -0,0 +1,3 @@
+ALTER TABLE pagamento
+    DROP INDEX uk_pagamento_pedido_ativo,
+    DROP COLUMN pedido_ativo_id;
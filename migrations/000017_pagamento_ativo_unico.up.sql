@@ -0,0 +1,5 @@
+-- Um unico pagamento ativo (pendente, autorizado ou capturado) por pedido. A coluna gerada
+-- fica NULL nas tentativas encerradas, que podem se repetir no mesmo pedido.
+ALTER TABLE pagamento
+    ADD COLUMN pedido_ativo_id BIGINT AS (CASE WHEN status IN ('PENDENTE', 'AUTORIZADO', 'CAPTURADO') THEN pedido_id END) STORED,
+    ADD CONSTRAINT uk_pagamento_pedido_ativo UNIQUE (pedido_ativo_id);
@@ -0,0 +1,2 @@
+ALTER TABLE restaurante
+    DROP COLUMN agenda_aberto;
@@ -0,0 +1,4 @@
+-- Ultima situacao (aberto/fechado) aplicada pela agenda de funcionamento. Fica NULL
+-- enquanto a agenda nao atuou no restaurante, que segue sob controle manual.
+ALTER TABLE restaurante
+    ADD COLUMN agenda_aberto TINYINT(1) NULL;
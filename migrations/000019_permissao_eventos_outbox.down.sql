@@ -0,0 +1,2 @@
+DELETE FROM grupo_permissao WHERE permissao_id = 19;
+DELETE FROM permissao WHERE id = 19;
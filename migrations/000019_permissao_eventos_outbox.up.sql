@@ -0,0 +1,5 @@
+-- Permissao propria para consultar e reprocessar o outbox de eventos
+INSERT INTO permissao (id, nome, descricao) VALUES
+(19, 'GERENCIAR_EVENTOS_OUTBOX', 'Permite consultar e reprocessar eventos do outbox');
+
+INSERT INTO grupo_permissao (grupo_id, permissao_id) VALUES (1, 19);
@@ -0,0 +1,8 @@
+// Package migrations embute os scripts SQL no binário. Os arquivos seguem a convenção
+// do golang-migrate: <versão>_<nome>.up.sql e <versão>_<nome>.down.sql.
+package migrations
+
+import "embed"
+
+//go:embed *.sql
+var FS embed.FS
@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// CursorInvalidoError is returned when the cursor cannot be decoded or was issued for
+// a different sort
+type CursorInvalidoError struct {
+	Message string
+}
+
+func (e *CursorInvalidoError) Error() string {
+	return e.Message
+}
+
+// cursor is the opaque position sent to clients: the sort it belongs to and the
+// sort values of the last element of the page
+type cursor struct {
+	Ordenacao string `json:"o"`
+	Valores   []any  `json:"v"`
+}
+
+// NovoCursor encodes the position after an element with the given sort values
+// (one value per criterion of the Ordenacao, in order)
+func (o Ordenacao) NovoCursor(valores []any) string {
+	data, _ := json.Marshal(cursor{Ordenacao: o.String(), Valores: valores})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// CondicaoApos returns the WHERE clause selecting the rows after the cursor:
+// (c1 > v1) OR (c1 = v1 AND c2 > v2) OR ..., with < for descending criteria.
+// An empty cursor returns an empty clause (first page).
+func (o Ordenacao) CondicaoApos(valor string) (string, []any, error) {
+	if valor == "" {
+		return "", nil, nil
+	}
+
+	invalido := &CursorInvalidoError{Message: "O cursor informado e invalido ou nao corresponde a ordenacao solicitada"}
+
+	data, err := base64.RawURLEncoding.DecodeString(valor)
+	if err != nil {
+		return "", nil, invalido
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var c cursor
+	if err := decoder.Decode(&c); err != nil {
+		return "", nil, invalido
+	}
+	if c.Ordenacao != o.String() || len(c.Valores) != len(o) {
+		return "", nil, invalido
+	}
+	for _, v := range c.Valores {
+		switch v.(type) {
+		case string, json.Number:
+		default:
+			return "", nil, invalido
+		}
+	}
+
+	var alternativas []string
+	var args []any
+	for i, ordem := range o {
+		var partes []string
+		for j := 0; j < i; j++ {
+			partes = append(partes, o[j].Coluna+" = ?")
+			args = append(args, c.Valores[j])
+		}
+		operador := " > ?"
+		if ordem.Desc {
+			operador = " < ?"
+		}
+		partes = append(partes, ordem.Coluna+operador)
+		args = append(args, c.Valores[i])
+		alternativas = append(alternativas, "("+strings.Join(partes, " AND ")+")")
+	}
+
+	return strings.Join(alternativas, " OR "), args, nil
+}
+
+// CursorPage is a page of a keyset (cursor) search. NextCursor is empty on the last page.
+type CursorPage[T any] struct {
+	Content          []T    `json:"content"`
+	Size             int    `json:"size"`
+	NumberOfElements int    `json:"numberOfElements"`
+	NextCursor       string `json:"nextCursor,omitempty"`
+	Empty            bool   `json:"empty"`
+}
+
+// NewCursorPage creates the page from up to Size+1 rows: the extra row only signals
+// that there is a next page. valores returns the sort values of an element.
+func NewCursorPage[T any](rows []T, pageable *Pageable, ordenacao Ordenacao, valores func(*T) []any) *CursorPage[T] {
+	page := &CursorPage[T]{Size: pageable.Size}
+	if len(rows) > pageable.Size {
+		rows = rows[:pageable.Size]
+		page.NextCursor = ordenacao.NovoCursor(valores(&rows[len(rows)-1]))
+	}
+	page.Content = rows
+	page.NumberOfElements = len(rows)
+	page.Empty = len(rows) == 0
+	return page
+}
+
+// MapCursorPage converts the content of a cursor page, keeping the position info
+func MapCursorPage[T, U any](page *CursorPage[T], content []U) CursorPage[U] {
+	return CursorPage[U]{
+		Content:          content,
+		Size:             page.Size,
+		NumberOfElements: page.NumberOfElements,
+		NextCursor:       page.NextCursor,
+		Empty:            page.Empty,
+	}
+}
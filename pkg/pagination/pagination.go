@@ -2,15 +2,21 @@ package pagination
 
 import (
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+// semLimite no Size desativa o LIMIT e o OFFSET (-1 cancela ambos no GORM)
+const semLimite = -1
+
 // Pageable represents pagination parameters
 type Pageable struct {
 	Page int    `json:"page"`
 	Size int    `json:"size"`
 	Sort string `json:"sort"`
+	// Cursor ativa a paginação por keyset quando não é nil ("" é a primeira página)
+	Cursor *string `json:"cursor,omitempty"`
 }
 
 // DefaultPageable creates a default pageable
@@ -26,7 +32,8 @@ func DefaultPageable() *Pageable {
 func NewPageableFromContext(c *gin.Context) *Pageable {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "0"))
 	size, _ := strconv.Atoi(c.DefaultQuery("size", "10"))
-	sort := c.DefaultQuery("sort", "")
+	// sort pode ser repetido: ?sort=nome,asc&sort=id,desc
+	sort := strings.Join(c.QueryArray("sort"), ";")
 
 	if page < 0 {
 		page = 0
@@ -35,11 +42,39 @@ func NewPageableFromContext(c *gin.Context) *Pageable {
 		size = 10
 	}
 
-	return &Pageable{
+	pageable := &Pageable{
 		Page: page,
 		Size: size,
 		Sort: sort,
 	}
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		pageable.Cursor = &cursor
+	}
+	return pageable
+}
+
+// NewPageableOpcionalFromContext é usado nas listagens que retornavam arrays antes de serem
+// paginadas: sem page nem size na requisição, a listagem continua trazendo todos os registros
+// (ainda ordenados por sort), e o handler responde com o array em vez da Page.
+func NewPageableOpcionalFromContext(c *gin.Context) *Pageable {
+	pageable := NewPageableFromContext(c)
+	_, temPage := c.GetQuery("page")
+	_, temSize := c.GetQuery("size")
+	if !temPage && !temSize {
+		pageable.Page = 0
+		pageable.Size = semLimite
+	}
+	return pageable
+}
+
+// Paginado indica se a consulta é limitada a uma página
+func (p *Pageable) Paginado() bool {
+	return p.Size > 0
+}
+
+// Keyset indica se a consulta deve usar cursor em vez de OFFSET
+func (p *Pageable) Keyset() bool {
+	return p.Cursor != nil
 }
 
 // Offset returns the offset for database queries
@@ -62,9 +97,12 @@ type Page[T any] struct {
 
 // NewPage creates a new Page with the given content and pagination info
 func NewPage[T any](content []T, totalElements int64, pageable *Pageable) *Page[T] {
-	totalPages := int(totalElements) / pageable.Size
-	if int(totalElements)%pageable.Size > 0 {
-		totalPages++
+	totalPages := 1
+	if pageable.Paginado() {
+		totalPages = int(totalElements) / pageable.Size
+		if int(totalElements)%pageable.Size > 0 {
+			totalPages++
+		}
 	}
 
 	return &Page[T]{
@@ -79,3 +117,18 @@ func NewPage[T any](content []T, totalElements int64, pageable *Pageable) *Page[
 		Empty:            len(content) == 0,
 	}
 }
+
+// MapPage converts the content of a page (e.g. entities to DTOs), keeping the paging info
+func MapPage[T, U any](page *Page[T], content []U) Page[U] {
+	return Page[U]{
+		Content:          content,
+		TotalElements:    page.TotalElements,
+		TotalPages:       page.TotalPages,
+		Size:             page.Size,
+		Number:           page.Number,
+		NumberOfElements: page.NumberOfElements,
+		First:            page.First,
+		Last:             page.Last,
+		Empty:            page.Empty,
+	}
+}
@@ -0,0 +1,141 @@
+package pagination
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CamposOrdenacao maps the sortable API fields of an entity to their columns.
+// Only fields in the map can be used in the sort parameter.
+type CamposOrdenacao map[string]string
+
+// Ordem is one sort criterion already resolved to a column
+type Ordem struct {
+	Campo  string
+	Coluna string
+	Desc   bool
+}
+
+// Ordenacao is the resolved sort, always including the entity's unique key so
+// that the order is deterministic (required by keyset pagination)
+type Ordenacao []Ordem
+
+// OrdenacaoInvalidaError is returned when the sort parameter is malformed or uses a field
+// outside the allow-list
+type OrdenacaoInvalidaError struct {
+	Message string
+}
+
+func (e *OrdenacaoInvalidaError) Error() string {
+	return e.Message
+}
+
+// Ordenacao resolves the sort parameter ("campo,asc|desc", several criteria separated
+// by ";") against the allow-list. padrao is used when no sort was requested and
+// chave is the unique column appended as tie-breaker when the sort does not use it.
+func (p *Pageable) Ordenacao(campos CamposOrdenacao, padrao, chave string) (Ordenacao, error) {
+	param := p.Sort
+	if param == "" {
+		param = padrao
+	}
+
+	var ordenacao Ordenacao
+	temChave := false
+	for _, criterio := range strings.Split(param, ";") {
+		criterio = strings.TrimSpace(criterio)
+		if criterio == "" {
+			continue
+		}
+
+		campo, direcao, _ := strings.Cut(criterio, ",")
+		campo = strings.TrimSpace(campo)
+		coluna, ok := campos[campo]
+		if !ok {
+			return nil, &OrdenacaoInvalidaError{
+				Message: fmt.Sprintf("O campo '%s' nao pode ser usado na ordenacao. Campos permitidos: %s", campo, campos.nomes()),
+			}
+		}
+
+		ordem := Ordem{Campo: campo, Coluna: coluna}
+		switch strings.ToLower(strings.TrimSpace(direcao)) {
+		case "", "asc":
+		case "desc":
+			ordem.Desc = true
+		default:
+			return nil, &OrdenacaoInvalidaError{
+				Message: fmt.Sprintf("A direcao de ordenacao '%s' e invalida. Use asc ou desc", direcao),
+			}
+		}
+
+		if coluna == chave {
+			temChave = true
+		}
+		ordenacao = append(ordenacao, ordem)
+	}
+	if temChave {
+		return ordenacao, nil
+	}
+
+	// Desempate pela chave, na mesma direção do último critério
+	desempate := Ordem{Campo: campos.campo(chave), Coluna: chave}
+	if len(ordenacao) > 0 {
+		desempate.Desc = ordenacao[len(ordenacao)-1].Desc
+	}
+	return append(ordenacao, desempate), nil
+}
+
+// SQL returns the ORDER BY clause. Columns come from the allow-list, never from the request.
+func (o Ordenacao) SQL() string {
+	partes := make([]string, len(o))
+	for i, ordem := range o {
+		partes[i] = ordem.Coluna
+		if ordem.Desc {
+			partes[i] += " DESC"
+		} else {
+			partes[i] += " ASC"
+		}
+	}
+	return strings.Join(partes, ", ")
+}
+
+// String returns the normalized sort, e.g. "dataCriacao,desc;id,desc"
+func (o Ordenacao) String() string {
+	partes := make([]string, len(o))
+	for i, ordem := range o {
+		direcao := "asc"
+		if ordem.Desc {
+			direcao = "desc"
+		}
+		nome := ordem.Campo
+		if nome == "" {
+			nome = ordem.Coluna
+		}
+		partes[i] = nome + "," + direcao
+	}
+	return strings.Join(partes, ";")
+}
+
+func (c CamposOrdenacao) nomes() string {
+	return strings.Join(c.ordenados(), ", ")
+}
+
+// campo returns the first field (in alphabetical order) mapped to the column, so that
+// the result does not depend on map iteration
+func (c CamposOrdenacao) campo(coluna string) string {
+	for _, campo := range c.ordenados() {
+		if c[campo] == coluna {
+			return campo
+		}
+	}
+	return ""
+}
+
+func (c CamposOrdenacao) ordenados() []string {
+	nomes := make([]string, 0, len(c))
+	for campo := range c {
+		nomes = append(nomes, campo)
+	}
+	sort.Strings(nomes)
+	return nomes
+}
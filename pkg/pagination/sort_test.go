@@ -0,0 +1,70 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPageableOrdenacao(t *testing.T) {
+	campos := CamposOrdenacao{
+		"codigo":      "codigo",
+		"dataCriacao": "data_criacao",
+		"id":          "id",
+		"idPedido":    "id",
+	}
+
+	tests := []struct {
+		name    string
+		sort    string
+		want    string
+		wantSQL string
+		wantErr bool
+	}{
+		{name: "padrao", sort: "", want: "dataCriacao,desc;id,desc", wantSQL: "data_criacao DESC, id DESC"},
+		{name: "desempate na direcao do ultimo criterio", sort: "codigo,asc", want: "codigo,asc;id,asc", wantSQL: "codigo ASC, id ASC"},
+		{name: "direcao omitida e ascendente", sort: "codigo", want: "codigo,asc;id,asc", wantSQL: "codigo ASC, id ASC"},
+		{name: "mantem a chave pedida", sort: "id,desc", want: "id,desc", wantSQL: "id DESC"},
+		{name: "chave no meio nao e repetida", sort: "id,desc;codigo,asc", want: "id,desc;codigo,asc", wantSQL: "id DESC, codigo ASC"},
+		{name: "alias da chave", sort: "idPedido,desc", want: "idPedido,desc", wantSQL: "id DESC"},
+		{name: "varios criterios", sort: " codigo , DESC ; dataCriacao,asc ", want: "codigo,desc;dataCriacao,asc;id,asc", wantSQL: "codigo DESC, data_criacao ASC, id ASC"},
+		{name: "campo fora da lista", sort: "senha,asc", wantErr: true},
+		{name: "coluna em vez do campo", sort: "data_criacao,asc", wantErr: true},
+		{name: "direcao invalida", sort: "codigo,up", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Pageable{Sort: tt.sort}
+			ordenacao, err := p.Ordenacao(campos, "dataCriacao,desc", "id")
+			if tt.wantErr {
+				var invalida *OrdenacaoInvalidaError
+				if !errors.As(err, &invalida) {
+					t.Fatalf("esperava OrdenacaoInvalidaError, obteve %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := ordenacao.String(); got != tt.want {
+				t.Errorf("String() = %q, esperava %q", got, tt.want)
+			}
+			if got := ordenacao.SQL(); got != tt.wantSQL {
+				t.Errorf("SQL() = %q, esperava %q", got, tt.wantSQL)
+			}
+		})
+	}
+}
+
+func TestPageableOrdenacaoDesempateDeterministico(t *testing.T) {
+	campos := CamposOrdenacao{"nome": "nome", "id": "id", "codigo": "id", "zid": "id"}
+	for i := 0; i < 50; i++ {
+		ordenacao, err := (&Pageable{Sort: "nome"}).Ordenacao(campos, "", "id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ordenacao.String(); got != "nome,asc;codigo,asc" {
+			t.Fatalf("String() = %q, esperava %q", got, "nome,asc;codigo,asc")
+		}
+	}
+}